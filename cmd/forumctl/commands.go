@@ -0,0 +1,289 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"github.com/mailru/easyjson"
+	"strconv"
+	"strings"
+	"techpark_db/internal/domain/entity"
+	"techpark_db/pkg/client"
+)
+
+var ErrConfirm = errors.New("service clear deletes all data, pass --yes to confirm")
+
+var commands = map[string]command{
+	"user create":    {"<nickname> --email email [--fullname name] [--about text]", userCreate},
+	"user show":      {"<nickname>", userShow},
+	"user update":    {"<nickname> [--email email] [--fullname name] [--about text] [--if-match etag]", userUpdate},
+	"forum create":   {"<slug> --title title --user nickname", forumCreate},
+	"forum show":     {"<slug>", forumShow},
+	"forum threads":  {"<slug> [--limit n] [--since time] [--desc] [--all]", forumThreads},
+	"thread show":    {"<slug_or_id>", threadShow},
+	"thread posts":   {"<slug_or_id> [--sort flat|tree|parent_tree] [--limit n] [--since id] [--desc] [--all]", threadPosts},
+	"post show":      {"<id> [--related user,forum,thread]", postShow},
+	"post edit":      {"<id> --message text [--if-match etag]", postEdit},
+	"service status": {"", serviceStatus},
+	"service clear":  {"--yes", serviceClear},
+	"service cache":  {"", serviceCache},
+}
+
+// created prints the entity a create conflicted with before its error.
+func (a *app) created(v easyjson.Marshaler, err error) error {
+	var e *client.Error
+	if errors.As(err, &e) && e.Existing != nil {
+		if printErr := a.out.print(e.Existing.(easyjson.Marshaler)); printErr != nil {
+			return printErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return a.out.print(v)
+}
+
+func userCreate(a *app, args []string) error {
+	flags := flag.NewFlagSet("user create", flag.ContinueOnError)
+	var user entity.CreateUser
+	flags.StringVar(&user.Email, "email", "", "email, required")
+	flags.StringVar(&user.Fullname, "fullname", "", "full name")
+	flags.StringVar(&user.About, "about", "", "about the user")
+	pos, err := parseArgs(flags, args, "nickname")
+	if err != nil {
+		return err
+	}
+
+	created, err := a.client.CreateUser(a.ctx, pos[0], user)
+	return a.created(created, err)
+}
+
+func userShow(a *app, args []string) error {
+	flags := flag.NewFlagSet("user show", flag.ContinueOnError)
+	pos, err := parseArgs(flags, args, "nickname")
+	if err != nil {
+		return err
+	}
+
+	user, err := a.client.User(a.ctx, pos[0])
+	if err != nil {
+		return err
+	}
+	return a.out.print(user)
+}
+
+func userUpdate(a *app, args []string) error {
+	flags := flag.NewFlagSet("user update", flag.ContinueOnError)
+	var update entity.UpdateUser
+	flags.StringVar(&update.Email, "email", "", "new email")
+	flags.StringVar(&update.Fullname, "fullname", "", "new full name")
+	flags.StringVar(&update.About, "about", "", "new about")
+	ifMatch := flags.String("if-match", "", "update only if the user still has this ETag")
+	pos, err := parseArgs(flags, args, "nickname")
+	if err != nil {
+		return err
+	}
+
+	var opts []client.CallOption
+	if *ifMatch != "" {
+		opts = append(opts, client.IfMatch(*ifMatch))
+	}
+	user, err := a.client.UpdateUser(a.ctx, pos[0], update, opts...)
+	if err != nil {
+		return err
+	}
+	return a.out.print(user)
+}
+
+func forumCreate(a *app, args []string) error {
+	flags := flag.NewFlagSet("forum create", flag.ContinueOnError)
+	var forum entity.CreateForum
+	flags.StringVar(&forum.Title, "title", "", "title, required")
+	flags.StringVar(&forum.User, "user", "", "nickname of the owner, required")
+	pos, err := parseArgs(flags, args, "slug")
+	if err != nil {
+		return err
+	}
+	forum.Slug = pos[0]
+
+	created, err := a.client.CreateForum(a.ctx, forum)
+	return a.created(created, err)
+}
+
+func forumShow(a *app, args []string) error {
+	flags := flag.NewFlagSet("forum show", flag.ContinueOnError)
+	pos, err := parseArgs(flags, args, "slug")
+	if err != nil {
+		return err
+	}
+
+	forum, err := a.client.Forum(a.ctx, pos[0])
+	if err != nil {
+		return err
+	}
+	return a.out.print(forum)
+}
+
+func forumThreads(a *app, args []string) error {
+	flags := flag.NewFlagSet("forum threads", flag.ContinueOnError)
+	list, all := listFlags(flags)
+	pos, err := parseArgs(flags, args, "slug")
+	if err != nil {
+		return err
+	}
+
+	if !*all {
+		threads, err := a.client.ForumThreads(a.ctx, pos[0], *list)
+		if err != nil {
+			return err
+		}
+		return a.out.print(threads)
+	}
+
+	threads := entity.Threads{}
+	it := a.client.ForumThreadsIter(pos[0], *list)
+	for it.Next(a.ctx) {
+		threads = append(threads, it.Thread())
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+	return a.out.print(threads)
+}
+
+func threadShow(a *app, args []string) error {
+	flags := flag.NewFlagSet("thread show", flag.ContinueOnError)
+	pos, err := parseArgs(flags, args, "slug_or_id")
+	if err != nil {
+		return err
+	}
+
+	thread, err := a.client.Thread(a.ctx, pos[0])
+	if err != nil {
+		return err
+	}
+	return a.out.print(thread)
+}
+
+func threadPosts(a *app, args []string) error {
+	flags := flag.NewFlagSet("thread posts", flag.ContinueOnError)
+	list, all := listFlags(flags)
+	flags.StringVar(&list.Sort, "sort", "", "flat, tree or parent_tree")
+	pos, err := parseArgs(flags, args, "slug_or_id")
+	if err != nil {
+		return err
+	}
+
+	if !*all {
+		posts, err := a.client.ThreadPosts(a.ctx, pos[0], *list)
+		if err != nil {
+			return err
+		}
+		return a.out.print(posts)
+	}
+
+	posts := entity.Posts{}
+	it := a.client.ThreadPostsIter(pos[0], *list)
+	for it.Next(a.ctx) {
+		posts = append(posts, it.Post())
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+	return a.out.print(posts)
+}
+
+func postShow(a *app, args []string) error {
+	flags := flag.NewFlagSet("post show", flag.ContinueOnError)
+	related := flags.String("related", "", "comma separated: user, forum, thread")
+	pos, err := parseArgs(flags, args, "id")
+	if err != nil {
+		return err
+	}
+	id, err := strconv.Atoi(pos[0])
+	if err != nil {
+		return errors.New("post id must be a number")
+	}
+
+	var relatedList []string
+	if *related != "" {
+		relatedList = strings.Split(*related, ",")
+	}
+	details, err := a.client.Post(a.ctx, id, relatedList)
+	if err != nil {
+		return err
+	}
+	return a.out.print(details)
+}
+
+func postEdit(a *app, args []string) error {
+	flags := flag.NewFlagSet("post edit", flag.ContinueOnError)
+	var update entity.UpdatePost
+	flags.StringVar(&update.Message, "message", "", "new message, required")
+	ifMatch := flags.String("if-match", "", "edit only if the post still has this ETag")
+	pos, err := parseArgs(flags, args, "id")
+	if err != nil {
+		return err
+	}
+	id, err := strconv.Atoi(pos[0])
+	if err != nil {
+		return errors.New("post id must be a number")
+	}
+
+	var opts []client.CallOption
+	if *ifMatch != "" {
+		opts = append(opts, client.IfMatch(*ifMatch))
+	}
+	post, err := a.client.UpdatePost(a.ctx, id, update, opts...)
+	if err != nil {
+		return err
+	}
+	return a.out.print(post)
+}
+
+func serviceStatus(a *app, args []string) error {
+	flags := flag.NewFlagSet("service status", flag.ContinueOnError)
+	if _, err := parseArgs(flags, args); err != nil {
+		return err
+	}
+
+	status, err := a.client.Status(a.ctx)
+	if err != nil {
+		return err
+	}
+	return a.out.print(status)
+}
+
+func serviceClear(a *app, args []string) error {
+	flags := flag.NewFlagSet("service clear", flag.ContinueOnError)
+	yes := flags.Bool("yes", false, "confirm deleting all data")
+	if _, err := parseArgs(flags, args); err != nil {
+		return err
+	}
+	if !*yes {
+		return ErrConfirm
+	}
+	return a.client.Clear(a.ctx)
+}
+
+func serviceCache(a *app, args []string) error {
+	flags := flag.NewFlagSet("service cache", flag.ContinueOnError)
+	if _, err := parseArgs(flags, args); err != nil {
+		return err
+	}
+
+	status, err := a.client.CacheStatus(a.ctx)
+	if err != nil {
+		return err
+	}
+	return a.out.print(status)
+}
+
+// listFlags are the paging flags of the list commands.
+func listFlags(flags *flag.FlagSet) (*client.ListOptions, *bool) {
+	var list client.ListOptions
+	flags.IntVar(&list.Limit, "limit", 0, "page size, the default of the server if 0")
+	flags.StringVar(&list.Since, "since", "", "continue after this value")
+	flags.BoolVar(&list.Desc, "desc", false, "reverse the order")
+	all := flags.Bool("all", false, "walk all the pages")
+	return &list, all
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"errors"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	CONFIG_ENV      = "FORUMCTL_CONFIG"
+	PROFILE_ENV     = "FORUMCTL_PROFILE"
+	CONFIG_FILE     = ".forumctl.yaml"
+	DEFAULT_PROFILE = "default"
+	DEFAULT_URL     = "http://localhost:5000/api"
+	DEFAULT_OUTPUT  = OUTPUT_TABLE
+	DEFAULT_TIMEOUT = 30 * time.Second
+	DEFAULT_RETRIES = 3
+)
+
+// Config is the profile file, by default ~/.forumctl.yaml:
+//
+//	profile: local
+//	profiles:
+//	  local:
+//	    url: http://localhost:5000/api
+//	    output: table
+//	  prod:
+//	    url: https://forum.example.com/api
+//	    output: json
+//	    timeout: 10s
+//	    retries: 5
+type Config struct {
+	Profile  string             `yaml:"profile"`
+	Profiles map[string]Profile `yaml:"profiles"`
+}
+
+type Profile struct {
+	URL     string        `yaml:"url"`
+	Output  string        `yaml:"output"`
+	Timeout time.Duration `yaml:"timeout"`
+	Retries *int          `yaml:"retries"`
+}
+
+func configPath() string {
+	if path := os.Getenv(CONFIG_ENV); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return CONFIG_FILE
+	}
+	return filepath.Join(home, CONFIG_FILE)
+}
+
+// loadProfile reads the named profile, the profile of the file when name
+// is empty. A missing file is no error, the defaults are used then.
+func loadProfile(path string, name string) (Profile, error) {
+	profile := Profile{
+		URL:     DEFAULT_URL,
+		Output:  DEFAULT_OUTPUT,
+		Timeout: DEFAULT_TIMEOUT,
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		if name != "" && name != DEFAULT_PROFILE {
+			return profile, errors.New("no config file " + path + " for profile " + name)
+		}
+		return profile, nil
+	}
+	if err != nil {
+		return profile, err
+	}
+
+	var config Config
+	if err := yaml.UnmarshalStrict(data, &config); err != nil {
+		return profile, errors.New(path + ": " + err.Error())
+	}
+	if name == "" {
+		name = config.Profile
+	}
+	if name == "" {
+		name = DEFAULT_PROFILE
+	}
+	found, ok := config.Profiles[name]
+	if !ok {
+		if name != DEFAULT_PROFILE {
+			return profile, errors.New("no profile " + name + " in " + path)
+		}
+		return profile, nil
+	}
+
+	if found.URL != "" {
+		profile.URL = found.URL
+	}
+	if found.Output != "" {
+		profile.Output = found.Output
+	}
+	if found.Timeout != 0 {
+		profile.Timeout = found.Timeout
+	}
+	profile.Retries = found.Retries
+	return profile, nil
+}
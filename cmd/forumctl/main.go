@@ -0,0 +1,141 @@
+// Command forumctl administers the forum over its HTTP API:
+//
+//	forumctl [--profile name] [--url url] [-o table|json|yaml] <group> <command> [args]
+//
+// The defaults of the flags come from the profile file, see Config.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"techpark_db/pkg/client"
+)
+
+// app is what the commands run with.
+type app struct {
+	client *client.Client
+	out    *printer
+	ctx    context.Context
+}
+
+type command struct {
+	usage string
+	run   func(a *app, args []string) error
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		if err != flag.ErrHelp {
+			fmt.Fprintln(os.Stderr, "forumctl:", err)
+			printProblem(err)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("forumctl", flag.ContinueOnError)
+	configFile := flags.String("config", configPath(), "profile file")
+	profileName := flags.String("profile", os.Getenv(PROFILE_ENV), "profile of the config file")
+	url := flags.String("url", "", "base URL of the API, overrides the profile")
+	output := flags.String("output", "", "output format: table, json or yaml")
+	flags.StringVar(output, "o", "", "shorthand for -output")
+	flags.Usage = func() { usage(flags) }
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	rest := flags.Args()
+	if len(rest) < 2 {
+		usage(flags)
+		return flag.ErrHelp
+	}
+	cmd, ok := commands[rest[0]+" "+rest[1]]
+	if !ok {
+		usage(flags)
+		return errors.New("unknown command " + rest[0] + " " + rest[1])
+	}
+
+	profile, err := loadProfile(*configFile, *profileName)
+	if err != nil {
+		return err
+	}
+	if *url != "" {
+		profile.URL = *url
+	}
+	if *output != "" {
+		profile.Output = *output
+	}
+	out, err := newPrinter(stdout, profile.Output)
+	if err != nil {
+		return err
+	}
+
+	opts := []client.Option{}
+	if profile.Retries != nil {
+		opts = append(opts, client.WithRetries(*profile.Retries))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), profile.Timeout)
+	defer cancel()
+
+	a := &app{
+		client: client.New(profile.URL, opts...),
+		out:    out,
+		ctx:    ctx,
+	}
+	return cmd.run(a, rest[2:])
+}
+
+func usage(flags *flag.FlagSet) {
+	w := flags.Output()
+	fmt.Fprintln(w, "Usage: forumctl [flags] <group> <command> [args]")
+	fmt.Fprintln(w, "\nCommands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintln(w, "  "+strings.TrimSpace(name+" "+commands[name].usage))
+	}
+	fmt.Fprintln(w, "\nFlags:")
+	flags.PrintDefaults()
+}
+
+// printProblem lists the invalid fields of a rejected request.
+func printProblem(err error) {
+	var e *client.Error
+	if !errors.As(err, &e) {
+		return
+	}
+	for _, field := range e.Problem.Errors {
+		fmt.Fprintf(os.Stderr, "  %s: %s\n", field.Field, field.Message)
+	}
+}
+
+// parseArgs parses the flags of a command, before or after its arguments,
+// and checks the count of the arguments.
+func parseArgs(flags *flag.FlagSet, args []string, names ...string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+	if len(positional) != len(names) {
+		return nil, errors.New("expected arguments: " + strings.Join(names, " "))
+	}
+	return positional, nil
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/mailru/easyjson"
+	"gopkg.in/yaml.v2"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+const (
+	OUTPUT_TABLE = "table"
+	OUTPUT_JSON  = "json"
+	OUTPUT_YAML  = "yaml"
+)
+
+var ErrOutput = errors.New("output must be table, json or yaml")
+
+// printer writes the entities in the output format. The entities go through
+// their JSON encoding, so every format shows the fields of the API.
+type printer struct {
+	w      io.Writer
+	format string
+}
+
+func newPrinter(w io.Writer, format string) (*printer, error) {
+	switch format {
+	case OUTPUT_TABLE, OUTPUT_JSON, OUTPUT_YAML:
+		return &printer{w: w, format: format}, nil
+	}
+	return nil, ErrOutput
+}
+
+func (p *printer) print(v easyjson.Marshaler) error {
+	data, err := easyjson.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	switch p.format {
+	case OUTPUT_JSON:
+		var out bytes.Buffer
+		if err := json.Indent(&out, data, "", "  "); err != nil {
+			return err
+		}
+		out.WriteByte('\n')
+		_, err = p.w.Write(out.Bytes())
+		return err
+	case OUTPUT_YAML:
+		// JSON is YAML, MapSlice keeps the order of the fields
+		var doc interface{}
+		if data[0] == '[' {
+			var list []yaml.MapSlice
+			err = yaml.Unmarshal(data, &list)
+			doc = list
+		} else {
+			var object yaml.MapSlice
+			err = yaml.Unmarshal(data, &object)
+			doc = object
+		}
+		if err != nil {
+			return err
+		}
+		out, err := yaml.Marshal(doc)
+		if err != nil {
+			return err
+		}
+		_, err = p.w.Write(out)
+		return err
+	}
+	return p.table(data)
+}
+
+// table prints a list as rows under the field names. An object is a table
+// of one row, the objects it holds get titled tables of their own.
+func (p *printer) table(data []byte) error {
+	var rows []yaml.MapSlice
+	if data[0] == '[' {
+		if err := yaml.Unmarshal(data, &rows); err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			_, err := fmt.Fprintln(p.w, "(none)")
+			return err
+		}
+		return p.rows(rows)
+	}
+
+	var object yaml.MapSlice
+	if err := yaml.Unmarshal(data, &object); err != nil {
+		return err
+	}
+	return p.object(object)
+}
+
+func (p *printer) object(object yaml.MapSlice) error {
+	var scalars yaml.MapSlice
+	var nested []yaml.MapItem
+	for _, item := range object {
+		switch item.Value.(type) {
+		case yaml.MapSlice:
+			nested = append(nested, item)
+		case nil:
+		default:
+			scalars = append(scalars, item)
+		}
+	}
+	if len(scalars) > 0 {
+		if err := p.rows([]yaml.MapSlice{scalars}); err != nil {
+			return err
+		}
+	}
+	for i, item := range nested {
+		if len(scalars) > 0 || i > 0 {
+			fmt.Fprintln(p.w)
+		}
+		fmt.Fprintln(p.w, strings.ToUpper(fmt.Sprint(item.Key))+":")
+		if err := p.object(item.Value.(yaml.MapSlice)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *printer) rows(rows []yaml.MapSlice) error {
+	tw := tabwriter.NewWriter(p.w, 0, 4, 2, ' ', 0)
+	header := make([]string, 0, len(rows[0]))
+	for _, item := range rows[0] {
+		header = append(header, strings.ToUpper(fmt.Sprint(item.Key)))
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	// the columns are the fields of the first row, omitted fields are empty
+	for _, row := range rows {
+		cells := make([]string, 0, len(rows[0]))
+		for _, column := range rows[0] {
+			var value interface{}
+			for _, item := range row {
+				if item.Key == column.Key {
+					value = item.Value
+				}
+			}
+			cells = append(cells, cell(value))
+		}
+		fmt.Fprintln(tw, strings.Join(cells, "\t"))
+	}
+	return tw.Flush()
+}
+
+// cell keeps a row on one line, long messages are cut.
+func cell(value interface{}) string {
+	var text string
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		text = v
+	case yaml.MapSlice, []interface{}:
+		data, _ := json.Marshal(jsonValue(v))
+		text = string(data)
+	default:
+		text = fmt.Sprint(v)
+	}
+	text = strings.Join(strings.Fields(text), " ")
+	if runes := []rune(text); len(runes) > 60 {
+		text = string(runes[:57]) + "..."
+	}
+	return text
+}
+
+// jsonValue converts the YAML maps back for encoding/json.
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case yaml.MapSlice:
+		object := make(map[string]interface{}, len(v))
+		for _, item := range v {
+			object[fmt.Sprint(item.Key)] = jsonValue(item.Value)
+		}
+		return object
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			list[i] = jsonValue(item)
+		}
+		return list
+	}
+	return value
+}
@@ -0,0 +1,161 @@
+// Command loadgen seeds the forum through its HTTP API and replays a mix of
+// requests against it, reporting the latencies by route:
+//
+//	loadgen -seed -data data.json                  # seed, save the dataset
+//	loadgen -data data.json -mix read -save a.json # replay the read mix
+//	loadgen -data data.json -compare a.json        # replay, compare with a
+//
+// Start the server without RATE_LIMIT_POLICIES, the rate limits reject a load
+// from one address.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"techpark_db/pkg/client"
+	"time"
+)
+
+const (
+	DEFAULT_URL         = "http://localhost:5000/api"
+	DEFAULT_CONCURRENCY = 16
+	DEFAULT_DURATION    = 30 * time.Second
+	REQUEST_TIMEOUT     = 30 * time.Second
+)
+
+var ErrEmptyDataset = errors.New("dataset has no users, forums, threads or posts, seed first")
+
+func main() {
+	url := flag.String("url", DEFAULT_URL, "base URL of the API")
+	data := flag.String("data", "loadgen-data.json", "dataset file, written by -seed and read by the replay")
+	seed := flag.Bool("seed", false, "seed the data before the replay")
+	clear := flag.Bool("clear", false, "delete all data before seeding")
+	users := flag.Int("users", 1000, "users to seed")
+	forums := flag.Int("forums", 20, "forums to seed")
+	threads := flag.Int("threads", 50, "threads to seed per forum")
+	posts := flag.Int("posts", 200, "posts to seed per thread")
+	depth := flag.Int("depth", 30, "max depth of the seeded post trees")
+	batch := flag.Int("batch", 50, "posts per create request of the seed")
+	votes := flag.Int("votes", 20, "votes to seed per thread")
+	concurrency := flag.Int("concurrency", DEFAULT_CONCURRENCY, "parallel requests")
+	duration := flag.Duration("duration", DEFAULT_DURATION, "length of the replay, 0 seeds only")
+	mixSpec := flag.String("mix", DEFAULT_MIX, "read, mixed, write or op=weight pairs of: "+fmt.Sprint(opNames()))
+	save := flag.String("save", "", "save the result of the replay to this file")
+	compare := flag.String("compare", "", "compare the replay with a result saved by -save")
+	flag.Parse()
+
+	// the client keeps a connection per worker and never retries, a retry
+	// would hide the latency of the failed request
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = *concurrency
+	c := client.New(*url,
+		client.WithHTTPClient(&http.Client{Transport: transport, Timeout: REQUEST_TIMEOUT}),
+		client.WithRetries(0),
+	)
+	ctx := context.Background()
+
+	if *seed {
+		if *clear {
+			if err := c.Clear(ctx); err != nil {
+				fatal(err)
+			}
+		}
+		config := SeedConfig{
+			Users:          *users,
+			Forums:         *forums,
+			ThreadsByForum: *threads,
+			PostsByThread:  *posts,
+			MaxDepth:       *depth,
+			PostBatch:      *batch,
+			VotesByThread:  *votes,
+		}
+		s := &seeder{
+			client:      c,
+			concurrency: *concurrency,
+			prefix:      "lg" + strconv.FormatInt(time.Now().Unix(), 36),
+		}
+		started := time.Now()
+		dataset, err := s.seed(ctx, config)
+		if err != nil {
+			fatal(err)
+		}
+		if err := dataset.save(*data); err != nil {
+			fatal(err)
+		}
+		fmt.Printf("seeded %d users, %d forums, %d threads, %d posts in %s\n",
+			len(dataset.Users), len(dataset.Forums), len(dataset.Threads), len(dataset.Posts),
+			time.Since(started).Round(time.Millisecond))
+	}
+	if *duration == 0 {
+		return
+	}
+
+	dataset, err := loadDataset(*data)
+	if err != nil {
+		fatal(err)
+	}
+	if len(dataset.Users) == 0 || len(dataset.Forums) == 0 || len(dataset.Threads) == 0 || len(dataset.Posts) == 0 {
+		fatal(ErrEmptyDataset)
+	}
+	m, err := parseMix(*mixSpec)
+	if err != nil {
+		fatal(err)
+	}
+
+	rec := newRecorder()
+	started := time.Now()
+	replay(c, dataset, m, rec, *concurrency, *duration)
+	run := rec.result(started, time.Since(started), *concurrency, *mixSpec)
+
+	run.print(os.Stdout)
+	if *compare != "" {
+		previous, err := loadRun(*compare)
+		if err != nil {
+			fatal(err)
+		}
+		run.compare(os.Stdout, previous)
+	}
+	if *save != "" {
+		if err := run.save(*save); err != nil {
+			fatal(err)
+		}
+	}
+}
+
+// replay runs the mix with concurrency workers for duration. The requests
+// running at the end are cancelled and not recorded.
+func replay(c *client.Client, d *Dataset, m *mix, rec *recorder, concurrency int, duration time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rnd := rand.New(rand.NewSource(seed))
+			for ctx.Err() == nil {
+				o := ops[m.pick(rnd)]
+				start := time.Now()
+				err := o.run(ctx, c, d, rnd)
+				if ctx.Err() != nil {
+					return
+				}
+				rec.record(o.route, time.Since(start), err)
+			}
+		}(rand.Int63())
+	}
+	wg.Wait()
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "loadgen:", err)
+	os.Exit(1)
+}
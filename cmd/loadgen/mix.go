@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"techpark_db/internal/domain/entity"
+	"techpark_db/pkg/client"
+)
+
+// op is one kind of request of the replay, reported under its route.
+type op struct {
+	route string
+	run   func(ctx context.Context, c *client.Client, d *Dataset, rnd *rand.Rand) error
+}
+
+var ops = map[string]op{
+	"forum_details": {"GET /forum/{slug}/details", func(ctx context.Context, c *client.Client, d *Dataset, rnd *rand.Rand) error {
+		_, err := c.Forum(ctx, pickString(rnd, d.Forums))
+		return err
+	}},
+	"forum_threads": {"GET /forum/{slug}/threads", func(ctx context.Context, c *client.Client, d *Dataset, rnd *rand.Rand) error {
+		_, err := c.ForumThreads(ctx, pickString(rnd, d.Forums), client.ListOptions{Limit: 20, Desc: rnd.Intn(2) == 0})
+		return err
+	}},
+	"forum_users": {"GET /forum/{slug}/users", func(ctx context.Context, c *client.Client, d *Dataset, rnd *rand.Rand) error {
+		_, err := c.ForumUsers(ctx, pickString(rnd, d.Forums), client.ListOptions{Limit: 20, Desc: rnd.Intn(2) == 0})
+		return err
+	}},
+	"thread_details": {"GET /thread/{slug_or_id}/details", func(ctx context.Context, c *client.Client, d *Dataset, rnd *rand.Rand) error {
+		_, err := c.Thread(ctx, strconv.Itoa(pickInt(rnd, d.Threads)))
+		return err
+	}},
+	"thread_posts_flat":        {"GET /thread/{slug_or_id}/posts?sort=flat", threadPosts("flat")},
+	"thread_posts_tree":        {"GET /thread/{slug_or_id}/posts?sort=tree", threadPosts("tree")},
+	"thread_posts_parent_tree": {"GET /thread/{slug_or_id}/posts?sort=parent_tree", threadPosts("parent_tree")},
+	"post_details": {"GET /post/{id}/details", func(ctx context.Context, c *client.Client, d *Dataset, rnd *rand.Rand) error {
+		var related []string
+		if rnd.Intn(2) == 0 {
+			related = []string{"user", "forum", "thread"}
+		}
+		_, err := c.Post(ctx, pickInt(rnd, d.Posts), related)
+		return err
+	}},
+	"user_profile": {"GET /user/{nickname}/profile", func(ctx context.Context, c *client.Client, d *Dataset, rnd *rand.Rand) error {
+		_, err := c.User(ctx, pickString(rnd, d.Users))
+		return err
+	}},
+	"create_posts": {"POST /thread/{slug_or_id}/create", func(ctx context.Context, c *client.Client, d *Dataset, rnd *rand.Rand) error {
+		posts := make(entity.CreatePosts, 1+rnd.Intn(10))
+		for i := range posts {
+			posts[i] = entity.CreatePost{Author: pickString(rnd, d.Users), Message: text(rnd, 5+rnd.Intn(20))}
+		}
+		_, err := c.CreatePosts(ctx, strconv.Itoa(pickInt(rnd, d.Threads)), posts)
+		return err
+	}},
+	"vote": {"POST /thread/{slug_or_id}/vote", func(ctx context.Context, c *client.Client, d *Dataset, rnd *rand.Rand) error {
+		vote := entity.Vote{Nickname: pickString(rnd, d.Users), Voice: 1 - 2*rnd.Intn(2)}
+		_, err := c.Vote(ctx, strconv.Itoa(pickInt(rnd, d.Threads)), vote)
+		return err
+	}},
+	"post_edit": {"POST /post/{id}/details", func(ctx context.Context, c *client.Client, d *Dataset, rnd *rand.Rand) error {
+		_, err := c.UpdatePost(ctx, pickInt(rnd, d.Posts), entity.UpdatePost{Message: text(rnd, 10)})
+		return err
+	}},
+	"user_update": {"POST /user/{nickname}/profile", func(ctx context.Context, c *client.Client, d *Dataset, rnd *rand.Rand) error {
+		_, err := c.UpdateUser(ctx, pickString(rnd, d.Users), entity.UpdateUser{About: text(rnd, 8)})
+		return err
+	}},
+}
+
+func threadPosts(sort string) func(ctx context.Context, c *client.Client, d *Dataset, rnd *rand.Rand) error {
+	return func(ctx context.Context, c *client.Client, d *Dataset, rnd *rand.Rand) error {
+		list := client.ListOptions{Limit: 20 + rnd.Intn(80), Sort: sort, Desc: rnd.Intn(2) == 0}
+		_, err := c.ThreadPosts(ctx, strconv.Itoa(pickInt(rnd, d.Threads)), list)
+		return err
+	}
+}
+
+// The preset mixes, -mix takes a preset or op=weight pairs.
+var presets = map[string]string{
+	"read":  "forum_details=10,forum_threads=10,forum_users=5,thread_details=15,thread_posts_flat=10,thread_posts_tree=10,thread_posts_parent_tree=10,post_details=20,user_profile=10",
+	"mixed": "forum_details=5,forum_threads=10,forum_users=5,thread_details=10,thread_posts_flat=10,thread_posts_tree=10,thread_posts_parent_tree=10,post_details=15,user_profile=5,create_posts=10,vote=5,post_edit=3,user_update=2",
+	"write": "create_posts=60,vote=25,post_edit=10,user_update=5",
+}
+
+const DEFAULT_MIX = "mixed"
+
+// mix picks the ops by their weights.
+type mix struct {
+	names   []string
+	cumsum  []int
+	weights map[string]int
+}
+
+func parseMix(spec string) (*mix, error) {
+	if preset, ok := presets[spec]; ok {
+		spec = preset
+	}
+
+	m := &mix{weights: make(map[string]int)}
+	for _, pair := range strings.Split(spec, ",") {
+		parts := strings.SplitN(strings.TrimSpace(pair), "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.New("mix entry " + pair + " is not op=weight")
+		}
+		if _, ok := ops[parts[0]]; !ok {
+			return nil, errors.New("unknown op " + parts[0] + ", expected one of " + strings.Join(opNames(), ", "))
+		}
+		weight, err := strconv.Atoi(parts[1])
+		if err != nil || weight < 0 {
+			return nil, errors.New("weight of " + parts[0] + " must be a non-negative number")
+		}
+		m.weights[parts[0]] += weight
+	}
+
+	total := 0
+	for _, name := range opNames() {
+		if weight := m.weights[name]; weight > 0 {
+			total += weight
+			m.names = append(m.names, name)
+			m.cumsum = append(m.cumsum, total)
+		}
+	}
+	if total == 0 {
+		return nil, errors.New("mix has no op with a weight")
+	}
+	return m, nil
+}
+
+func (m *mix) pick(rnd *rand.Rand) string {
+	n := rnd.Intn(m.cumsum[len(m.cumsum)-1])
+	return m.names[sort.SearchInts(m.cumsum, n+1)]
+}
+
+func opNames() []string {
+	names := make([]string, 0, len(ops))
+	for name := range ops {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func pickString(rnd *rand.Rand, values []string) string {
+	return values[rnd.Intn(len(values))]
+}
+
+func pickInt(rnd *rand.Rand, values []int) int {
+	return values[rnd.Intn(len(values))]
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// recorder collects the latencies of the replay by route.
+type recorder struct {
+	mu        sync.Mutex
+	latencies map[string][]time.Duration
+	errors    map[string]int
+}
+
+func newRecorder() *recorder {
+	return &recorder{
+		latencies: make(map[string][]time.Duration),
+		errors:    make(map[string]int),
+	}
+}
+
+func (r *recorder) record(route string, latency time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.latencies[route] = append(r.latencies[route], latency)
+	if err != nil {
+		r.errors[route]++
+	}
+}
+
+// Run is the result of a replay, saved with -save for a later -compare.
+type Run struct {
+	Started     time.Time              `json:"started"`
+	Duration    float64                `json:"duration_s"`
+	Concurrency int                    `json:"concurrency"`
+	Mix         string                 `json:"mix"`
+	Routes      map[string]RouteResult `json:"routes"`
+	Total       RouteResult            `json:"total"`
+}
+
+// RouteResult holds the latencies in milliseconds.
+type RouteResult struct {
+	Requests int     `json:"requests"`
+	Errors   int     `json:"errors"`
+	RPS      float64 `json:"rps"`
+	P50      float64 `json:"p50_ms"`
+	P90      float64 `json:"p90_ms"`
+	P99      float64 `json:"p99_ms"`
+	Max      float64 `json:"max_ms"`
+}
+
+func (r *recorder) result(started time.Time, duration time.Duration, concurrency int, mixSpec string) *Run {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	run := &Run{
+		Started:     started,
+		Duration:    duration.Seconds(),
+		Concurrency: concurrency,
+		Mix:         mixSpec,
+		Routes:      make(map[string]RouteResult, len(r.latencies)),
+	}
+	var all []time.Duration
+	errors := 0
+	for route, latencies := range r.latencies {
+		run.Routes[route] = summarize(latencies, r.errors[route], duration)
+		all = append(all, latencies...)
+		errors += r.errors[route]
+	}
+	run.Total = summarize(all, errors, duration)
+	return run
+}
+
+func summarize(latencies []time.Duration, errors int, duration time.Duration) RouteResult {
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	result := RouteResult{
+		Requests: len(latencies),
+		Errors:   errors,
+	}
+	if len(latencies) == 0 {
+		return result
+	}
+	result.RPS = float64(len(latencies)) / duration.Seconds()
+	result.P50 = percentile(latencies, 0.50)
+	result.P90 = percentile(latencies, 0.90)
+	result.P99 = percentile(latencies, 0.99)
+	result.Max = millis(latencies[len(latencies)-1])
+	return result
+}
+
+// percentile takes the nearest rank of the sorted latencies.
+func percentile(sorted []time.Duration, q float64) float64 {
+	rank := int(q*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return millis(sorted[rank])
+}
+
+func millis(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func loadRun(path string) (*Run, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var run Run
+	if err := json.Unmarshal(data, &run); err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (run *Run) save(path string) error {
+	data, err := json.MarshalIndent(run, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+func (run *Run) routes() []string {
+	routes := make([]string, 0, len(run.Routes))
+	for route := range run.Routes {
+		routes = append(routes, route)
+	}
+	sort.Strings(routes)
+	return routes
+}
+
+func (run *Run) print(w io.Writer) error {
+	fmt.Fprintf(w, "%d workers for %.1fs, mix %s\n\n", run.Concurrency, run.Duration, run.Mix)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ROUTE\tREQUESTS\tERRORS\tRPS\tP50 MS\tP90 MS\tP99 MS\tMAX MS\t")
+	for _, route := range run.routes() {
+		printResult(tw, route, run.Routes[route])
+	}
+	printResult(tw, "TOTAL", run.Total)
+	return tw.Flush()
+}
+
+func printResult(w io.Writer, name string, r RouteResult) {
+	fmt.Fprintf(w, "%s\t%d\t%d\t%.1f\t%.2f\t%.2f\t%.2f\t%.2f\t\n",
+		name, r.Requests, r.Errors, r.RPS, r.P50, r.P90, r.P99, r.Max)
+}
+
+// compare prints the change of the throughput and the percentiles against
+// a previous run. Negative latency changes are improvements.
+func (run *Run) compare(w io.Writer, previous *Run) error {
+	fmt.Fprintf(w, "\nagainst the run of %s (%d workers, mix %s)\n\n",
+		previous.Started.Format(time.RFC3339), previous.Concurrency, previous.Mix)
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ROUTE\tRPS\tP50\tP90\tP99\t")
+	for _, route := range run.routes() {
+		before, ok := previous.Routes[route]
+		if !ok {
+			fmt.Fprintf(tw, "%s\tnew\t\t\t\t\n", route)
+			continue
+		}
+		printDelta(tw, route, before, run.Routes[route])
+	}
+	printDelta(tw, "TOTAL", previous.Total, run.Total)
+	return tw.Flush()
+}
+
+func printDelta(w io.Writer, name string, before RouteResult, after RouteResult) {
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t\n", name,
+		delta(before.RPS, after.RPS), delta(before.P50, after.P50),
+		delta(before.P90, after.P90), delta(before.P99, after.P99))
+}
+
+func delta(before float64, after float64) string {
+	if before == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%+.1f%%", (after-before)/before*100)
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+	"techpark_db/internal/domain/entity"
+	"techpark_db/pkg/client"
+)
+
+// SeedConfig is the size of the seeded data.
+type SeedConfig struct {
+	Users          int
+	Forums         int
+	ThreadsByForum int
+	PostsByThread  int
+	MaxDepth       int
+	PostBatch      int
+	VotesByThread  int
+}
+
+// Dataset is what the replay picks its requests from. The seed saves it,
+// so later runs can replay against the same data without seeding.
+type Dataset struct {
+	Users   []string `json:"users"`
+	Forums  []string `json:"forums"`
+	Threads []int    `json:"threads"`
+	Posts   []int    `json:"posts"`
+}
+
+func loadDataset(path string) (*Dataset, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var dataset Dataset
+	if err := json.Unmarshal(data, &dataset); err != nil {
+		return nil, err
+	}
+	return &dataset, nil
+}
+
+func (d *Dataset) save(path string) error {
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0644)
+}
+
+var words = strings.Fields(`forum thread post reply query index vacuum replica tree
+	path vote user slug cache lock commit rollback page limit since order nested
+	depth parent child author message title database postgres latency throughput`)
+
+func text(rnd *rand.Rand, n int) string {
+	parts := make([]string, n)
+	for i := range parts {
+		parts[i] = words[rnd.Intn(len(words))]
+	}
+	return strings.Join(parts, " ")
+}
+
+// seeder creates the data with concurrency workers. The names carry the
+// prefix of the run, seeding twice does not conflict.
+type seeder struct {
+	client      *client.Client
+	concurrency int
+	prefix      string
+
+	mu      sync.Mutex
+	dataset Dataset
+}
+
+func (s *seeder) seed(ctx context.Context, config SeedConfig) (*Dataset, error) {
+	users := make([]string, config.Users)
+	err := s.parallel(ctx, config.Users, func(rnd *rand.Rand, i int) error {
+		nickname := s.prefix + "_u" + strconv.Itoa(i)
+		_, err := s.client.CreateUser(ctx, nickname, entity.CreateUser{
+			Fullname: text(rnd, 2),
+			About:    text(rnd, 8),
+			Email:    nickname + "@loadgen.test",
+		})
+		users[i] = nickname
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.dataset.Users = users
+
+	forums := make([]string, config.Forums)
+	err = s.parallel(ctx, config.Forums, func(rnd *rand.Rand, i int) error {
+		slug := s.prefix + "_f" + strconv.Itoa(i)
+		_, err := s.client.CreateForum(ctx, entity.CreateForum{
+			Title: text(rnd, 3),
+			User:  users[rnd.Intn(len(users))],
+			Slug:  slug,
+		})
+		forums[i] = slug
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.dataset.Forums = forums
+
+	threads := make([]int, config.Forums*config.ThreadsByForum)
+	err = s.parallel(ctx, len(threads), func(rnd *rand.Rand, i int) error {
+		thread, err := s.client.CreateThread(ctx, forums[i%len(forums)], entity.CreateThread{
+			Title:   text(rnd, 4),
+			Author:  users[rnd.Intn(len(users))],
+			Message: text(rnd, 20),
+			Slug:    s.prefix + "_t" + strconv.Itoa(i),
+		})
+		if err != nil {
+			return err
+		}
+		threads[i] = thread.Id
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.dataset.Threads = threads
+
+	err = s.parallel(ctx, len(threads), func(rnd *rand.Rand, i int) error {
+		return s.seedPosts(ctx, rnd, threads[i], config)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.parallel(ctx, len(threads), func(rnd *rand.Rand, i int) error {
+		thread := strconv.Itoa(threads[i])
+		voters := rnd.Perm(len(users))
+		if len(voters) > config.VotesByThread {
+			voters = voters[:config.VotesByThread]
+		}
+		for _, voter := range voters {
+			voice := 1
+			if rnd.Intn(3) == 0 {
+				voice = -1
+			}
+			if _, err := s.client.Vote(ctx, thread, entity.Vote{Nickname: users[voter], Voice: voice}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &s.dataset, nil
+}
+
+// seedPosts grows the tree of a thread batch by batch. Replies favour the
+// latest posts, so the branches reach MaxDepth.
+func (s *seeder) seedPosts(ctx context.Context, rnd *rand.Rand, thread int, config SeedConfig) error {
+	var ids []int
+	depths := make(map[int]int)
+	users := s.dataset.Users
+
+	for created := 0; created < config.PostsByThread; {
+		batch := config.PostBatch
+		if left := config.PostsByThread - created; left < batch {
+			batch = left
+		}
+
+		posts := make(entity.CreatePosts, batch)
+		for i := range posts {
+			parent := 0
+			if len(ids) > 0 && rnd.Intn(5) > 0 {
+				// the last quarter of the posts, the deepest ones
+				from := len(ids) * 3 / 4
+				candidate := ids[from+rnd.Intn(len(ids)-from)]
+				if depths[candidate] < config.MaxDepth {
+					parent = candidate
+				}
+			}
+			posts[i] = entity.CreatePost{
+				Parent:  parent,
+				Author:  users[rnd.Intn(len(users))],
+				Message: text(rnd, 5+rnd.Intn(30)),
+			}
+		}
+
+		saved, err := s.client.CreatePosts(ctx, strconv.Itoa(thread), posts)
+		if err != nil {
+			return err
+		}
+		for _, post := range saved {
+			depths[post.Id] = depths[post.Parent] + 1
+			ids = append(ids, post.Id)
+		}
+		created += batch
+	}
+
+	s.mu.Lock()
+	s.dataset.Posts = append(s.dataset.Posts, ids...)
+	s.mu.Unlock()
+	return nil
+}
+
+// parallel runs fn for 0..n-1 and returns the first error.
+func (s *seeder) parallel(ctx context.Context, n int, fn func(rnd *rand.Rand, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan int)
+	errs := make(chan error, s.concurrency)
+	var wg sync.WaitGroup
+	for w := 0; w < s.concurrency; w++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			rnd := rand.New(rand.NewSource(seed))
+			for i := range jobs {
+				if err := fn(rnd, i); err != nil {
+					errs <- err
+					cancel()
+					return
+				}
+			}
+		}(rand.Int63())
+	}
+
+feed:
+	for i := 0; i < n; i++ {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	select {
+	case err := <-errs:
+		return err
+	default:
+		return ctx.Err()
+	}
+}
@@ -5,7 +5,9 @@ CREATE UNLOGGED TABLE Users
     Nickname    citext      COLLATE "ucs_basic"  NOT NULL PRIMARY KEY,
     Fullname    varchar(100)      NOT NULL,
     About       text              NOT NULL,
-    Email       citext      NOT NULL UNIQUE
+    Email       citext      NOT NULL UNIQUE,
+    Version     int         NOT NULL DEFAULT 1,
+    Modified    timestamp WITH TIME ZONE NOT NULL DEFAULT now()
 );
 CREATE INDEX users_nickname ON Users using hash (Nickname);
 
@@ -13,22 +15,27 @@ CREATE UNLOGGED TABLE Forum
 (
     Slug         citext             NOT NULL PRIMARY KEY,
     Title        varchar(100)      NOT NULL,
-    Nickname     citext           NOT NULL REFERENCES Users(Nickname),
+    Nickname     citext           NOT NULL REFERENCES Users(Nickname) ON UPDATE CASCADE,
     Posts        int              NOT NULL DEFAULT 0,
-    Threads      int               NOT NULL DEFAULT 0
+    Threads      int               NOT NULL DEFAULT 0,
+    Version      int               NOT NULL DEFAULT 1,
+    Modified     timestamp WITH TIME ZONE NOT NULL DEFAULT now()
 );
 CREATE INDEX forum_slug ON Forum using hash (Slug);
+CREATE INDEX forum_nickname ON Forum (Nickname);
 
 CREATE UNLOGGED TABLE Thread
 (
     Id           serial            NOT NULL PRIMARY KEY,
     Title        varchar(100)      NOT NULL,
-    Author       citext             NOT NULL REFERENCES Users(Nickname),
+    Author       citext             NOT NULL REFERENCES Users(Nickname) ON UPDATE CASCADE,
     Forum        citext              NOT NULL REFERENCES Forum(Slug),
     Message      text              NOT NULL,
     Votes        int               NOT NULL DEFAULT 0,
     Slug         citext,
-    Created      timestamp WITH TIME ZONE NOT NULL
+    Created      timestamp WITH TIME ZONE NOT NULL,
+    Version      int               NOT NULL DEFAULT 1,
+    Modified     timestamp WITH TIME ZONE NOT NULL DEFAULT now()
 );
 CREATE INDEX thread_slug ON Thread using hash (Slug);
 CREATE INDEX forum_thread ON Thread (Forum, Created);
@@ -37,21 +44,24 @@ CREATE UNLOGGED TABLE Posts
 (
     Id           serial            NOT NULL PRIMARY KEY,
     Parent       int               NOT NULL DEFAULT 0,
-    Author       citext            NOT NULL REFERENCES Users(Nickname),
+    Author       citext            NOT NULL REFERENCES Users(Nickname) ON UPDATE CASCADE,
     Message      text              NOT NULL,
     IsEdited     bool              NOT NULL DEFAULT false,
     Forum        citext            NOT NULL REFERENCES Forum(Slug),
     Thread       serial            NOT NULL REFERENCES Thread(Id),
     Created      timestamp WITH TIME ZONE NOT NULL,
-    TreePath     int[]             DEFAULT ARRAY[] :: INT[]
+    TreePath     int[]             DEFAULT ARRAY[] :: INT[],
+    Version      int               NOT NULL DEFAULT 1,
+    Modified     timestamp WITH TIME ZONE NOT NULL DEFAULT now()
 );
 CREATE INDEX posts_select ON Posts (Thread, TreePath);
 CREATE INDEX posts_select_parent_tree ON Posts ((TreePath[1]), TreePath);
+CREATE INDEX posts_author ON Posts (Author);
 
 CREATE UNLOGGED TABLE Vote
 (
     IdThread     int               NOT NULL REFERENCES Thread(Id),
-    Nickname     citext            NOT NULL REFERENCES Users(Nickname),
+    Nickname     citext            NOT NULL REFERENCES Users(Nickname) ON UPDATE CASCADE,
     Voice        int               NOT NULL DEFAULT 0,
     PRIMARY KEY(IdThread, Nickname)
 );
@@ -59,16 +69,57 @@ CREATE UNLOGGED TABLE Vote
 CREATE UNLOGGED TABLE UsersForum
 (
     Forum        citext   NOT NULL REFERENCES Forum(Slug),
-    Nickname     citext  COLLATE "ucs_basic" NOT NULL REFERENCES Users(Nickname),
+    Nickname     citext  COLLATE "ucs_basic" NOT NULL REFERENCES Users(Nickname) ON UPDATE CASCADE,
     PRIMARY KEY(Forum, Nickname)
 );
 CREATE INDEX usersforum_nickname ON UsersForum using hash (Nickname);
 
+CREATE UNLOGGED TABLE NicknameHistory
+(
+    OldNickname  citext  COLLATE "ucs_basic" NOT NULL PRIMARY KEY,
+    Nickname     citext  COLLATE "ucs_basic" NOT NULL REFERENCES Users(Nickname) ON UPDATE CASCADE,
+    Changed      timestamp WITH TIME ZONE NOT NULL DEFAULT now()
+);
+CREATE INDEX nickname_history_nickname ON NicknameHistory (Nickname);
+
+CREATE UNLOGGED TABLE AuditLog
+(
+    Id           serial            NOT NULL PRIMARY KEY,
+    Action       varchar(50)       NOT NULL,
+    Details      jsonb             NOT NULL DEFAULT '{}',
+    Created      timestamp WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE UNLOGGED TABLE RateLimit
+(
+    Key          text              NOT NULL PRIMARY KEY,
+    Tokens       float8            NOT NULL,
+    Updated      timestamp WITH TIME ZONE NOT NULL
+);
+
+CREATE UNLOGGED TABLE Idempotency
+(
+    Key          varchar(320)      NOT NULL PRIMARY KEY,
+    RequestHash  bytea             NOT NULL,
+    Status       int               NOT NULL DEFAULT 0,
+    ContentType  text              NOT NULL DEFAULT '',
+    Body         bytea             NOT NULL DEFAULT '',
+    ETag         text              NOT NULL DEFAULT '',
+    Location     text              NOT NULL DEFAULT '',
+    Created      timestamp WITH TIME ZONE NOT NULL DEFAULT now(),
+    LeaseUntil   timestamp WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
 -- INSERT INTO Users(Nickname, Fullname, About, Email)
 -- VALUES ('Test', 'NikitaGureev', 'About 1st user', 'test@mail.ru');
 
 CREATE OR REPLACE FUNCTION update_users_forum() RETURNS TRIGGER AS $$
 BEGIN
+    -- SavePosts maintains TreePath, counters and UsersForum set-based
+    -- for bulk inserts, see forum.bulk_insert in savePostsCopy
+    IF current_setting('forum.bulk_insert', true) = 'on' THEN
+        RETURN new;
+    END IF;
     INSERT INTO UsersForum(Forum, Nickname)
     VALUES (new.Forum, new.Author)
     ON CONFLICT ON CONSTRAINT usersforum_pkey
@@ -81,6 +132,9 @@ CREATE TRIGGER update_users_forum_thread_trigger AFTER INSERT ON Thread FOR EACH
 
 CREATE OR REPLACE FUNCTION update_post_path() RETURNS TRIGGER AS $$
 BEGIN
+    IF current_setting('forum.bulk_insert', true) = 'on' THEN
+        RETURN new;
+    END IF;
     new.TreePath = (SELECT TreePath FROM Posts WHERE id = new.parent) || new.id;
     RETURN new;
 END;
@@ -89,6 +143,9 @@ CREATE TRIGGER update_path BEFORE INSERT ON Posts FOR EACH ROW EXECUTE PROCEDURE
 
 CREATE OR REPLACE FUNCTION update_post_count() RETURNS TRIGGER AS $$
 BEGIN
+    IF current_setting('forum.bulk_insert', true) = 'on' THEN
+        RETURN new;
+    END IF;
     UPDATE forum
     SET Posts = forum.Posts + 1
     WHERE Slug = new.Forum;
@@ -125,4 +182,19 @@ END;
 $$ LANGUAGE plpgsql;
 CREATE TRIGGER update_vote_count_trigger AFTER UPDATE OR INSERT ON Vote FOR EACH ROW EXECUTE PROCEDURE update_vote_count();
 
+-- Version backs the ETag and Modified the Last-Modified of users, forums,
+-- threads and posts: any change of the row, including votes, counters and
+-- cascaded nickname changes, makes a new version
+CREATE OR REPLACE FUNCTION bump_version() RETURNS TRIGGER AS $$
+BEGIN
+    new.Version = old.Version + 1;
+    new.Modified = now();
+    RETURN new;
+END;
+$$ LANGUAGE plpgsql;
+CREATE TRIGGER bump_users_version BEFORE UPDATE ON Users FOR EACH ROW EXECUTE PROCEDURE bump_version();
+CREATE TRIGGER bump_forum_version BEFORE UPDATE ON Forum FOR EACH ROW EXECUTE PROCEDURE bump_version();
+CREATE TRIGGER bump_thread_version BEFORE UPDATE ON Thread FOR EACH ROW EXECUTE PROCEDURE bump_version();
+CREATE TRIGGER bump_posts_version BEFORE UPDATE ON Posts FOR EACH ROW EXECUTE PROCEDURE bump_version();
+
 VACUUM ANALYSE;
\ No newline at end of file
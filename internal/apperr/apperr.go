@@ -0,0 +1,131 @@
+package apperr
+
+import (
+	"errors"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+	"techpark_db/internal/domain/entity"
+)
+
+const PROBLEM_TYPE_PREFIX = "urn:forum:problem:"
+
+type Code string
+
+const (
+	CodeBadRequest     Code = "bad_request"
+	CodeInvalidBody    Code = "invalid_body"
+	CodeInvalidFields  Code = "invalid_fields"
+	CodeInvalidQuery   Code = "invalid_query"
+	CodeUserNotFound   Code = "user_not_found"
+	CodeForumNotFound  Code = "forum_not_found"
+	CodeThreadNotFound Code = "thread_not_found"
+	CodePostNotFound   Code = "post_not_found"
+	CodeParentNotFound Code = "parent_not_found"
+	CodeEmailTaken     Code = "email_taken"
+	CodeNicknameTaken  Code = "nickname_taken"
+	CodeSameThread     Code = "same_thread"
+	CodeVoteRejected   Code = "vote_rejected"
+	CodeRateLimited    Code = "rate_limited"
+	CodeKeyReused      Code = "idempotency_key_reused"
+	CodeKeyInProgress  Code = "idempotency_key_in_progress"
+	CodeVersionChanged Code = "precondition_failed"
+	CodeExists         Code = "already_exists"
+	CodeInternal       Code = "internal"
+)
+
+var statuses = map[Code]int{
+	CodeBadRequest:     http.StatusBadRequest,
+	CodeInvalidBody:    http.StatusBadRequest,
+	CodeInvalidFields:  http.StatusBadRequest,
+	CodeInvalidQuery:   http.StatusBadRequest,
+	CodeUserNotFound:   http.StatusNotFound,
+	CodeForumNotFound:  http.StatusNotFound,
+	CodeThreadNotFound: http.StatusNotFound,
+	CodePostNotFound:   http.StatusNotFound,
+	CodeParentNotFound: http.StatusConflict,
+	CodeEmailTaken:     http.StatusConflict,
+	CodeNicknameTaken:  http.StatusConflict,
+	CodeSameThread:     http.StatusConflict,
+	CodeVoteRejected:   http.StatusConflict,
+	CodeRateLimited:    http.StatusTooManyRequests,
+	CodeKeyReused:      http.StatusUnprocessableEntity,
+	CodeKeyInProgress:  http.StatusConflict,
+	CodeVersionChanged: http.StatusPreconditionFailed,
+	CodeExists:         http.StatusConflict,
+	CodeInternal:       http.StatusInternalServerError,
+}
+
+const detailInternal = "Internal server error"
+
+// Error is an error shown to the client. Err keeps the cause for the logs.
+type Error struct {
+	Code   Code
+	Detail string
+	Fields []entity.FieldError
+	Err    error
+}
+
+func New(code Code, detail string) *Error {
+	return &Error{
+		Code:   code,
+		Detail: detail,
+	}
+}
+
+func Wrap(code Code, detail string, err error) *Error {
+	return &Error{
+		Code:   code,
+		Detail: detail,
+		Err:    err,
+	}
+}
+
+// Invalid lists the invalid fields of the body or the query.
+func Invalid(code Code, detail string, fields []entity.FieldError) *Error {
+	return &Error{
+		Code:   code,
+		Detail: detail,
+		Fields: fields,
+	}
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return string(e.Code) + ": " + e.Detail + ": " + e.Err.Error()
+	}
+	return string(e.Code) + ": " + e.Detail
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func (e *Error) Status() int {
+	if status, ok := statuses[e.Code]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// ProblemOf describes err to the client. Errors other than *Error are logged
+// and hidden behind the internal code.
+func ProblemOf(err error) entity.Problem {
+	var appErr *Error
+	if !errors.As(err, &appErr) {
+		log.Error(err)
+		appErr = New(CodeInternal, detailInternal)
+	} else if appErr.Err != nil {
+		log.Warning(appErr)
+	}
+
+	status := appErr.Status()
+	return entity.Problem{
+		Type:    PROBLEM_TYPE_PREFIX + string(appErr.Code),
+		Title:   http.StatusText(status),
+		Status:  status,
+		Detail:  appErr.Detail,
+		Code:    string(appErr.Code),
+		Message: appErr.Detail,
+		Errors:  appErr.Fields,
+	}
+}
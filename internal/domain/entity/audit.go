@@ -0,0 +1,27 @@
+package entity
+
+const (
+	AuditThreadMove  = "thread_move"
+	AuditThreadMerge = "thread_merge"
+	AuditPostSplit   = "post_split"
+)
+
+type ThreadMoveAudit struct {
+	Thread int    `json:"thread"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Stub   int    `json:"stub,omitempty"`
+}
+
+type ThreadMergeAudit struct {
+	Source int `json:"source"`
+	Target int `json:"target"`
+	Posts  int `json:"posts"`
+}
+
+type PostSplitAudit struct {
+	Post   int `json:"post"`
+	Source int `json:"source"`
+	Target int `json:"target"`
+	Posts  int `json:"posts"`
+}
@@ -0,0 +1,273 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package entity
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonF2c44427DecodeTechparkDbInternalDomainEntity(in *jlexer.Lexer, out *ThreadMoveAudit) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "thread":
+			out.Thread = int(in.Int())
+		case "from":
+			out.From = string(in.String())
+		case "to":
+			out.To = string(in.String())
+		case "stub":
+			out.Stub = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF2c44427EncodeTechparkDbInternalDomainEntity(out *jwriter.Writer, in ThreadMoveAudit) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"thread\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Thread))
+	}
+	{
+		const prefix string = ",\"from\":"
+		out.RawString(prefix)
+		out.String(string(in.From))
+	}
+	{
+		const prefix string = ",\"to\":"
+		out.RawString(prefix)
+		out.String(string(in.To))
+	}
+	if in.Stub != 0 {
+		const prefix string = ",\"stub\":"
+		out.RawString(prefix)
+		out.Int(int(in.Stub))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ThreadMoveAudit) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF2c44427EncodeTechparkDbInternalDomainEntity(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ThreadMoveAudit) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF2c44427EncodeTechparkDbInternalDomainEntity(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ThreadMoveAudit) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF2c44427DecodeTechparkDbInternalDomainEntity(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ThreadMoveAudit) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF2c44427DecodeTechparkDbInternalDomainEntity(l, v)
+}
+func easyjsonF2c44427DecodeTechparkDbInternalDomainEntity1(in *jlexer.Lexer, out *ThreadMergeAudit) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "source":
+			out.Source = int(in.Int())
+		case "target":
+			out.Target = int(in.Int())
+		case "posts":
+			out.Posts = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF2c44427EncodeTechparkDbInternalDomainEntity1(out *jwriter.Writer, in ThreadMergeAudit) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"source\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Source))
+	}
+	{
+		const prefix string = ",\"target\":"
+		out.RawString(prefix)
+		out.Int(int(in.Target))
+	}
+	{
+		const prefix string = ",\"posts\":"
+		out.RawString(prefix)
+		out.Int(int(in.Posts))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ThreadMergeAudit) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF2c44427EncodeTechparkDbInternalDomainEntity1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ThreadMergeAudit) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF2c44427EncodeTechparkDbInternalDomainEntity1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ThreadMergeAudit) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF2c44427DecodeTechparkDbInternalDomainEntity1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ThreadMergeAudit) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF2c44427DecodeTechparkDbInternalDomainEntity1(l, v)
+}
+func easyjsonF2c44427DecodeTechparkDbInternalDomainEntity2(in *jlexer.Lexer, out *PostSplitAudit) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "post":
+			out.Post = int(in.Int())
+		case "source":
+			out.Source = int(in.Int())
+		case "target":
+			out.Target = int(in.Int())
+		case "posts":
+			out.Posts = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF2c44427EncodeTechparkDbInternalDomainEntity2(out *jwriter.Writer, in PostSplitAudit) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"post\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Post))
+	}
+	{
+		const prefix string = ",\"source\":"
+		out.RawString(prefix)
+		out.Int(int(in.Source))
+	}
+	{
+		const prefix string = ",\"target\":"
+		out.RawString(prefix)
+		out.Int(int(in.Target))
+	}
+	{
+		const prefix string = ",\"posts\":"
+		out.RawString(prefix)
+		out.Int(int(in.Posts))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PostSplitAudit) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF2c44427EncodeTechparkDbInternalDomainEntity2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PostSplitAudit) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF2c44427EncodeTechparkDbInternalDomainEntity2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PostSplitAudit) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF2c44427DecodeTechparkDbInternalDomainEntity2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PostSplitAudit) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF2c44427DecodeTechparkDbInternalDomainEntity2(l, v)
+}
@@ -0,0 +1,24 @@
+package entity
+
+import "github.com/mailru/easyjson"
+
+const (
+	DumpUser   = "user"
+	DumpForum  = "forum"
+	DumpThread = "thread"
+	DumpPost   = "post"
+	DumpVote   = "vote"
+)
+
+type DumpRecord struct {
+	Type string              `json:"type"`
+	Data easyjson.RawMessage `json:"data"`
+}
+
+type ImportStats struct {
+	Users   int `json:"users"`
+	Forums  int `json:"forums"`
+	Threads int `json:"threads"`
+	Posts   int `json:"posts"`
+	Votes   int `json:"votes"`
+}
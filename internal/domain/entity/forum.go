@@ -1,15 +1,19 @@
 package entity
 
+import "time"
+
 type CreateForum struct {
-	Title string `json:"title"`
-	User  string `json:"user"`
-	Slug  string `json:"slug"`
+	Title string `json:"title" msg:"title" validate:"required,max=100"`
+	User  string `json:"user" msg:"user" validate:"required,slug"`
+	Slug  string `json:"slug" msg:"slug" validate:"required,slug"`
 }
 
 type Forum struct {
-	Title   string `json:"title"`
-	User    string `json:"user"`
-	Slug    string `json:"slug"`
-	Posts   int    `json:"posts"`
-	Threads int    `json:"threads"`
+	Title    string    `json:"title" msg:"title"`
+	User     string    `json:"user" msg:"user"`
+	Slug     string    `json:"slug" msg:"slug"`
+	Posts    int       `json:"posts" msg:"posts"`
+	Threads  int       `json:"threads" msg:"threads"`
+	Version  int       `json:"-" msg:"-"`
+	Modified time.Time `json:"-" msg:"-"`
 }
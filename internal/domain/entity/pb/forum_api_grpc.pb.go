@@ -0,0 +1,1057 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: forum_api.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	ForumAPI_ForumCreate_FullMethodName       = "/forum.ForumAPI/ForumCreate"
+	ForumAPI_ForumDetails_FullMethodName      = "/forum.ForumAPI/ForumDetails"
+	ForumAPI_ForumCreateThread_FullMethodName = "/forum.ForumAPI/ForumCreateThread"
+	ForumAPI_ForumUsers_FullMethodName        = "/forum.ForumAPI/ForumUsers"
+	ForumAPI_ForumThreads_FullMethodName      = "/forum.ForumAPI/ForumThreads"
+	ForumAPI_ThreadCreatePosts_FullMethodName = "/forum.ForumAPI/ThreadCreatePosts"
+	ForumAPI_ThreadVote_FullMethodName        = "/forum.ForumAPI/ThreadVote"
+	ForumAPI_ThreadDetails_FullMethodName     = "/forum.ForumAPI/ThreadDetails"
+	ForumAPI_ThreadUpdate_FullMethodName      = "/forum.ForumAPI/ThreadUpdate"
+	ForumAPI_ThreadPosts_FullMethodName       = "/forum.ForumAPI/ThreadPosts"
+	ForumAPI_ThreadMove_FullMethodName        = "/forum.ForumAPI/ThreadMove"
+	ForumAPI_ThreadMerge_FullMethodName       = "/forum.ForumAPI/ThreadMerge"
+	ForumAPI_PostGet_FullMethodName           = "/forum.ForumAPI/PostGet"
+	ForumAPI_PostUpdate_FullMethodName        = "/forum.ForumAPI/PostUpdate"
+	ForumAPI_PostSplit_FullMethodName         = "/forum.ForumAPI/PostSplit"
+	ForumAPI_PostReplies_FullMethodName       = "/forum.ForumAPI/PostReplies"
+	ForumAPI_PostAncestors_FullMethodName     = "/forum.ForumAPI/PostAncestors"
+	ForumAPI_PostFeed_FullMethodName          = "/forum.ForumAPI/PostFeed"
+	ForumAPI_UserCreate_FullMethodName        = "/forum.ForumAPI/UserCreate"
+	ForumAPI_UserDetails_FullMethodName       = "/forum.ForumAPI/UserDetails"
+	ForumAPI_UserUpdate_FullMethodName        = "/forum.ForumAPI/UserUpdate"
+	ForumAPI_UserRename_FullMethodName        = "/forum.ForumAPI/UserRename"
+	ForumAPI_ServiceStatus_FullMethodName     = "/forum.ForumAPI/ServiceStatus"
+	ForumAPI_ServiceClear_FullMethodName      = "/forum.ForumAPI/ServiceClear"
+	ForumAPI_ServiceCache_FullMethodName      = "/forum.ForumAPI/ServiceCache"
+)
+
+// ForumAPIClient is the client API for ForumAPI service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ForumAPIClient interface {
+	ForumCreate(ctx context.Context, in *CreateForum, opts ...grpc.CallOption) (*Forum, error)
+	ForumDetails(ctx context.Context, in *ForumRequest, opts ...grpc.CallOption) (*Forum, error)
+	ForumCreateThread(ctx context.Context, in *ForumCreateThreadRequest, opts ...grpc.CallOption) (*Thread, error)
+	ForumUsers(ctx context.Context, in *ForumUsersRequest, opts ...grpc.CallOption) (*Users, error)
+	ForumThreads(ctx context.Context, in *ForumThreadsRequest, opts ...grpc.CallOption) (*Threads, error)
+	ThreadCreatePosts(ctx context.Context, in *ThreadCreatePostsRequest, opts ...grpc.CallOption) (*Posts, error)
+	ThreadVote(ctx context.Context, in *ThreadVoteRequest, opts ...grpc.CallOption) (*Thread, error)
+	ThreadDetails(ctx context.Context, in *ThreadRequest, opts ...grpc.CallOption) (*Thread, error)
+	ThreadUpdate(ctx context.Context, in *ThreadUpdateRequest, opts ...grpc.CallOption) (*Thread, error)
+	// ThreadPosts streams a page of posts row by row as it is read.
+	ThreadPosts(ctx context.Context, in *ThreadPostsRequest, opts ...grpc.CallOption) (ForumAPI_ThreadPostsClient, error)
+	ThreadMove(ctx context.Context, in *ThreadMoveRequest, opts ...grpc.CallOption) (*Thread, error)
+	ThreadMerge(ctx context.Context, in *ThreadMergeRequest, opts ...grpc.CallOption) (*Thread, error)
+	PostGet(ctx context.Context, in *PostGetRequest, opts ...grpc.CallOption) (*PostDetails, error)
+	PostUpdate(ctx context.Context, in *PostUpdateRequest, opts ...grpc.CallOption) (*Post, error)
+	PostSplit(ctx context.Context, in *PostSplitRequest, opts ...grpc.CallOption) (*Thread, error)
+	PostReplies(ctx context.Context, in *PostRepliesRequest, opts ...grpc.CallOption) (*Posts, error)
+	PostAncestors(ctx context.Context, in *PostRequest, opts ...grpc.CallOption) (*Posts, error)
+	// PostFeed streams the posts created from now on until the client leaves.
+	PostFeed(ctx context.Context, in *PostFeedRequest, opts ...grpc.CallOption) (ForumAPI_PostFeedClient, error)
+	UserCreate(ctx context.Context, in *UserCreateRequest, opts ...grpc.CallOption) (*User, error)
+	UserDetails(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*User, error)
+	UserUpdate(ctx context.Context, in *UserUpdateRequest, opts ...grpc.CallOption) (*User, error)
+	UserRename(ctx context.Context, in *UserRenameRequest, opts ...grpc.CallOption) (*User, error)
+	ServiceStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ServStatus, error)
+	ServiceClear(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ServiceCache(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CacheStatus, error)
+}
+
+type forumAPIClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewForumAPIClient(cc grpc.ClientConnInterface) ForumAPIClient {
+	return &forumAPIClient{cc}
+}
+
+func (c *forumAPIClient) ForumCreate(ctx context.Context, in *CreateForum, opts ...grpc.CallOption) (*Forum, error) {
+	out := new(Forum)
+	err := c.cc.Invoke(ctx, ForumAPI_ForumCreate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forumAPIClient) ForumDetails(ctx context.Context, in *ForumRequest, opts ...grpc.CallOption) (*Forum, error) {
+	out := new(Forum)
+	err := c.cc.Invoke(ctx, ForumAPI_ForumDetails_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forumAPIClient) ForumCreateThread(ctx context.Context, in *ForumCreateThreadRequest, opts ...grpc.CallOption) (*Thread, error) {
+	out := new(Thread)
+	err := c.cc.Invoke(ctx, ForumAPI_ForumCreateThread_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forumAPIClient) ForumUsers(ctx context.Context, in *ForumUsersRequest, opts ...grpc.CallOption) (*Users, error) {
+	out := new(Users)
+	err := c.cc.Invoke(ctx, ForumAPI_ForumUsers_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forumAPIClient) ForumThreads(ctx context.Context, in *ForumThreadsRequest, opts ...grpc.CallOption) (*Threads, error) {
+	out := new(Threads)
+	err := c.cc.Invoke(ctx, ForumAPI_ForumThreads_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forumAPIClient) ThreadCreatePosts(ctx context.Context, in *ThreadCreatePostsRequest, opts ...grpc.CallOption) (*Posts, error) {
+	out := new(Posts)
+	err := c.cc.Invoke(ctx, ForumAPI_ThreadCreatePosts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forumAPIClient) ThreadVote(ctx context.Context, in *ThreadVoteRequest, opts ...grpc.CallOption) (*Thread, error) {
+	out := new(Thread)
+	err := c.cc.Invoke(ctx, ForumAPI_ThreadVote_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forumAPIClient) ThreadDetails(ctx context.Context, in *ThreadRequest, opts ...grpc.CallOption) (*Thread, error) {
+	out := new(Thread)
+	err := c.cc.Invoke(ctx, ForumAPI_ThreadDetails_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forumAPIClient) ThreadUpdate(ctx context.Context, in *ThreadUpdateRequest, opts ...grpc.CallOption) (*Thread, error) {
+	out := new(Thread)
+	err := c.cc.Invoke(ctx, ForumAPI_ThreadUpdate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forumAPIClient) ThreadPosts(ctx context.Context, in *ThreadPostsRequest, opts ...grpc.CallOption) (ForumAPI_ThreadPostsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ForumAPI_ServiceDesc.Streams[0], ForumAPI_ThreadPosts_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &forumAPIThreadPostsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ForumAPI_ThreadPostsClient interface {
+	Recv() (*Post, error)
+	grpc.ClientStream
+}
+
+type forumAPIThreadPostsClient struct {
+	grpc.ClientStream
+}
+
+func (x *forumAPIThreadPostsClient) Recv() (*Post, error) {
+	m := new(Post)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *forumAPIClient) ThreadMove(ctx context.Context, in *ThreadMoveRequest, opts ...grpc.CallOption) (*Thread, error) {
+	out := new(Thread)
+	err := c.cc.Invoke(ctx, ForumAPI_ThreadMove_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forumAPIClient) ThreadMerge(ctx context.Context, in *ThreadMergeRequest, opts ...grpc.CallOption) (*Thread, error) {
+	out := new(Thread)
+	err := c.cc.Invoke(ctx, ForumAPI_ThreadMerge_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forumAPIClient) PostGet(ctx context.Context, in *PostGetRequest, opts ...grpc.CallOption) (*PostDetails, error) {
+	out := new(PostDetails)
+	err := c.cc.Invoke(ctx, ForumAPI_PostGet_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forumAPIClient) PostUpdate(ctx context.Context, in *PostUpdateRequest, opts ...grpc.CallOption) (*Post, error) {
+	out := new(Post)
+	err := c.cc.Invoke(ctx, ForumAPI_PostUpdate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forumAPIClient) PostSplit(ctx context.Context, in *PostSplitRequest, opts ...grpc.CallOption) (*Thread, error) {
+	out := new(Thread)
+	err := c.cc.Invoke(ctx, ForumAPI_PostSplit_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forumAPIClient) PostReplies(ctx context.Context, in *PostRepliesRequest, opts ...grpc.CallOption) (*Posts, error) {
+	out := new(Posts)
+	err := c.cc.Invoke(ctx, ForumAPI_PostReplies_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forumAPIClient) PostAncestors(ctx context.Context, in *PostRequest, opts ...grpc.CallOption) (*Posts, error) {
+	out := new(Posts)
+	err := c.cc.Invoke(ctx, ForumAPI_PostAncestors_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forumAPIClient) PostFeed(ctx context.Context, in *PostFeedRequest, opts ...grpc.CallOption) (ForumAPI_PostFeedClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ForumAPI_ServiceDesc.Streams[1], ForumAPI_PostFeed_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &forumAPIPostFeedClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ForumAPI_PostFeedClient interface {
+	Recv() (*Post, error)
+	grpc.ClientStream
+}
+
+type forumAPIPostFeedClient struct {
+	grpc.ClientStream
+}
+
+func (x *forumAPIPostFeedClient) Recv() (*Post, error) {
+	m := new(Post)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *forumAPIClient) UserCreate(ctx context.Context, in *UserCreateRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, ForumAPI_UserCreate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forumAPIClient) UserDetails(ctx context.Context, in *UserRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, ForumAPI_UserDetails_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forumAPIClient) UserUpdate(ctx context.Context, in *UserUpdateRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, ForumAPI_UserUpdate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forumAPIClient) UserRename(ctx context.Context, in *UserRenameRequest, opts ...grpc.CallOption) (*User, error) {
+	out := new(User)
+	err := c.cc.Invoke(ctx, ForumAPI_UserRename_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forumAPIClient) ServiceStatus(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ServStatus, error) {
+	out := new(ServStatus)
+	err := c.cc.Invoke(ctx, ForumAPI_ServiceStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forumAPIClient) ServiceClear(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ForumAPI_ServiceClear_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *forumAPIClient) ServiceCache(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*CacheStatus, error) {
+	out := new(CacheStatus)
+	err := c.cc.Invoke(ctx, ForumAPI_ServiceCache_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ForumAPIServer is the server API for ForumAPI service.
+// All implementations must embed UnimplementedForumAPIServer
+// for forward compatibility
+type ForumAPIServer interface {
+	ForumCreate(context.Context, *CreateForum) (*Forum, error)
+	ForumDetails(context.Context, *ForumRequest) (*Forum, error)
+	ForumCreateThread(context.Context, *ForumCreateThreadRequest) (*Thread, error)
+	ForumUsers(context.Context, *ForumUsersRequest) (*Users, error)
+	ForumThreads(context.Context, *ForumThreadsRequest) (*Threads, error)
+	ThreadCreatePosts(context.Context, *ThreadCreatePostsRequest) (*Posts, error)
+	ThreadVote(context.Context, *ThreadVoteRequest) (*Thread, error)
+	ThreadDetails(context.Context, *ThreadRequest) (*Thread, error)
+	ThreadUpdate(context.Context, *ThreadUpdateRequest) (*Thread, error)
+	// ThreadPosts streams a page of posts row by row as it is read.
+	ThreadPosts(*ThreadPostsRequest, ForumAPI_ThreadPostsServer) error
+	ThreadMove(context.Context, *ThreadMoveRequest) (*Thread, error)
+	ThreadMerge(context.Context, *ThreadMergeRequest) (*Thread, error)
+	PostGet(context.Context, *PostGetRequest) (*PostDetails, error)
+	PostUpdate(context.Context, *PostUpdateRequest) (*Post, error)
+	PostSplit(context.Context, *PostSplitRequest) (*Thread, error)
+	PostReplies(context.Context, *PostRepliesRequest) (*Posts, error)
+	PostAncestors(context.Context, *PostRequest) (*Posts, error)
+	// PostFeed streams the posts created from now on until the client leaves.
+	PostFeed(*PostFeedRequest, ForumAPI_PostFeedServer) error
+	UserCreate(context.Context, *UserCreateRequest) (*User, error)
+	UserDetails(context.Context, *UserRequest) (*User, error)
+	UserUpdate(context.Context, *UserUpdateRequest) (*User, error)
+	UserRename(context.Context, *UserRenameRequest) (*User, error)
+	ServiceStatus(context.Context, *emptypb.Empty) (*ServStatus, error)
+	ServiceClear(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	ServiceCache(context.Context, *emptypb.Empty) (*CacheStatus, error)
+	mustEmbedUnimplementedForumAPIServer()
+}
+
+// UnimplementedForumAPIServer must be embedded to have forward compatible implementations.
+type UnimplementedForumAPIServer struct {
+}
+
+func (UnimplementedForumAPIServer) ForumCreate(context.Context, *CreateForum) (*Forum, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForumCreate not implemented")
+}
+func (UnimplementedForumAPIServer) ForumDetails(context.Context, *ForumRequest) (*Forum, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForumDetails not implemented")
+}
+func (UnimplementedForumAPIServer) ForumCreateThread(context.Context, *ForumCreateThreadRequest) (*Thread, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForumCreateThread not implemented")
+}
+func (UnimplementedForumAPIServer) ForumUsers(context.Context, *ForumUsersRequest) (*Users, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForumUsers not implemented")
+}
+func (UnimplementedForumAPIServer) ForumThreads(context.Context, *ForumThreadsRequest) (*Threads, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForumThreads not implemented")
+}
+func (UnimplementedForumAPIServer) ThreadCreatePosts(context.Context, *ThreadCreatePostsRequest) (*Posts, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ThreadCreatePosts not implemented")
+}
+func (UnimplementedForumAPIServer) ThreadVote(context.Context, *ThreadVoteRequest) (*Thread, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ThreadVote not implemented")
+}
+func (UnimplementedForumAPIServer) ThreadDetails(context.Context, *ThreadRequest) (*Thread, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ThreadDetails not implemented")
+}
+func (UnimplementedForumAPIServer) ThreadUpdate(context.Context, *ThreadUpdateRequest) (*Thread, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ThreadUpdate not implemented")
+}
+func (UnimplementedForumAPIServer) ThreadPosts(*ThreadPostsRequest, ForumAPI_ThreadPostsServer) error {
+	return status.Errorf(codes.Unimplemented, "method ThreadPosts not implemented")
+}
+func (UnimplementedForumAPIServer) ThreadMove(context.Context, *ThreadMoveRequest) (*Thread, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ThreadMove not implemented")
+}
+func (UnimplementedForumAPIServer) ThreadMerge(context.Context, *ThreadMergeRequest) (*Thread, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ThreadMerge not implemented")
+}
+func (UnimplementedForumAPIServer) PostGet(context.Context, *PostGetRequest) (*PostDetails, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostGet not implemented")
+}
+func (UnimplementedForumAPIServer) PostUpdate(context.Context, *PostUpdateRequest) (*Post, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostUpdate not implemented")
+}
+func (UnimplementedForumAPIServer) PostSplit(context.Context, *PostSplitRequest) (*Thread, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostSplit not implemented")
+}
+func (UnimplementedForumAPIServer) PostReplies(context.Context, *PostRepliesRequest) (*Posts, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostReplies not implemented")
+}
+func (UnimplementedForumAPIServer) PostAncestors(context.Context, *PostRequest) (*Posts, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PostAncestors not implemented")
+}
+func (UnimplementedForumAPIServer) PostFeed(*PostFeedRequest, ForumAPI_PostFeedServer) error {
+	return status.Errorf(codes.Unimplemented, "method PostFeed not implemented")
+}
+func (UnimplementedForumAPIServer) UserCreate(context.Context, *UserCreateRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserCreate not implemented")
+}
+func (UnimplementedForumAPIServer) UserDetails(context.Context, *UserRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserDetails not implemented")
+}
+func (UnimplementedForumAPIServer) UserUpdate(context.Context, *UserUpdateRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserUpdate not implemented")
+}
+func (UnimplementedForumAPIServer) UserRename(context.Context, *UserRenameRequest) (*User, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserRename not implemented")
+}
+func (UnimplementedForumAPIServer) ServiceStatus(context.Context, *emptypb.Empty) (*ServStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ServiceStatus not implemented")
+}
+func (UnimplementedForumAPIServer) ServiceClear(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ServiceClear not implemented")
+}
+func (UnimplementedForumAPIServer) ServiceCache(context.Context, *emptypb.Empty) (*CacheStatus, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ServiceCache not implemented")
+}
+func (UnimplementedForumAPIServer) mustEmbedUnimplementedForumAPIServer() {}
+
+// UnsafeForumAPIServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ForumAPIServer will
+// result in compilation errors.
+type UnsafeForumAPIServer interface {
+	mustEmbedUnimplementedForumAPIServer()
+}
+
+func RegisterForumAPIServer(s grpc.ServiceRegistrar, srv ForumAPIServer) {
+	s.RegisterService(&ForumAPI_ServiceDesc, srv)
+}
+
+func _ForumAPI_ForumCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateForum)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumAPIServer).ForumCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumAPI_ForumCreate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumAPIServer).ForumCreate(ctx, req.(*CreateForum))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForumAPI_ForumDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForumRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumAPIServer).ForumDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumAPI_ForumDetails_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumAPIServer).ForumDetails(ctx, req.(*ForumRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForumAPI_ForumCreateThread_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForumCreateThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumAPIServer).ForumCreateThread(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumAPI_ForumCreateThread_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumAPIServer).ForumCreateThread(ctx, req.(*ForumCreateThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForumAPI_ForumUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForumUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumAPIServer).ForumUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumAPI_ForumUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumAPIServer).ForumUsers(ctx, req.(*ForumUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForumAPI_ForumThreads_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForumThreadsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumAPIServer).ForumThreads(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumAPI_ForumThreads_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumAPIServer).ForumThreads(ctx, req.(*ForumThreadsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForumAPI_ThreadCreatePosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ThreadCreatePostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumAPIServer).ThreadCreatePosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumAPI_ThreadCreatePosts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumAPIServer).ThreadCreatePosts(ctx, req.(*ThreadCreatePostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForumAPI_ThreadVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ThreadVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumAPIServer).ThreadVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumAPI_ThreadVote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumAPIServer).ThreadVote(ctx, req.(*ThreadVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForumAPI_ThreadDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ThreadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumAPIServer).ThreadDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumAPI_ThreadDetails_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumAPIServer).ThreadDetails(ctx, req.(*ThreadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForumAPI_ThreadUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ThreadUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumAPIServer).ThreadUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumAPI_ThreadUpdate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumAPIServer).ThreadUpdate(ctx, req.(*ThreadUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForumAPI_ThreadPosts_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ThreadPostsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ForumAPIServer).ThreadPosts(m, &forumAPIThreadPostsServer{stream})
+}
+
+type ForumAPI_ThreadPostsServer interface {
+	Send(*Post) error
+	grpc.ServerStream
+}
+
+type forumAPIThreadPostsServer struct {
+	grpc.ServerStream
+}
+
+func (x *forumAPIThreadPostsServer) Send(m *Post) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ForumAPI_ThreadMove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ThreadMoveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumAPIServer).ThreadMove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumAPI_ThreadMove_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumAPIServer).ThreadMove(ctx, req.(*ThreadMoveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForumAPI_ThreadMerge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ThreadMergeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumAPIServer).ThreadMerge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumAPI_ThreadMerge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumAPIServer).ThreadMerge(ctx, req.(*ThreadMergeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForumAPI_PostGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostGetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumAPIServer).PostGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumAPI_PostGet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumAPIServer).PostGet(ctx, req.(*PostGetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForumAPI_PostUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumAPIServer).PostUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumAPI_PostUpdate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumAPIServer).PostUpdate(ctx, req.(*PostUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForumAPI_PostSplit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostSplitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumAPIServer).PostSplit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumAPI_PostSplit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumAPIServer).PostSplit(ctx, req.(*PostSplitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForumAPI_PostReplies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostRepliesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumAPIServer).PostReplies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumAPI_PostReplies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumAPIServer).PostReplies(ctx, req.(*PostRepliesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForumAPI_PostAncestors_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumAPIServer).PostAncestors(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumAPI_PostAncestors_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumAPIServer).PostAncestors(ctx, req.(*PostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForumAPI_PostFeed_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(PostFeedRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ForumAPIServer).PostFeed(m, &forumAPIPostFeedServer{stream})
+}
+
+type ForumAPI_PostFeedServer interface {
+	Send(*Post) error
+	grpc.ServerStream
+}
+
+type forumAPIPostFeedServer struct {
+	grpc.ServerStream
+}
+
+func (x *forumAPIPostFeedServer) Send(m *Post) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _ForumAPI_UserCreate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserCreateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumAPIServer).UserCreate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumAPI_UserCreate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumAPIServer).UserCreate(ctx, req.(*UserCreateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForumAPI_UserDetails_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumAPIServer).UserDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumAPI_UserDetails_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumAPIServer).UserDetails(ctx, req.(*UserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForumAPI_UserUpdate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserUpdateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumAPIServer).UserUpdate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumAPI_UserUpdate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumAPIServer).UserUpdate(ctx, req.(*UserUpdateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForumAPI_UserRename_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserRenameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumAPIServer).UserRename(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumAPI_UserRename_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumAPIServer).UserRename(ctx, req.(*UserRenameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForumAPI_ServiceStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumAPIServer).ServiceStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumAPI_ServiceStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumAPIServer).ServiceStatus(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForumAPI_ServiceClear_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumAPIServer).ServiceClear(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumAPI_ServiceClear_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumAPIServer).ServiceClear(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ForumAPI_ServiceCache_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ForumAPIServer).ServiceCache(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ForumAPI_ServiceCache_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ForumAPIServer).ServiceCache(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ForumAPI_ServiceDesc is the grpc.ServiceDesc for ForumAPI service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ForumAPI_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "forum.ForumAPI",
+	HandlerType: (*ForumAPIServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ForumCreate",
+			Handler:    _ForumAPI_ForumCreate_Handler,
+		},
+		{
+			MethodName: "ForumDetails",
+			Handler:    _ForumAPI_ForumDetails_Handler,
+		},
+		{
+			MethodName: "ForumCreateThread",
+			Handler:    _ForumAPI_ForumCreateThread_Handler,
+		},
+		{
+			MethodName: "ForumUsers",
+			Handler:    _ForumAPI_ForumUsers_Handler,
+		},
+		{
+			MethodName: "ForumThreads",
+			Handler:    _ForumAPI_ForumThreads_Handler,
+		},
+		{
+			MethodName: "ThreadCreatePosts",
+			Handler:    _ForumAPI_ThreadCreatePosts_Handler,
+		},
+		{
+			MethodName: "ThreadVote",
+			Handler:    _ForumAPI_ThreadVote_Handler,
+		},
+		{
+			MethodName: "ThreadDetails",
+			Handler:    _ForumAPI_ThreadDetails_Handler,
+		},
+		{
+			MethodName: "ThreadUpdate",
+			Handler:    _ForumAPI_ThreadUpdate_Handler,
+		},
+		{
+			MethodName: "ThreadMove",
+			Handler:    _ForumAPI_ThreadMove_Handler,
+		},
+		{
+			MethodName: "ThreadMerge",
+			Handler:    _ForumAPI_ThreadMerge_Handler,
+		},
+		{
+			MethodName: "PostGet",
+			Handler:    _ForumAPI_PostGet_Handler,
+		},
+		{
+			MethodName: "PostUpdate",
+			Handler:    _ForumAPI_PostUpdate_Handler,
+		},
+		{
+			MethodName: "PostSplit",
+			Handler:    _ForumAPI_PostSplit_Handler,
+		},
+		{
+			MethodName: "PostReplies",
+			Handler:    _ForumAPI_PostReplies_Handler,
+		},
+		{
+			MethodName: "PostAncestors",
+			Handler:    _ForumAPI_PostAncestors_Handler,
+		},
+		{
+			MethodName: "UserCreate",
+			Handler:    _ForumAPI_UserCreate_Handler,
+		},
+		{
+			MethodName: "UserDetails",
+			Handler:    _ForumAPI_UserDetails_Handler,
+		},
+		{
+			MethodName: "UserUpdate",
+			Handler:    _ForumAPI_UserUpdate_Handler,
+		},
+		{
+			MethodName: "UserRename",
+			Handler:    _ForumAPI_UserRename_Handler,
+		},
+		{
+			MethodName: "ServiceStatus",
+			Handler:    _ForumAPI_ServiceStatus_Handler,
+		},
+		{
+			MethodName: "ServiceClear",
+			Handler:    _ForumAPI_ServiceClear_Handler,
+		},
+		{
+			MethodName: "ServiceCache",
+			Handler:    _ForumAPI_ServiceCache_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ThreadPosts",
+			Handler:       _ForumAPI_ThreadPosts_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "PostFeed",
+			Handler:       _ForumAPI_PostFeed_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "forum_api.proto",
+}
@@ -1,42 +1,75 @@
 package entity
 
+import "time"
+
 type Post struct {
-	Id       int    `json:"id"`
-	Parent   int    `json:"parent"`
-	Author   string `json:"author"`
-	Message  string `json:"message"`
-	IsEdited bool   `json:"isEdited"`
-	Forum    string `json:"forum"`
-	Thread   int    `json:"thread"`
-	Created  string `json:"created"`
+	Id       int       `json:"id" msg:"id"`
+	Parent   int       `json:"parent" msg:"parent"`
+	Author   string    `json:"author" msg:"author"`
+	Message  string    `json:"message" msg:"message"`
+	IsEdited bool      `json:"isEdited" msg:"isEdited"`
+	Forum    string    `json:"forum" msg:"forum"`
+	Thread   int       `json:"thread" msg:"thread"`
+	Created  string    `json:"created" msg:"created"`
+	Version  int       `json:"-" msg:"-"`
+	Modified time.Time `json:"-" msg:"-"`
 }
 
 //easyjson:json
 type Posts []Post
 
+type PostNode struct {
+	Id       int          `json:"id" msg:"id"`
+	Parent   int          `json:"parent" msg:"parent"`
+	Author   string       `json:"author" msg:"author"`
+	Message  string       `json:"message" msg:"message"`
+	IsEdited bool         `json:"isEdited" msg:"isEdited"`
+	Forum    string       `json:"forum" msg:"forum"`
+	Thread   int          `json:"thread" msg:"thread"`
+	Created  string       `json:"created" msg:"created"`
+	Children PostNodes    `json:"children" msg:"children"`
+	More     *MoreReplies `json:"more,omitempty" msg:"more,omitempty"`
+}
+
+//easyjson:json
+type PostNodes []PostNode
+
+type MoreReplies struct {
+	Count int `json:"count" msg:"count"`
+}
+
 type CreatePost struct {
-	Parent  int    `json:"parent"`
-	Author  string `json:"author"`
-	Message string `json:"message"`
+	Parent  int    `json:"parent" msg:"parent" validate:"min=0"`
+	Author  string `json:"author" msg:"author" validate:"required,slug"`
+	Message string `json:"message" msg:"message"`
 }
 
+//easyjson:json
+type CreatePosts []CreatePost
+
 type PostDetails struct {
-	DPost   *Post   `json:"post"`
-	DAuthor *User   `json:"author"`
-	DThread *Thread `json:"thread"`
-	DForum  *Forum  `json:"forum"`
+	DPost   *Post   `json:"post" msg:"post"`
+	DAuthor *User   `json:"author" msg:"author"`
+	DThread *Thread `json:"thread" msg:"thread"`
+	DForum  *Forum  `json:"forum" msg:"forum"`
 }
 
 type UpdatePost struct {
-	Message string `json:"message"`
+	Message string `json:"message" msg:"message"`
+}
+
+type SplitPost struct {
+	Title  string `json:"title" msg:"title" validate:"required,max=100"`
+	Author string `json:"author" msg:"author" validate:"slug"`
+	Slug   string `json:"slug" msg:"slug" validate:"slug,nonnumeric"`
 }
 
 type PostWithoutEdited struct {
-	Id      int    `json:"id"`
-	Parent  int    `json:"parent"`
-	Author  string `json:"author"`
-	Message string `json:"message"`
-	Forum   string `json:"forum"`
-	Thread  int    `json:"thread"`
-	Created string `json:"created"`
+	Id      int    `json:"id" msg:"id"`
+	Parent  int    `json:"parent" msg:"parent"`
+	Author  string `json:"author" msg:"author"`
+	Message string `json:"message" msg:"message"`
+	Forum   string `json:"forum" msg:"forum"`
+	Thread  int    `json:"thread" msg:"thread"`
+	Created string `json:"created" msg:"created"`
 }
@@ -83,7 +83,87 @@ func (v *UpdatePost) UnmarshalJSON(data []byte) error {
 func (v *UpdatePost) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity(l, v)
 }
-func easyjson5a72dc82DecodeTechparkDbInternalDomainEntity1(in *jlexer.Lexer, out *Posts) {
+func easyjson5a72dc82DecodeTechparkDbInternalDomainEntity1(in *jlexer.Lexer, out *SplitPost) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "title":
+			out.Title = string(in.String())
+		case "author":
+			out.Author = string(in.String())
+		case "slug":
+			out.Slug = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson5a72dc82EncodeTechparkDbInternalDomainEntity1(out *jwriter.Writer, in SplitPost) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"title\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Title))
+	}
+	{
+		const prefix string = ",\"author\":"
+		out.RawString(prefix)
+		out.String(string(in.Author))
+	}
+	{
+		const prefix string = ",\"slug\":"
+		out.RawString(prefix)
+		out.String(string(in.Slug))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v SplitPost) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v SplitPost) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *SplitPost) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *SplitPost) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity1(l, v)
+}
+func easyjson5a72dc82DecodeTechparkDbInternalDomainEntity2(in *jlexer.Lexer, out *Posts) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -111,7 +191,7 @@ func easyjson5a72dc82DecodeTechparkDbInternalDomainEntity1(in *jlexer.Lexer, out
 		in.Consumed()
 	}
 }
-func easyjson5a72dc82EncodeTechparkDbInternalDomainEntity1(out *jwriter.Writer, in Posts) {
+func easyjson5a72dc82EncodeTechparkDbInternalDomainEntity2(out *jwriter.Writer, in Posts) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
@@ -129,27 +209,27 @@ func easyjson5a72dc82EncodeTechparkDbInternalDomainEntity1(out *jwriter.Writer,
 // MarshalJSON supports json.Marshaler interface
 func (v Posts) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity1(&w, v)
+	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Posts) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity1(w, v)
+	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Posts) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity1(&r, v)
+	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Posts) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity1(l, v)
+	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity2(l, v)
 }
-func easyjson5a72dc82DecodeTechparkDbInternalDomainEntity2(in *jlexer.Lexer, out *PostWithoutEdited) {
+func easyjson5a72dc82DecodeTechparkDbInternalDomainEntity3(in *jlexer.Lexer, out *PostWithoutEdited) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -192,7 +272,7 @@ func easyjson5a72dc82DecodeTechparkDbInternalDomainEntity2(in *jlexer.Lexer, out
 		in.Consumed()
 	}
 }
-func easyjson5a72dc82EncodeTechparkDbInternalDomainEntity2(out *jwriter.Writer, in PostWithoutEdited) {
+func easyjson5a72dc82EncodeTechparkDbInternalDomainEntity3(out *jwriter.Writer, in PostWithoutEdited) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -237,27 +317,230 @@ func easyjson5a72dc82EncodeTechparkDbInternalDomainEntity2(out *jwriter.Writer,
 // MarshalJSON supports json.Marshaler interface
 func (v PostWithoutEdited) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity2(&w, v)
+	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v PostWithoutEdited) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity2(w, v)
+	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *PostWithoutEdited) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity2(&r, v)
+	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *PostWithoutEdited) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity2(l, v)
+	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity3(l, v)
 }
-func easyjson5a72dc82DecodeTechparkDbInternalDomainEntity3(in *jlexer.Lexer, out *PostDetails) {
+func easyjson5a72dc82DecodeTechparkDbInternalDomainEntity4(in *jlexer.Lexer, out *PostNodes) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(PostNodes, 0, 0)
+			} else {
+				*out = PostNodes{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v4 PostNode
+			(v4).UnmarshalEasyJSON(in)
+			*out = append(*out, v4)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson5a72dc82EncodeTechparkDbInternalDomainEntity4(out *jwriter.Writer, in PostNodes) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v5, v6 := range in {
+			if v5 > 0 {
+				out.RawByte(',')
+			}
+			(v6).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PostNodes) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PostNodes) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PostNodes) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PostNodes) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity4(l, v)
+}
+func easyjson5a72dc82DecodeTechparkDbInternalDomainEntity5(in *jlexer.Lexer, out *PostNode) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.Id = int(in.Int())
+		case "parent":
+			out.Parent = int(in.Int())
+		case "author":
+			out.Author = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		case "isEdited":
+			out.IsEdited = bool(in.Bool())
+		case "forum":
+			out.Forum = string(in.String())
+		case "thread":
+			out.Thread = int(in.Int())
+		case "created":
+			out.Created = string(in.String())
+		case "children":
+			(out.Children).UnmarshalEasyJSON(in)
+		case "more":
+			if in.IsNull() {
+				in.Skip()
+				out.More = nil
+			} else {
+				if out.More == nil {
+					out.More = new(MoreReplies)
+				}
+				(*out.More).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson5a72dc82EncodeTechparkDbInternalDomainEntity5(out *jwriter.Writer, in PostNode) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Id))
+	}
+	{
+		const prefix string = ",\"parent\":"
+		out.RawString(prefix)
+		out.Int(int(in.Parent))
+	}
+	{
+		const prefix string = ",\"author\":"
+		out.RawString(prefix)
+		out.String(string(in.Author))
+	}
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	{
+		const prefix string = ",\"isEdited\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsEdited))
+	}
+	{
+		const prefix string = ",\"forum\":"
+		out.RawString(prefix)
+		out.String(string(in.Forum))
+	}
+	{
+		const prefix string = ",\"thread\":"
+		out.RawString(prefix)
+		out.Int(int(in.Thread))
+	}
+	{
+		const prefix string = ",\"created\":"
+		out.RawString(prefix)
+		out.String(string(in.Created))
+	}
+	{
+		const prefix string = ",\"children\":"
+		out.RawString(prefix)
+		(in.Children).MarshalEasyJSON(out)
+	}
+	if in.More != nil {
+		const prefix string = ",\"more\":"
+		out.RawString(prefix)
+		(*in.More).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PostNode) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PostNode) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PostNode) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PostNode) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity5(l, v)
+}
+func easyjson5a72dc82DecodeTechparkDbInternalDomainEntity6(in *jlexer.Lexer, out *PostDetails) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -326,7 +609,7 @@ func easyjson5a72dc82DecodeTechparkDbInternalDomainEntity3(in *jlexer.Lexer, out
 		in.Consumed()
 	}
 }
-func easyjson5a72dc82EncodeTechparkDbInternalDomainEntity3(out *jwriter.Writer, in PostDetails) {
+func easyjson5a72dc82EncodeTechparkDbInternalDomainEntity6(out *jwriter.Writer, in PostDetails) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -372,27 +655,27 @@ func easyjson5a72dc82EncodeTechparkDbInternalDomainEntity3(out *jwriter.Writer,
 // MarshalJSON supports json.Marshaler interface
 func (v PostDetails) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity3(&w, v)
+	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity6(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v PostDetails) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity3(w, v)
+	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity6(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *PostDetails) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity3(&r, v)
+	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity6(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *PostDetails) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity3(l, v)
+	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity6(l, v)
 }
-func easyjson5a72dc82DecodeTechparkDbInternalDomainEntity4(in *jlexer.Lexer, out *Post) {
+func easyjson5a72dc82DecodeTechparkDbInternalDomainEntity7(in *jlexer.Lexer, out *Post) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -437,7 +720,7 @@ func easyjson5a72dc82DecodeTechparkDbInternalDomainEntity4(in *jlexer.Lexer, out
 		in.Consumed()
 	}
 }
-func easyjson5a72dc82EncodeTechparkDbInternalDomainEntity4(out *jwriter.Writer, in Post) {
+func easyjson5a72dc82EncodeTechparkDbInternalDomainEntity7(out *jwriter.Writer, in Post) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -487,27 +770,159 @@ func easyjson5a72dc82EncodeTechparkDbInternalDomainEntity4(out *jwriter.Writer,
 // MarshalJSON supports json.Marshaler interface
 func (v Post) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity4(&w, v)
+	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity7(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Post) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity4(w, v)
+	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity7(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Post) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity4(&r, v)
+	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity7(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Post) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity4(l, v)
+	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity7(l, v)
+}
+func easyjson5a72dc82DecodeTechparkDbInternalDomainEntity8(in *jlexer.Lexer, out *MoreReplies) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "count":
+			out.Count = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson5a72dc82EncodeTechparkDbInternalDomainEntity8(out *jwriter.Writer, in MoreReplies) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"count\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Count))
+	}
+	out.RawByte('}')
 }
-func easyjson5a72dc82DecodeTechparkDbInternalDomainEntity5(in *jlexer.Lexer, out *CreatePost) {
+
+// MarshalJSON supports json.Marshaler interface
+func (v MoreReplies) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity8(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MoreReplies) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity8(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MoreReplies) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity8(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MoreReplies) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity8(l, v)
+}
+func easyjson5a72dc82DecodeTechparkDbInternalDomainEntity9(in *jlexer.Lexer, out *CreatePosts) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(CreatePosts, 0, 1)
+			} else {
+				*out = CreatePosts{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v7 CreatePost
+			(v7).UnmarshalEasyJSON(in)
+			*out = append(*out, v7)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson5a72dc82EncodeTechparkDbInternalDomainEntity9(out *jwriter.Writer, in CreatePosts) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v8, v9 := range in {
+			if v8 > 0 {
+				out.RawByte(',')
+			}
+			(v9).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CreatePosts) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity9(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CreatePosts) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity9(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CreatePosts) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity9(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CreatePosts) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity9(l, v)
+}
+func easyjson5a72dc82DecodeTechparkDbInternalDomainEntity10(in *jlexer.Lexer, out *CreatePost) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -542,7 +957,7 @@ func easyjson5a72dc82DecodeTechparkDbInternalDomainEntity5(in *jlexer.Lexer, out
 		in.Consumed()
 	}
 }
-func easyjson5a72dc82EncodeTechparkDbInternalDomainEntity5(out *jwriter.Writer, in CreatePost) {
+func easyjson5a72dc82EncodeTechparkDbInternalDomainEntity10(out *jwriter.Writer, in CreatePost) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -567,23 +982,23 @@ func easyjson5a72dc82EncodeTechparkDbInternalDomainEntity5(out *jwriter.Writer,
 // MarshalJSON supports json.Marshaler interface
 func (v CreatePost) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity5(&w, v)
+	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity10(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v CreatePost) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity5(w, v)
+	easyjson5a72dc82EncodeTechparkDbInternalDomainEntity10(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *CreatePost) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity5(&r, v)
+	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity10(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *CreatePost) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity5(l, v)
+	easyjson5a72dc82DecodeTechparkDbInternalDomainEntity10(l, v)
 }
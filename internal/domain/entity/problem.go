@@ -0,0 +1,20 @@
+package entity
+
+// Problem is the application/problem+json body (RFC 7807) of every error.
+// Message repeats Detail for the clients of the former {"message"} body.
+type Problem struct {
+	Type    string       `json:"type" msg:"type"`
+	Title   string       `json:"title" msg:"title"`
+	Status  int          `json:"status" msg:"status"`
+	Detail  string       `json:"detail" msg:"detail"`
+	Code    string       `json:"code" msg:"code"`
+	Message string       `json:"message" msg:"message"`
+	Errors  []FieldError `json:"errors,omitempty" msg:"errors,omitempty"`
+}
+
+// FieldError describes an invalid field of the body or the query.
+type FieldError struct {
+	Field   string `json:"field" msg:"field"`
+	Rule    string `json:"rule" msg:"rule"`
+	Message string `json:"message" msg:"message"`
+}
@@ -0,0 +1,969 @@
+package entity
+
+import (
+	"google.golang.org/protobuf/proto"
+	"techpark_db/internal/domain/entity/pb"
+)
+
+// MarshalProto and UnmarshalProto encode the entities with the messages of
+// pb/entity.proto for the application/x-protobuf clients. Proto and the
+// FromProto functions convert them for the gRPC API.
+
+func (v User) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *User) UnmarshalProto(data []byte) error {
+	m := &pb.User{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = UserFromProto(m)
+	return nil
+}
+
+func (v User) Proto() *pb.User {
+	return &pb.User{
+		Nickname: v.Nickname,
+		Fullname: v.Fullname,
+		About:    v.About,
+		Email:    v.Email,
+	}
+}
+
+func UserFromProto(m *pb.User) User {
+	return User{
+		Nickname: m.GetNickname(),
+		Fullname: m.GetFullname(),
+		About:    m.GetAbout(),
+		Email:    m.GetEmail(),
+	}
+}
+
+func (v CreateUser) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *CreateUser) UnmarshalProto(data []byte) error {
+	m := &pb.CreateUser{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = CreateUserFromProto(m)
+	return nil
+}
+
+func (v CreateUser) Proto() *pb.CreateUser {
+	return &pb.CreateUser{
+		Fullname: v.Fullname,
+		About:    v.About,
+		Email:    v.Email,
+	}
+}
+
+func CreateUserFromProto(m *pb.CreateUser) CreateUser {
+	return CreateUser{
+		Fullname: m.GetFullname(),
+		About:    m.GetAbout(),
+		Email:    m.GetEmail(),
+	}
+}
+
+func (v UpdateUser) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *UpdateUser) UnmarshalProto(data []byte) error {
+	m := &pb.UpdateUser{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = UpdateUserFromProto(m)
+	return nil
+}
+
+func (v UpdateUser) Proto() *pb.UpdateUser {
+	return &pb.UpdateUser{
+		Fullname: v.Fullname,
+		About:    v.About,
+		Email:    v.Email,
+	}
+}
+
+func UpdateUserFromProto(m *pb.UpdateUser) UpdateUser {
+	return UpdateUser{
+		Fullname: m.GetFullname(),
+		About:    m.GetAbout(),
+		Email:    m.GetEmail(),
+	}
+}
+
+func (v RenameUser) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *RenameUser) UnmarshalProto(data []byte) error {
+	m := &pb.RenameUser{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = RenameUserFromProto(m)
+	return nil
+}
+
+func (v RenameUser) Proto() *pb.RenameUser {
+	return &pb.RenameUser{
+		Nickname: v.Nickname,
+	}
+}
+
+func RenameUserFromProto(m *pb.RenameUser) RenameUser {
+	return RenameUser{
+		Nickname: m.GetNickname(),
+	}
+}
+
+func (v Forum) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *Forum) UnmarshalProto(data []byte) error {
+	m := &pb.Forum{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = ForumFromProto(m)
+	return nil
+}
+
+func (v Forum) Proto() *pb.Forum {
+	return &pb.Forum{
+		Title:   v.Title,
+		User:    v.User,
+		Slug:    v.Slug,
+		Posts:   int64(v.Posts),
+		Threads: int64(v.Threads),
+	}
+}
+
+func ForumFromProto(m *pb.Forum) Forum {
+	return Forum{
+		Title:   m.GetTitle(),
+		User:    m.GetUser(),
+		Slug:    m.GetSlug(),
+		Posts:   int(m.GetPosts()),
+		Threads: int(m.GetThreads()),
+	}
+}
+
+func (v CreateForum) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *CreateForum) UnmarshalProto(data []byte) error {
+	m := &pb.CreateForum{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = CreateForumFromProto(m)
+	return nil
+}
+
+func (v CreateForum) Proto() *pb.CreateForum {
+	return &pb.CreateForum{
+		Title: v.Title,
+		User:  v.User,
+		Slug:  v.Slug,
+	}
+}
+
+func CreateForumFromProto(m *pb.CreateForum) CreateForum {
+	return CreateForum{
+		Title: m.GetTitle(),
+		User:  m.GetUser(),
+		Slug:  m.GetSlug(),
+	}
+}
+
+func (v Thread) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *Thread) UnmarshalProto(data []byte) error {
+	m := &pb.Thread{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = ThreadFromProto(m)
+	return nil
+}
+
+func (v Thread) Proto() *pb.Thread {
+	return &pb.Thread{
+		Id:      int64(v.Id),
+		Title:   v.Title,
+		Author:  v.Author,
+		Forum:   v.Forum,
+		Message: v.Message,
+		Votes:   int64(v.Votes),
+		Slug:    v.Slug,
+		Created: v.Created,
+	}
+}
+
+func ThreadFromProto(m *pb.Thread) Thread {
+	return Thread{
+		Id:      int(m.GetId()),
+		Title:   m.GetTitle(),
+		Author:  m.GetAuthor(),
+		Forum:   m.GetForum(),
+		Message: m.GetMessage(),
+		Votes:   int(m.GetVotes()),
+		Slug:    m.GetSlug(),
+		Created: m.GetCreated(),
+	}
+}
+
+func (v CreateThread) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *CreateThread) UnmarshalProto(data []byte) error {
+	m := &pb.CreateThread{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = CreateThreadFromProto(m)
+	return nil
+}
+
+func (v CreateThread) Proto() *pb.CreateThread {
+	return &pb.CreateThread{
+		Title:   v.Title,
+		Author:  v.Author,
+		Message: v.Message,
+		Slug:    v.Slug,
+		Created: v.Created,
+	}
+}
+
+func CreateThreadFromProto(m *pb.CreateThread) CreateThread {
+	return CreateThread{
+		Title:   m.GetTitle(),
+		Author:  m.GetAuthor(),
+		Message: m.GetMessage(),
+		Slug:    m.GetSlug(),
+		Created: m.GetCreated(),
+	}
+}
+
+func (v MoveThread) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *MoveThread) UnmarshalProto(data []byte) error {
+	m := &pb.MoveThread{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = MoveThreadFromProto(m)
+	return nil
+}
+
+func (v MoveThread) Proto() *pb.MoveThread {
+	return &pb.MoveThread{
+		Forum: v.Forum,
+		Stub:  v.Stub,
+	}
+}
+
+func MoveThreadFromProto(m *pb.MoveThread) MoveThread {
+	return MoveThread{
+		Forum: m.GetForum(),
+		Stub:  m.GetStub(),
+	}
+}
+
+func (v MergeThread) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *MergeThread) UnmarshalProto(data []byte) error {
+	m := &pb.MergeThread{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = MergeThreadFromProto(m)
+	return nil
+}
+
+func (v MergeThread) Proto() *pb.MergeThread {
+	return &pb.MergeThread{
+		Target: v.Target,
+	}
+}
+
+func MergeThreadFromProto(m *pb.MergeThread) MergeThread {
+	return MergeThread{
+		Target: m.GetTarget(),
+	}
+}
+
+func (v ThreadResponse) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *ThreadResponse) UnmarshalProto(data []byte) error {
+	m := &pb.ThreadResponse{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = ThreadResponseFromProto(m)
+	return nil
+}
+
+func (v ThreadResponse) Proto() *pb.ThreadResponse {
+	return &pb.ThreadResponse{
+		Id:      int64(v.Id),
+		Title:   v.Title,
+		Author:  v.Author,
+		Forum:   v.Forum,
+		Message: v.Message,
+		Votes:   int64(v.Votes),
+		Created: v.Created,
+	}
+}
+
+func ThreadResponseFromProto(m *pb.ThreadResponse) ThreadResponse {
+	return ThreadResponse{
+		Id:      int(m.GetId()),
+		Title:   m.GetTitle(),
+		Author:  m.GetAuthor(),
+		Forum:   m.GetForum(),
+		Message: m.GetMessage(),
+		Votes:   int(m.GetVotes()),
+		Created: m.GetCreated(),
+	}
+}
+
+func (v Post) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *Post) UnmarshalProto(data []byte) error {
+	m := &pb.Post{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = PostFromProto(m)
+	return nil
+}
+
+func (v Post) Proto() *pb.Post {
+	return &pb.Post{
+		Id:       int64(v.Id),
+		Parent:   int64(v.Parent),
+		Author:   v.Author,
+		Message:  v.Message,
+		IsEdited: v.IsEdited,
+		Forum:    v.Forum,
+		Thread:   int64(v.Thread),
+		Created:  v.Created,
+	}
+}
+
+func PostFromProto(m *pb.Post) Post {
+	return Post{
+		Id:       int(m.GetId()),
+		Parent:   int(m.GetParent()),
+		Author:   m.GetAuthor(),
+		Message:  m.GetMessage(),
+		IsEdited: m.GetIsEdited(),
+		Forum:    m.GetForum(),
+		Thread:   int(m.GetThread()),
+		Created:  m.GetCreated(),
+	}
+}
+
+func (v PostNode) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *PostNode) UnmarshalProto(data []byte) error {
+	m := &pb.PostNode{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = PostNodeFromProto(m)
+	return nil
+}
+
+func (v PostNode) Proto() *pb.PostNode {
+	m := &pb.PostNode{
+		Id:       int64(v.Id),
+		Parent:   int64(v.Parent),
+		Author:   v.Author,
+		Message:  v.Message,
+		IsEdited: v.IsEdited,
+		Forum:    v.Forum,
+		Thread:   int64(v.Thread),
+		Created:  v.Created,
+		Children: v.Children.Proto().Nodes,
+	}
+	if v.More != nil {
+		m.More = v.More.Proto()
+	}
+	return m
+}
+
+func PostNodeFromProto(m *pb.PostNode) PostNode {
+	v := PostNode{
+		Id:       int(m.GetId()),
+		Parent:   int(m.GetParent()),
+		Author:   m.GetAuthor(),
+		Message:  m.GetMessage(),
+		IsEdited: m.GetIsEdited(),
+		Forum:    m.GetForum(),
+		Thread:   int(m.GetThread()),
+		Created:  m.GetCreated(),
+		Children: PostNodesFromProto(&pb.PostNodes{Nodes: m.GetChildren()}),
+	}
+	if m.GetMore() != nil {
+		more := MoreRepliesFromProto(m.GetMore())
+		v.More = &more
+	}
+	return v
+}
+
+func (v MoreReplies) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *MoreReplies) UnmarshalProto(data []byte) error {
+	m := &pb.MoreReplies{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = MoreRepliesFromProto(m)
+	return nil
+}
+
+func (v MoreReplies) Proto() *pb.MoreReplies {
+	return &pb.MoreReplies{
+		Count: int64(v.Count),
+	}
+}
+
+func MoreRepliesFromProto(m *pb.MoreReplies) MoreReplies {
+	return MoreReplies{
+		Count: int(m.GetCount()),
+	}
+}
+
+func (v CreatePost) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *CreatePost) UnmarshalProto(data []byte) error {
+	m := &pb.CreatePost{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = CreatePostFromProto(m)
+	return nil
+}
+
+func (v CreatePost) Proto() *pb.CreatePost {
+	return &pb.CreatePost{
+		Parent:  int64(v.Parent),
+		Author:  v.Author,
+		Message: v.Message,
+	}
+}
+
+func CreatePostFromProto(m *pb.CreatePost) CreatePost {
+	return CreatePost{
+		Parent:  int(m.GetParent()),
+		Author:  m.GetAuthor(),
+		Message: m.GetMessage(),
+	}
+}
+
+func (v PostDetails) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *PostDetails) UnmarshalProto(data []byte) error {
+	m := &pb.PostDetails{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = PostDetailsFromProto(m)
+	return nil
+}
+
+func (v PostDetails) Proto() *pb.PostDetails {
+	m := &pb.PostDetails{}
+	if v.DPost != nil {
+		m.Post = v.DPost.Proto()
+	}
+	if v.DAuthor != nil {
+		m.Author = v.DAuthor.Proto()
+	}
+	if v.DThread != nil {
+		m.Thread = v.DThread.Proto()
+	}
+	if v.DForum != nil {
+		m.Forum = v.DForum.Proto()
+	}
+	return m
+}
+
+func PostDetailsFromProto(m *pb.PostDetails) PostDetails {
+	v := PostDetails{}
+	if m.GetPost() != nil {
+		dPost := PostFromProto(m.GetPost())
+		v.DPost = &dPost
+	}
+	if m.GetAuthor() != nil {
+		dAuthor := UserFromProto(m.GetAuthor())
+		v.DAuthor = &dAuthor
+	}
+	if m.GetThread() != nil {
+		dThread := ThreadFromProto(m.GetThread())
+		v.DThread = &dThread
+	}
+	if m.GetForum() != nil {
+		dForum := ForumFromProto(m.GetForum())
+		v.DForum = &dForum
+	}
+	return v
+}
+
+func (v UpdatePost) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *UpdatePost) UnmarshalProto(data []byte) error {
+	m := &pb.UpdatePost{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = UpdatePostFromProto(m)
+	return nil
+}
+
+func (v UpdatePost) Proto() *pb.UpdatePost {
+	return &pb.UpdatePost{
+		Message: v.Message,
+	}
+}
+
+func UpdatePostFromProto(m *pb.UpdatePost) UpdatePost {
+	return UpdatePost{
+		Message: m.GetMessage(),
+	}
+}
+
+func (v SplitPost) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *SplitPost) UnmarshalProto(data []byte) error {
+	m := &pb.SplitPost{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = SplitPostFromProto(m)
+	return nil
+}
+
+func (v SplitPost) Proto() *pb.SplitPost {
+	return &pb.SplitPost{
+		Title:  v.Title,
+		Author: v.Author,
+		Slug:   v.Slug,
+	}
+}
+
+func SplitPostFromProto(m *pb.SplitPost) SplitPost {
+	return SplitPost{
+		Title:  m.GetTitle(),
+		Author: m.GetAuthor(),
+		Slug:   m.GetSlug(),
+	}
+}
+
+func (v PostWithoutEdited) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *PostWithoutEdited) UnmarshalProto(data []byte) error {
+	m := &pb.PostWithoutEdited{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = PostWithoutEditedFromProto(m)
+	return nil
+}
+
+func (v PostWithoutEdited) Proto() *pb.PostWithoutEdited {
+	return &pb.PostWithoutEdited{
+		Id:      int64(v.Id),
+		Parent:  int64(v.Parent),
+		Author:  v.Author,
+		Message: v.Message,
+		Forum:   v.Forum,
+		Thread:  int64(v.Thread),
+		Created: v.Created,
+	}
+}
+
+func PostWithoutEditedFromProto(m *pb.PostWithoutEdited) PostWithoutEdited {
+	return PostWithoutEdited{
+		Id:      int(m.GetId()),
+		Parent:  int(m.GetParent()),
+		Author:  m.GetAuthor(),
+		Message: m.GetMessage(),
+		Forum:   m.GetForum(),
+		Thread:  int(m.GetThread()),
+		Created: m.GetCreated(),
+	}
+}
+
+func (v Vote) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *Vote) UnmarshalProto(data []byte) error {
+	m := &pb.Vote{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = VoteFromProto(m)
+	return nil
+}
+
+func (v Vote) Proto() *pb.Vote {
+	return &pb.Vote{
+		IdThread:   int64(v.IdThread),
+		SlugThread: v.SlugThread,
+		Nickname:   v.Nickname,
+		Voice:      int64(v.Voice),
+	}
+}
+
+func VoteFromProto(m *pb.Vote) Vote {
+	return Vote{
+		IdThread:   int(m.GetIdThread()),
+		SlugThread: m.GetSlugThread(),
+		Nickname:   m.GetNickname(),
+		Voice:      int(m.GetVoice()),
+	}
+}
+
+func (v ServStatus) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *ServStatus) UnmarshalProto(data []byte) error {
+	m := &pb.ServStatus{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = ServStatusFromProto(m)
+	return nil
+}
+
+func (v ServStatus) Proto() *pb.ServStatus {
+	return &pb.ServStatus{
+		User:   int64(v.User),
+		Forum:  int64(v.Forum),
+		Thread: int64(v.Thread),
+		Post:   int64(v.Post),
+	}
+}
+
+func ServStatusFromProto(m *pb.ServStatus) ServStatus {
+	return ServStatus{
+		User:   int(m.GetUser()),
+		Forum:  int(m.GetForum()),
+		Thread: int(m.GetThread()),
+		Post:   int(m.GetPost()),
+	}
+}
+
+func (v CacheStats) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *CacheStats) UnmarshalProto(data []byte) error {
+	m := &pb.CacheStats{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = CacheStatsFromProto(m)
+	return nil
+}
+
+func (v CacheStats) Proto() *pb.CacheStats {
+	return &pb.CacheStats{
+		Hits:   v.Hits,
+		Misses: v.Misses,
+		Size:   int64(v.Size),
+	}
+}
+
+func CacheStatsFromProto(m *pb.CacheStats) CacheStats {
+	return CacheStats{
+		Hits:   m.GetHits(),
+		Misses: m.GetMisses(),
+		Size:   int(m.GetSize()),
+	}
+}
+
+func (v CacheStatus) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *CacheStatus) UnmarshalProto(data []byte) error {
+	m := &pb.CacheStatus{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = CacheStatusFromProto(m)
+	return nil
+}
+
+func (v CacheStatus) Proto() *pb.CacheStatus {
+	return &pb.CacheStatus{
+		User:   v.User.Proto(),
+		Forum:  v.Forum.Proto(),
+		Thread: v.Thread.Proto(),
+	}
+}
+
+func CacheStatusFromProto(m *pb.CacheStatus) CacheStatus {
+	return CacheStatus{
+		User:   CacheStatsFromProto(m.GetUser()),
+		Forum:  CacheStatsFromProto(m.GetForum()),
+		Thread: CacheStatsFromProto(m.GetThread()),
+	}
+}
+
+func (v Problem) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *Problem) UnmarshalProto(data []byte) error {
+	m := &pb.Problem{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = ProblemFromProto(m)
+	return nil
+}
+
+func (v Problem) Proto() *pb.Problem {
+	m := &pb.Problem{
+		Type:    v.Type,
+		Title:   v.Title,
+		Status:  int64(v.Status),
+		Detail:  v.Detail,
+		Code:    v.Code,
+		Message: v.Message,
+	}
+	for _, fieldError := range v.Errors {
+		m.Errors = append(m.Errors, fieldError.Proto())
+	}
+	return m
+}
+
+func ProblemFromProto(m *pb.Problem) Problem {
+	v := Problem{
+		Type:    m.GetType(),
+		Title:   m.GetTitle(),
+		Status:  int(m.GetStatus()),
+		Detail:  m.GetDetail(),
+		Code:    m.GetCode(),
+		Message: m.GetMessage(),
+	}
+	for _, fieldError := range m.GetErrors() {
+		v.Errors = append(v.Errors, FieldErrorFromProto(fieldError))
+	}
+	return v
+}
+
+func (v FieldError) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *FieldError) UnmarshalProto(data []byte) error {
+	m := &pb.FieldError{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = FieldErrorFromProto(m)
+	return nil
+}
+
+func (v FieldError) Proto() *pb.FieldError {
+	return &pb.FieldError{
+		Field:   v.Field,
+		Rule:    v.Rule,
+		Message: v.Message,
+	}
+}
+
+func FieldErrorFromProto(m *pb.FieldError) FieldError {
+	return FieldError{
+		Field:   m.GetField(),
+		Rule:    m.GetRule(),
+		Message: m.GetMessage(),
+	}
+}
+
+func (v Users) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *Users) UnmarshalProto(data []byte) error {
+	m := &pb.Users{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = UsersFromProto(m)
+	return nil
+}
+
+func (v Users) Proto() *pb.Users {
+	m := &pb.Users{Users: make([]*pb.User, len(v))}
+	for i := range v {
+		m.Users[i] = v[i].Proto()
+	}
+	return m
+}
+
+func UsersFromProto(m *pb.Users) Users {
+	v := make(Users, len(m.GetUsers()))
+	for i, item := range m.GetUsers() {
+		v[i] = UserFromProto(item)
+	}
+	return v
+}
+
+func (v Threads) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *Threads) UnmarshalProto(data []byte) error {
+	m := &pb.Threads{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = ThreadsFromProto(m)
+	return nil
+}
+
+func (v Threads) Proto() *pb.Threads {
+	m := &pb.Threads{Threads: make([]*pb.Thread, len(v))}
+	for i := range v {
+		m.Threads[i] = v[i].Proto()
+	}
+	return m
+}
+
+func ThreadsFromProto(m *pb.Threads) Threads {
+	v := make(Threads, len(m.GetThreads()))
+	for i, item := range m.GetThreads() {
+		v[i] = ThreadFromProto(item)
+	}
+	return v
+}
+
+func (v Posts) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *Posts) UnmarshalProto(data []byte) error {
+	m := &pb.Posts{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = PostsFromProto(m)
+	return nil
+}
+
+func (v Posts) Proto() *pb.Posts {
+	m := &pb.Posts{Posts: make([]*pb.Post, len(v))}
+	for i := range v {
+		m.Posts[i] = v[i].Proto()
+	}
+	return m
+}
+
+func PostsFromProto(m *pb.Posts) Posts {
+	v := make(Posts, len(m.GetPosts()))
+	for i, item := range m.GetPosts() {
+		v[i] = PostFromProto(item)
+	}
+	return v
+}
+
+func (v PostNodes) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *PostNodes) UnmarshalProto(data []byte) error {
+	m := &pb.PostNodes{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = PostNodesFromProto(m)
+	return nil
+}
+
+func (v PostNodes) Proto() *pb.PostNodes {
+	m := &pb.PostNodes{Nodes: make([]*pb.PostNode, len(v))}
+	for i := range v {
+		m.Nodes[i] = v[i].Proto()
+	}
+	return m
+}
+
+func PostNodesFromProto(m *pb.PostNodes) PostNodes {
+	v := make(PostNodes, len(m.GetNodes()))
+	for i, item := range m.GetNodes() {
+		v[i] = PostNodeFromProto(item)
+	}
+	return v
+}
+
+func (v CreatePosts) MarshalProto() ([]byte, error) {
+	return proto.Marshal(v.Proto())
+}
+
+func (v *CreatePosts) UnmarshalProto(data []byte) error {
+	m := &pb.CreatePosts{}
+	if err := proto.Unmarshal(data, m); err != nil {
+		return err
+	}
+	*v = CreatePostsFromProto(m)
+	return nil
+}
+
+func (v CreatePosts) Proto() *pb.CreatePosts {
+	m := &pb.CreatePosts{Posts: make([]*pb.CreatePost, len(v))}
+	for i := range v {
+		m.Posts[i] = v[i].Proto()
+	}
+	return m
+}
+
+func CreatePostsFromProto(m *pb.CreatePosts) CreatePosts {
+	v := make(CreatePosts, len(m.GetPosts()))
+	for i, item := range m.GetPosts() {
+		v[i] = CreatePostFromProto(item)
+	}
+	return v
+}
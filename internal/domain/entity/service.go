@@ -1,8 +1,20 @@
 package entity
 
 type ServStatus struct {
-	User   int `json:"user"`
-	Forum  int `json:"forum"`
-	Thread int `json:"thread"`
-	Post   int `json:"post"`
+	User   int `json:"user" msg:"user"`
+	Forum  int `json:"forum" msg:"forum"`
+	Thread int `json:"thread" msg:"thread"`
+	Post   int `json:"post" msg:"post"`
+}
+
+type CacheStats struct {
+	Hits   uint64 `json:"hits" msg:"hits"`
+	Misses uint64 `json:"misses" msg:"misses"`
+	Size   int    `json:"size" msg:"size"`
+}
+
+type CacheStatus struct {
+	User   CacheStats `json:"user" msg:"user"`
+	Forum  CacheStats `json:"forum" msg:"forum"`
+	Thread CacheStats `json:"thread" msg:"thread"`
 }
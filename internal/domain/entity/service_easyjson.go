@@ -104,3 +104,163 @@ func (v *ServStatus) UnmarshalJSON(data []byte) error {
 func (v *ServStatus) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjsonCd93bc43DecodeTechparkDbInternalDomainEntity(l, v)
 }
+func easyjsonCd93bc43DecodeTechparkDbInternalDomainEntity1(in *jlexer.Lexer, out *CacheStatus) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "user":
+			(out.User).UnmarshalEasyJSON(in)
+		case "forum":
+			(out.Forum).UnmarshalEasyJSON(in)
+		case "thread":
+			(out.Thread).UnmarshalEasyJSON(in)
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonCd93bc43EncodeTechparkDbInternalDomainEntity1(out *jwriter.Writer, in CacheStatus) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"user\":"
+		out.RawString(prefix[1:])
+		(in.User).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"forum\":"
+		out.RawString(prefix)
+		(in.Forum).MarshalEasyJSON(out)
+	}
+	{
+		const prefix string = ",\"thread\":"
+		out.RawString(prefix)
+		(in.Thread).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CacheStatus) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonCd93bc43EncodeTechparkDbInternalDomainEntity1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CacheStatus) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonCd93bc43EncodeTechparkDbInternalDomainEntity1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CacheStatus) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonCd93bc43DecodeTechparkDbInternalDomainEntity1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CacheStatus) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonCd93bc43DecodeTechparkDbInternalDomainEntity1(l, v)
+}
+func easyjsonCd93bc43DecodeTechparkDbInternalDomainEntity2(in *jlexer.Lexer, out *CacheStats) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "hits":
+			out.Hits = uint64(in.Uint64())
+		case "misses":
+			out.Misses = uint64(in.Uint64())
+		case "size":
+			out.Size = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonCd93bc43EncodeTechparkDbInternalDomainEntity2(out *jwriter.Writer, in CacheStats) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"hits\":"
+		out.RawString(prefix[1:])
+		out.Uint64(uint64(in.Hits))
+	}
+	{
+		const prefix string = ",\"misses\":"
+		out.RawString(prefix)
+		out.Uint64(uint64(in.Misses))
+	}
+	{
+		const prefix string = ",\"size\":"
+		out.RawString(prefix)
+		out.Int(int(in.Size))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v CacheStats) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonCd93bc43EncodeTechparkDbInternalDomainEntity2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v CacheStats) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonCd93bc43EncodeTechparkDbInternalDomainEntity2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *CacheStats) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonCd93bc43DecodeTechparkDbInternalDomainEntity2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *CacheStats) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonCd93bc43DecodeTechparkDbInternalDomainEntity2(l, v)
+}
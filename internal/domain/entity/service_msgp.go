@@ -0,0 +1,358 @@
+package entity
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z CacheStats) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "hits"
+	o = append(o, 0x83, 0xa4, 0x68, 0x69, 0x74, 0x73)
+	o = msgp.AppendUint64(o, z.Hits)
+	// string "misses"
+	o = append(o, 0xa6, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x73)
+	o = msgp.AppendUint64(o, z.Misses)
+	// string "size"
+	o = append(o, 0xa4, 0x73, 0x69, 0x7a, 0x65)
+	o = msgp.AppendInt(o, z.Size)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *CacheStats) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "hits":
+			z.Hits, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Hits")
+				return
+			}
+		case "misses":
+			z.Misses, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Misses")
+				return
+			}
+		case "size":
+			z.Size, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Size")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z CacheStats) Msgsize() (s int) {
+	s = 1 + 5 + msgp.Uint64Size + 7 + msgp.Uint64Size + 5 + msgp.IntSize
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *CacheStatus) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "user"
+	o = append(o, 0x83, 0xa4, 0x75, 0x73, 0x65, 0x72)
+	// map header, size 3
+	// string "hits"
+	o = append(o, 0x83, 0xa4, 0x68, 0x69, 0x74, 0x73)
+	o = msgp.AppendUint64(o, z.User.Hits)
+	// string "misses"
+	o = append(o, 0xa6, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x73)
+	o = msgp.AppendUint64(o, z.User.Misses)
+	// string "size"
+	o = append(o, 0xa4, 0x73, 0x69, 0x7a, 0x65)
+	o = msgp.AppendInt(o, z.User.Size)
+	// string "forum"
+	o = append(o, 0xa5, 0x66, 0x6f, 0x72, 0x75, 0x6d)
+	// map header, size 3
+	// string "hits"
+	o = append(o, 0x83, 0xa4, 0x68, 0x69, 0x74, 0x73)
+	o = msgp.AppendUint64(o, z.Forum.Hits)
+	// string "misses"
+	o = append(o, 0xa6, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x73)
+	o = msgp.AppendUint64(o, z.Forum.Misses)
+	// string "size"
+	o = append(o, 0xa4, 0x73, 0x69, 0x7a, 0x65)
+	o = msgp.AppendInt(o, z.Forum.Size)
+	// string "thread"
+	o = append(o, 0xa6, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64)
+	// map header, size 3
+	// string "hits"
+	o = append(o, 0x83, 0xa4, 0x68, 0x69, 0x74, 0x73)
+	o = msgp.AppendUint64(o, z.Thread.Hits)
+	// string "misses"
+	o = append(o, 0xa6, 0x6d, 0x69, 0x73, 0x73, 0x65, 0x73)
+	o = msgp.AppendUint64(o, z.Thread.Misses)
+	// string "size"
+	o = append(o, 0xa4, 0x73, 0x69, 0x7a, 0x65)
+	o = msgp.AppendInt(o, z.Thread.Size)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *CacheStatus) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "user":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "User")
+				return
+			}
+			for zb0002 > 0 {
+				zb0002--
+				field, bts, err = msgp.ReadMapKeyZC(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "User")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "hits":
+					z.User.Hits, bts, err = msgp.ReadUint64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "User", "Hits")
+						return
+					}
+				case "misses":
+					z.User.Misses, bts, err = msgp.ReadUint64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "User", "Misses")
+						return
+					}
+				case "size":
+					z.User.Size, bts, err = msgp.ReadIntBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "User", "Size")
+						return
+					}
+				default:
+					bts, err = msgp.Skip(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "User")
+						return
+					}
+				}
+			}
+		case "forum":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Forum")
+				return
+			}
+			for zb0003 > 0 {
+				zb0003--
+				field, bts, err = msgp.ReadMapKeyZC(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Forum")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "hits":
+					z.Forum.Hits, bts, err = msgp.ReadUint64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Forum", "Hits")
+						return
+					}
+				case "misses":
+					z.Forum.Misses, bts, err = msgp.ReadUint64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Forum", "Misses")
+						return
+					}
+				case "size":
+					z.Forum.Size, bts, err = msgp.ReadIntBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Forum", "Size")
+						return
+					}
+				default:
+					bts, err = msgp.Skip(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Forum")
+						return
+					}
+				}
+			}
+		case "thread":
+			var zb0004 uint32
+			zb0004, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Thread")
+				return
+			}
+			for zb0004 > 0 {
+				zb0004--
+				field, bts, err = msgp.ReadMapKeyZC(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Thread")
+					return
+				}
+				switch msgp.UnsafeString(field) {
+				case "hits":
+					z.Thread.Hits, bts, err = msgp.ReadUint64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Thread", "Hits")
+						return
+					}
+				case "misses":
+					z.Thread.Misses, bts, err = msgp.ReadUint64Bytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Thread", "Misses")
+						return
+					}
+				case "size":
+					z.Thread.Size, bts, err = msgp.ReadIntBytes(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Thread", "Size")
+						return
+					}
+				default:
+					bts, err = msgp.Skip(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "Thread")
+						return
+					}
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *CacheStatus) Msgsize() (s int) {
+	s = 1 + 5 + 1 + 5 + msgp.Uint64Size + 7 + msgp.Uint64Size + 5 + msgp.IntSize + 6 + 1 + 5 + msgp.Uint64Size + 7 + msgp.Uint64Size + 5 + msgp.IntSize + 7 + 1 + 5 + msgp.Uint64Size + 7 + msgp.Uint64Size + 5 + msgp.IntSize
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *ServStatus) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "user"
+	o = append(o, 0x84, 0xa4, 0x75, 0x73, 0x65, 0x72)
+	o = msgp.AppendInt(o, z.User)
+	// string "forum"
+	o = append(o, 0xa5, 0x66, 0x6f, 0x72, 0x75, 0x6d)
+	o = msgp.AppendInt(o, z.Forum)
+	// string "thread"
+	o = append(o, 0xa6, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64)
+	o = msgp.AppendInt(o, z.Thread)
+	// string "post"
+	o = append(o, 0xa4, 0x70, 0x6f, 0x73, 0x74)
+	o = msgp.AppendInt(o, z.Post)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *ServStatus) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "user":
+			z.User, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "User")
+				return
+			}
+		case "forum":
+			z.Forum, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Forum")
+				return
+			}
+		case "thread":
+			z.Thread, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Thread")
+				return
+			}
+		case "post":
+			z.Post, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Post")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *ServStatus) Msgsize() (s int) {
+	s = 1 + 5 + msgp.IntSize + 6 + msgp.IntSize + 7 + msgp.IntSize + 5 + msgp.IntSize
+	return
+}
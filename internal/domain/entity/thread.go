@@ -1,33 +1,46 @@
 package entity
 
+import "time"
+
 type CreateThread struct {
-	Title   string `json:"title"`
-	Author  string `json:"author"`
-	Message string `json:"message"`
-	Slug    string `json:"slug"`
-	Created string `json:"created"`
+	Title   string `json:"title" msg:"title" validate:"required,max=100"`
+	Author  string `json:"author" msg:"author" validate:"required,slug"`
+	Message string `json:"message" msg:"message"`
+	Slug    string `json:"slug" msg:"slug" validate:"slug,nonnumeric"`
+	Created string `json:"created" msg:"created" validate:"datetime"`
 }
 
 type Thread struct {
-	Id      int    `json:"id"`
-	Title   string `json:"title"`
-	Author  string `json:"author"`
-	Forum   string `json:"forum"`
-	Message string `json:"message"`
-	Votes   int    `json:"votes"`
-	Slug    string `json:"slug"`
-	Created string `json:"created"`
+	Id       int       `json:"id" msg:"id"`
+	Title    string    `json:"title" msg:"title" validate:"max=100"`
+	Author   string    `json:"author" msg:"author"`
+	Forum    string    `json:"forum" msg:"forum"`
+	Message  string    `json:"message" msg:"message"`
+	Votes    int       `json:"votes" msg:"votes"`
+	Slug     string    `json:"slug" msg:"slug"`
+	Created  string    `json:"created" msg:"created"`
+	Version  int       `json:"-" msg:"-"`
+	Modified time.Time `json:"-" msg:"-"`
 }
 
 //easyjson:json
 type Threads []Thread
 
+type MoveThread struct {
+	Forum string `json:"forum" msg:"forum" validate:"required,slug"`
+	Stub  bool   `json:"stub" msg:"stub"`
+}
+
+type MergeThread struct {
+	Target string `json:"target" msg:"target" validate:"required,slug"`
+}
+
 type ThreadResponse struct {
-	Id      int    `json:"id"`
-	Title   string `json:"title"`
-	Author  string `json:"author"`
-	Forum   string `json:"forum"`
-	Message string `json:"message"`
-	Votes   int    `json:"votes"`
-	Created string `json:"created"`
+	Id      int    `json:"id" msg:"id"`
+	Title   string `json:"title" msg:"title"`
+	Author  string `json:"author" msg:"author"`
+	Forum   string `json:"forum" msg:"forum"`
+	Message string `json:"message" msg:"message"`
+	Votes   int    `json:"votes" msg:"votes"`
+	Created string `json:"created" msg:"created"`
 }
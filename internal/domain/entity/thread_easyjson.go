@@ -306,7 +306,146 @@ func (v *Thread) UnmarshalJSON(data []byte) error {
 func (v *Thread) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson2d00218DecodeTechparkDbInternalDomainEntity2(l, v)
 }
-func easyjson2d00218DecodeTechparkDbInternalDomainEntity3(in *jlexer.Lexer, out *CreateThread) {
+func easyjson2d00218DecodeTechparkDbInternalDomainEntity3(in *jlexer.Lexer, out *MoveThread) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "forum":
+			out.Forum = string(in.String())
+		case "stub":
+			out.Stub = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2d00218EncodeTechparkDbInternalDomainEntity3(out *jwriter.Writer, in MoveThread) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"forum\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Forum))
+	}
+	{
+		const prefix string = ",\"stub\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Stub))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MoveThread) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2d00218EncodeTechparkDbInternalDomainEntity3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MoveThread) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2d00218EncodeTechparkDbInternalDomainEntity3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MoveThread) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2d00218DecodeTechparkDbInternalDomainEntity3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MoveThread) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2d00218DecodeTechparkDbInternalDomainEntity3(l, v)
+}
+func easyjson2d00218DecodeTechparkDbInternalDomainEntity4(in *jlexer.Lexer, out *MergeThread) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "target":
+			out.Target = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2d00218EncodeTechparkDbInternalDomainEntity4(out *jwriter.Writer, in MergeThread) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"target\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Target))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v MergeThread) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2d00218EncodeTechparkDbInternalDomainEntity4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v MergeThread) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2d00218EncodeTechparkDbInternalDomainEntity4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *MergeThread) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2d00218DecodeTechparkDbInternalDomainEntity4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *MergeThread) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2d00218DecodeTechparkDbInternalDomainEntity4(l, v)
+}
+func easyjson2d00218DecodeTechparkDbInternalDomainEntity5(in *jlexer.Lexer, out *CreateThread) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -345,7 +484,7 @@ func easyjson2d00218DecodeTechparkDbInternalDomainEntity3(in *jlexer.Lexer, out
 		in.Consumed()
 	}
 }
-func easyjson2d00218EncodeTechparkDbInternalDomainEntity3(out *jwriter.Writer, in CreateThread) {
+func easyjson2d00218EncodeTechparkDbInternalDomainEntity5(out *jwriter.Writer, in CreateThread) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -380,23 +519,23 @@ func easyjson2d00218EncodeTechparkDbInternalDomainEntity3(out *jwriter.Writer, i
 // MarshalJSON supports json.Marshaler interface
 func (v CreateThread) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson2d00218EncodeTechparkDbInternalDomainEntity3(&w, v)
+	easyjson2d00218EncodeTechparkDbInternalDomainEntity5(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v CreateThread) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson2d00218EncodeTechparkDbInternalDomainEntity3(w, v)
+	easyjson2d00218EncodeTechparkDbInternalDomainEntity5(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *CreateThread) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson2d00218DecodeTechparkDbInternalDomainEntity3(&r, v)
+	easyjson2d00218DecodeTechparkDbInternalDomainEntity5(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *CreateThread) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson2d00218DecodeTechparkDbInternalDomainEntity3(l, v)
+	easyjson2d00218DecodeTechparkDbInternalDomainEntity5(l, v)
 }
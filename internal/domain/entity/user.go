@@ -1,27 +1,31 @@
 package entity
 
+import "time"
+
 type User struct {
-	Nickname string `json:"nickname"`
-	Fullname string `json:"fullname"`
-	About    string `json:"about"`
-	Email    string `json:"email"`
+	Nickname string    `json:"nickname" msg:"nickname"`
+	Fullname string    `json:"fullname" msg:"fullname"`
+	About    string    `json:"about" msg:"about"`
+	Email    string    `json:"email" msg:"email"`
+	Version  int       `json:"-" msg:"-"`
+	Modified time.Time `json:"-" msg:"-"`
 }
 
 //easyjson:json
 type Users []User
 
 type CreateUser struct {
-	Fullname string `json:"fullname"`
-	About    string `json:"about"`
-	Email    string `json:"email"`
+	Fullname string `json:"fullname" msg:"fullname" validate:"max=100"`
+	About    string `json:"about" msg:"about"`
+	Email    string `json:"email" msg:"email" validate:"required,email"`
 }
 
 type UpdateUser struct {
-	Fullname string `json:"fullname"`
-	About    string `json:"about"`
-	Email    string `json:"email"`
+	Fullname string `json:"fullname" msg:"fullname" validate:"max=100"`
+	About    string `json:"about" msg:"about"`
+	Email    string `json:"email" msg:"email" validate:"email"`
 }
 
-type Error struct {
-	Message string `json:"message"`
+type RenameUser struct {
+	Nickname string `json:"nickname" msg:"nickname" validate:"required,slug"`
 }
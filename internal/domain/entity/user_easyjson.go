@@ -26,7 +26,7 @@ func easyjson9e1087fdDecodeTechparkDbInternalDomainEntity(in *jlexer.Lexer, out
 		in.Delim('[')
 		if *out == nil {
 			if !in.IsDelim(']') {
-				*out = make(Users, 0, 1)
+				*out = make(Users, 0, 0)
 			} else {
 				*out = Users{}
 			}
@@ -250,7 +250,7 @@ func (v *UpdateUser) UnmarshalJSON(data []byte) error {
 func (v *UpdateUser) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson9e1087fdDecodeTechparkDbInternalDomainEntity2(l, v)
 }
-func easyjson9e1087fdDecodeTechparkDbInternalDomainEntity3(in *jlexer.Lexer, out *Error) {
+func easyjson9e1087fdDecodeTechparkDbInternalDomainEntity3(in *jlexer.Lexer, out *RenameUser) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -269,8 +269,8 @@ func easyjson9e1087fdDecodeTechparkDbInternalDomainEntity3(in *jlexer.Lexer, out
 			continue
 		}
 		switch key {
-		case "message":
-			out.Message = string(in.String())
+		case "nickname":
+			out.Nickname = string(in.String())
 		default:
 			in.SkipRecursive()
 		}
@@ -281,39 +281,39 @@ func easyjson9e1087fdDecodeTechparkDbInternalDomainEntity3(in *jlexer.Lexer, out
 		in.Consumed()
 	}
 }
-func easyjson9e1087fdEncodeTechparkDbInternalDomainEntity3(out *jwriter.Writer, in Error) {
+func easyjson9e1087fdEncodeTechparkDbInternalDomainEntity3(out *jwriter.Writer, in RenameUser) {
 	out.RawByte('{')
 	first := true
 	_ = first
 	{
-		const prefix string = ",\"message\":"
+		const prefix string = ",\"nickname\":"
 		out.RawString(prefix[1:])
-		out.String(string(in.Message))
+		out.String(string(in.Nickname))
 	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
-func (v Error) MarshalJSON() ([]byte, error) {
+func (v RenameUser) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
 	easyjson9e1087fdEncodeTechparkDbInternalDomainEntity3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
-func (v Error) MarshalEasyJSON(w *jwriter.Writer) {
+func (v RenameUser) MarshalEasyJSON(w *jwriter.Writer) {
 	easyjson9e1087fdEncodeTechparkDbInternalDomainEntity3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
-func (v *Error) UnmarshalJSON(data []byte) error {
+func (v *RenameUser) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
 	easyjson9e1087fdDecodeTechparkDbInternalDomainEntity3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
-func (v *Error) UnmarshalEasyJSON(l *jlexer.Lexer) {
+func (v *RenameUser) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson9e1087fdDecodeTechparkDbInternalDomainEntity3(l, v)
 }
 func easyjson9e1087fdDecodeTechparkDbInternalDomainEntity4(in *jlexer.Lexer, out *CreateUser) {
@@ -1,8 +1,8 @@
 package entity
 
 type Vote struct {
-	IdThread   int    `json:"idThread"`
-	SlugThread string `json:"slugThread"`
-	Nickname   string `json:"nickname"`
-	Voice      int    `json:"voice"`
+	IdThread   int    `json:"idThread" msg:"idThread"`
+	SlugThread string `json:"slugThread" msg:"slugThread"`
+	Nickname   string `json:"nickname" msg:"nickname" validate:"required,slug"`
+	Voice      int    `json:"voice" msg:"voice" validate:"oneof=-1 1"`
 }
@@ -0,0 +1,86 @@
+package entity
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// MarshalMsg implements msgp.Marshaler
+func (z *Vote) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 4
+	// string "idThread"
+	o = append(o, 0x84, 0xa8, 0x69, 0x64, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64)
+	o = msgp.AppendInt(o, z.IdThread)
+	// string "slugThread"
+	o = append(o, 0xaa, 0x73, 0x6c, 0x75, 0x67, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64)
+	o = msgp.AppendString(o, z.SlugThread)
+	// string "nickname"
+	o = append(o, 0xa8, 0x6e, 0x69, 0x63, 0x6b, 0x6e, 0x61, 0x6d, 0x65)
+	o = msgp.AppendString(o, z.Nickname)
+	// string "voice"
+	o = append(o, 0xa5, 0x76, 0x6f, 0x69, 0x63, 0x65)
+	o = msgp.AppendInt(o, z.Voice)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *Vote) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "idThread":
+			z.IdThread, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "IdThread")
+				return
+			}
+		case "slugThread":
+			z.SlugThread, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "SlugThread")
+				return
+			}
+		case "nickname":
+			z.Nickname, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Nickname")
+				return
+			}
+		case "voice":
+			z.Voice, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Voice")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *Vote) Msgsize() (s int) {
+	s = 1 + 9 + msgp.IntSize + 11 + msgp.StringPrefixSize + len(z.SlugThread) + 9 + msgp.StringPrefixSize + len(z.Nickname) + 6 + msgp.IntSize
+	return
+}
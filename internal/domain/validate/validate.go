@@ -0,0 +1,193 @@
+package validate
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"techpark_db/internal/domain/entity"
+	"time"
+	"unicode/utf8"
+)
+
+// Rules are read from the validate tag, e.g. `validate:"required,max=100"`:
+//
+//	required   not empty
+//	max=N      at most N characters for strings, at most N for integers
+//	min=N      at least N characters for strings, at least N for integers
+//	slug       matches the slug and nickname routes, [A-Za-z0-9._-]+
+//	nonnumeric not digits only, which the slug_or_id routes read as an id
+//	email      looks like an email address
+//	datetime   RFC 3339 date and time
+//	oneof=A B  one of the space-separated values
+//
+// Rules other than required skip empty strings.
+const TAG = "validate"
+
+var (
+	slugPattern    = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+	emailPattern   = regexp.MustCompile(`^[^@\s]+@[^@\s]+\.[^@\s]+$`)
+	numericPattern = regexp.MustCompile(`^[0-9]+$`)
+)
+
+type Errors []entity.FieldError
+
+func (errs Errors) Error() string {
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Field + " " + err.Message
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Struct checks the fields of a struct, a pointer to it or a slice of them.
+// Fields are named by their json tags, slice elements by index: "[0].author".
+func Struct(v interface{}) Errors {
+	var errs Errors
+	checkValue(reflect.ValueOf(v), "", &errs)
+	return errs
+}
+
+// Var checks a single string or integer value against the rules.
+func Var(field string, value interface{}, rules string) Errors {
+	var errs Errors
+	checkField(reflect.ValueOf(value), field, rules, &errs)
+	return errs
+}
+
+func checkValue(v reflect.Value, prefix string, errs *Errors) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if !v.IsNil() {
+			checkValue(v.Elem(), prefix, errs)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			checkValue(v.Index(i), prefix+"["+strconv.Itoa(i)+"]", errs)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name := fieldName(field)
+			if prefix != "" {
+				name = prefix + "." + name
+			}
+			if rules, ok := field.Tag.Lookup(TAG); ok {
+				checkField(v.Field(i), name, rules, errs)
+			}
+		}
+	}
+}
+
+func fieldName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+func checkField(v reflect.Value, name string, rules string, errs *Errors) {
+	for _, rule := range strings.Split(rules, ",") {
+		rule, arg := splitRule(rule)
+		if msg := checkRule(v, rule, arg); msg != "" {
+			*errs = append(*errs, entity.FieldError{
+				Field:   name,
+				Rule:    rule,
+				Message: msg,
+			})
+			return
+		}
+	}
+}
+
+func splitRule(rule string) (string, string) {
+	if i := strings.IndexByte(rule, '='); i >= 0 {
+		return rule[:i], rule[i+1:]
+	}
+	return rule, ""
+}
+
+// checkRule returns the message of a broken rule or an empty string.
+func checkRule(v reflect.Value, rule string, arg string) string {
+	switch v.Kind() {
+	case reflect.String:
+		return checkString(v.String(), rule, arg)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return checkInt(v.Int(), rule, arg)
+	}
+	return ""
+}
+
+func checkString(s string, rule string, arg string) string {
+	if s == "" {
+		if rule == "required" {
+			return "is required"
+		}
+		return ""
+	}
+
+	switch rule {
+	case "max":
+		if n, _ := strconv.Atoi(arg); utf8.RuneCountInString(s) > n {
+			return "must be at most " + arg + " characters long"
+		}
+	case "min":
+		if n, _ := strconv.Atoi(arg); utf8.RuneCountInString(s) < n {
+			return "must be at least " + arg + " characters long"
+		}
+	case "slug":
+		if !slugPattern.MatchString(s) {
+			return "must contain only letters, digits, '.', '_' and '-'"
+		}
+	case "nonnumeric":
+		if numericPattern.MatchString(s) {
+			return "must not consist of digits only"
+		}
+	case "email":
+		if !emailPattern.MatchString(s) {
+			return "must be an email address"
+		}
+	case "datetime":
+		if _, err := time.Parse(time.RFC3339, s); err != nil {
+			return "must be an RFC 3339 date and time"
+		}
+	case "oneof":
+		if !oneOf(s, arg) {
+			return "must be one of: " + arg
+		}
+	}
+	return ""
+}
+
+func checkInt(i int64, rule string, arg string) string {
+	switch rule {
+	case "required":
+		if i == 0 {
+			return "is required"
+		}
+	case "max":
+		if n, _ := strconv.ParseInt(arg, 10, 64); i > n {
+			return "must be at most " + arg
+		}
+	case "min":
+		if n, _ := strconv.ParseInt(arg, 10, 64); i < n {
+			return "must be at least " + arg
+		}
+	case "oneof":
+		if !oneOf(strconv.FormatInt(i, 10), arg) {
+			return "must be one of: " + arg
+		}
+	}
+	return ""
+}
+
+func oneOf(s string, values string) bool {
+	for _, value := range strings.Fields(values) {
+		if s == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package dump
+
+import (
+	"errors"
+	"flag"
+	log "github.com/sirupsen/logrus"
+	"os"
+	"techpark_db/internal/infra/psql"
+)
+
+var ErrUnknownCommand = errors.New("unknown command, expected export or import")
+
+// RunCommand runs the export or import subcommand:
+//
+//	techpark_db export [--forum slug] > dump.ndjson
+//	techpark_db import < dump.ndjson
+func RunCommand(store *psql.Storage, args []string) error {
+	switch args[0] {
+	case "export":
+		flags := flag.NewFlagSet("export", flag.ExitOnError)
+		forum := flags.String("forum", "", "slug of the forum to export, all forums if empty")
+		flags.Parse(args[1:])
+
+		if err := Export(store, os.Stdout, *forum); err != nil {
+			return err
+		}
+		log.Info("Export finished.")
+	case "import":
+		flags := flag.NewFlagSet("import", flag.ExitOnError)
+		flags.Parse(args[1:])
+
+		stats, err := Import(store, os.Stdin)
+		if err != nil {
+			return err
+		}
+		log.Info("Import finished: ", stats.Users, " users, ", stats.Forums, " forums, ",
+			stats.Threads, " threads, ", stats.Posts, " posts, ", stats.Votes, " votes.")
+	default:
+		return ErrUnknownCommand
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package dump
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jwriter"
+	"io"
+	"techpark_db/internal/domain/entity"
+	"techpark_db/internal/infra/psql"
+)
+
+// Export writes the forum (or every forum when slug is empty) as NDJSON:
+// users, forums, threads, posts and votes, one typed record per line.
+// All records are read from one snapshot.
+func Export(store *psql.Storage, out io.Writer, forum string) error {
+	tx, err := store.DB.BeginTx(context.Background(), &sql.TxOptions{
+		Isolation: sql.LevelRepeatableRead,
+		ReadOnly:  true,
+	})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	w := bufio.NewWriter(out)
+	enc := &encoder{w: w}
+
+	if err := store.ExportUsers(tx, forum, func(user *entity.User) error {
+		return enc.write(entity.DumpUser, user)
+	}); err != nil {
+		return err
+	}
+	if err := store.ExportForums(tx, forum, func(forum *entity.Forum) error {
+		return enc.write(entity.DumpForum, forum)
+	}); err != nil {
+		return err
+	}
+	if err := store.ExportThreads(tx, forum, func(thread *entity.Thread) error {
+		return enc.write(entity.DumpThread, thread)
+	}); err != nil {
+		return err
+	}
+	if err := store.ExportPosts(tx, forum, func(post *entity.Post) error {
+		return enc.write(entity.DumpPost, post)
+	}); err != nil {
+		return err
+	}
+	if err := store.ExportVotes(tx, forum, func(vote *entity.Vote) error {
+		return enc.write(entity.DumpVote, vote)
+	}); err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+type encoder struct {
+	w *bufio.Writer
+}
+
+func (enc *encoder) write(recordType string, data easyjson.Marshaler) error {
+	dataWriter := jwriter.Writer{}
+	data.MarshalEasyJSON(&dataWriter)
+	dataBytes, err := dataWriter.BuildBytes()
+	if err != nil {
+		return err
+	}
+
+	record := entity.DumpRecord{
+		Type: recordType,
+		Data: dataBytes,
+	}
+	recordBytes, err := easyjson.Marshal(record)
+	if err != nil {
+		return err
+	}
+	if _, err := enc.w.Write(recordBytes); err != nil {
+		return err
+	}
+	return enc.w.WriteByte('\n')
+}
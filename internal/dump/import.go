@@ -0,0 +1,209 @@
+package dump
+
+import (
+	"bufio"
+	"database/sql"
+	"fmt"
+	"github.com/mailru/easyjson"
+	"io"
+	"techpark_db/internal/domain/entity"
+	"techpark_db/internal/infra/psql"
+)
+
+// Import reads an NDJSON dump produced by Export and loads it in one transaction.
+// Ids are preserved when they are free and remapped otherwise. Rows already
+// present in the database are matched instead of inserted, so importing the
+// same dump twice does not create duplicates.
+func Import(store *psql.Storage, in io.Reader) (*entity.ImportStats, error) {
+	tx, err := store.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	imp := &importer{
+		store:   store,
+		tx:      tx,
+		threads: make(map[int]int),
+		posts:   make(map[int]int),
+		claimed: make(map[string]map[int]bool),
+		forums:  make(map[string]bool),
+	}
+
+	r := bufio.NewReader(in)
+	for line := 1; ; line++ {
+		recordBytes, err := r.ReadBytes('\n')
+		if len(recordBytes) > 0 {
+			if err := imp.record(recordBytes); err != nil {
+				tx.Rollback()
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			tx.Rollback()
+			return nil, err
+		}
+	}
+
+	forums := make([]string, 0, len(imp.forums))
+	for forum := range imp.forums {
+		forums = append(forums, forum)
+	}
+	if err := store.FinishImport(tx, forums); err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return &imp.stats, nil
+}
+
+type importer struct {
+	store *psql.Storage
+	tx    *sql.Tx
+	stats entity.ImportStats
+
+	// threads and posts map exported ids to ids in this database.
+	threads map[int]int
+	posts   map[int]int
+	// claimed holds the matched or inserted ids per record type, so equal
+	// rows in the dump are never matched to the same row of the database.
+	claimed map[string]map[int]bool
+	forums  map[string]bool
+}
+
+func (imp *importer) record(recordBytes []byte) error {
+	var record entity.DumpRecord
+	if err := easyjson.Unmarshal(recordBytes, &record); err != nil {
+		return err
+	}
+
+	switch record.Type {
+	case entity.DumpUser:
+		var user entity.User
+		if err := easyjson.Unmarshal(record.Data, &user); err != nil {
+			return err
+		}
+		if err := imp.store.ImportUser(imp.tx, user); err != nil {
+			return fmt.Errorf("user %s: %w", user.Nickname, err)
+		}
+		imp.stats.Users++
+	case entity.DumpForum:
+		var forum entity.Forum
+		if err := easyjson.Unmarshal(record.Data, &forum); err != nil {
+			return err
+		}
+		if err := imp.store.ImportForum(imp.tx, forum); err != nil {
+			return fmt.Errorf("forum %s: %w", forum.Slug, err)
+		}
+		imp.forums[forum.Slug] = true
+		imp.stats.Forums++
+	case entity.DumpThread:
+		var thread entity.Thread
+		if err := easyjson.Unmarshal(record.Data, &thread); err != nil {
+			return err
+		}
+		if err := imp.thread(thread); err != nil {
+			return fmt.Errorf("thread %d: %w", thread.Id, err)
+		}
+		imp.forums[thread.Forum] = true
+		imp.stats.Threads++
+	case entity.DumpPost:
+		var post entity.Post
+		if err := easyjson.Unmarshal(record.Data, &post); err != nil {
+			return err
+		}
+		if err := imp.post(post); err != nil {
+			return fmt.Errorf("post %d: %w", post.Id, err)
+		}
+		imp.stats.Posts++
+	case entity.DumpVote:
+		var vote entity.Vote
+		if err := easyjson.Unmarshal(record.Data, &vote); err != nil {
+			return err
+		}
+		threadId, ok := imp.threads[vote.IdThread]
+		if !ok {
+			return fmt.Errorf("vote of %s: unknown thread %d", vote.Nickname, vote.IdThread)
+		}
+		vote.IdThread = threadId
+		if err := imp.store.SetVote(imp.tx, vote); err != nil {
+			return fmt.Errorf("vote of %s: %w", vote.Nickname, err)
+		}
+		imp.stats.Votes++
+	default:
+		return fmt.Errorf("unknown record type %q", record.Type)
+	}
+	return nil
+}
+
+func (imp *importer) thread(thread entity.Thread) error {
+	candidates, err := imp.store.FindImportedThreads(imp.tx, thread)
+	if err != nil {
+		return err
+	}
+	if id, ok := imp.claim(entity.DumpThread, candidates); ok {
+		imp.threads[thread.Id] = id
+		return nil
+	}
+
+	id, err := imp.store.ImportThread(imp.tx, thread)
+	if err != nil {
+		return err
+	}
+	imp.claim(entity.DumpThread, []int{id})
+	imp.threads[thread.Id] = id
+	return nil
+}
+
+func (imp *importer) post(post entity.Post) error {
+	threadId, ok := imp.threads[post.Thread]
+	if !ok {
+		return fmt.Errorf("unknown thread %d", post.Thread)
+	}
+	post.Thread = threadId
+	if post.Parent != 0 {
+		parentId, ok := imp.posts[post.Parent]
+		if !ok {
+			return fmt.Errorf("unknown parent %d", post.Parent)
+		}
+		post.Parent = parentId
+	}
+
+	candidates, err := imp.store.FindImportedPosts(imp.tx, post)
+	if err != nil {
+		return err
+	}
+	if id, ok := imp.claim(entity.DumpPost, candidates); ok {
+		imp.posts[post.Id] = id
+		return nil
+	}
+
+	id, err := imp.store.ImportPost(imp.tx, post)
+	if err != nil {
+		return err
+	}
+	imp.claim(entity.DumpPost, []int{id})
+	imp.posts[post.Id] = id
+	return nil
+}
+
+// claim takes the first candidate id not taken by an earlier record.
+func (imp *importer) claim(recordType string, candidates []int) (int, bool) {
+	claimed, ok := imp.claimed[recordType]
+	if !ok {
+		claimed = make(map[int]bool)
+		imp.claimed[recordType] = claimed
+	}
+	for _, id := range candidates {
+		if !claimed[id] {
+			claimed[id] = true
+			return id, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"github.com/mailru/easyjson"
+	"github.com/tinylib/msgp/msgp"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"techpark_db/internal/handler/httperr"
+)
+
+const (
+	CONTENT_TYPE_JSON     = "application/json"
+	CONTENT_TYPE_MSGPACK  = "application/msgpack"
+	CONTENT_TYPE_PROTOBUF = "application/x-protobuf"
+)
+
+// mediaTypes maps the accepted names of the formats to the content type of
+// the response. The order of formats decides between equal q-values.
+var mediaTypes = []struct {
+	name        string
+	contentType string
+}{
+	{CONTENT_TYPE_JSON, CONTENT_TYPE_JSON},
+	{CONTENT_TYPE_MSGPACK, CONTENT_TYPE_MSGPACK},
+	{"application/x-msgpack", CONTENT_TYPE_MSGPACK},
+	{"application/vnd.msgpack", CONTENT_TYPE_MSGPACK},
+	{CONTENT_TYPE_PROTOBUF, CONTENT_TYPE_PROTOBUF},
+	{"application/protobuf", CONTENT_TYPE_PROTOBUF},
+}
+
+// body is an entity which can be sent in every supported format: easyjson,
+// msgp and the pb mapping of the entity package.
+type body interface {
+	easyjson.Marshaler
+	msgp.Marshaler
+	MarshalProto() ([]byte, error)
+}
+
+type protoUnmarshaler interface {
+	UnmarshalProto(data []byte) error
+}
+
+// write sends v in the format negotiated from the Accept header.
+// Errors stay application/problem+json whatever the client accepts.
+func write(w http.ResponseWriter, r *http.Request, status int, v body) {
+	contentType := negotiateContentType(r)
+
+	var data []byte
+	var err error
+	switch contentType {
+	case CONTENT_TYPE_MSGPACK:
+		data, err = v.MarshalMsg(nil)
+	case CONTENT_TYPE_PROTOBUF:
+		data, err = v.MarshalProto()
+	default:
+		data, err = easyjson.Marshal(v)
+	}
+	if err != nil {
+		httperr.Write(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	varyAccept(w)
+	w.WriteHeader(status)
+	w.Write(data)
+}
+
+// negotiateContentType picks the supported format with the highest q-value
+// in the Accept header. JSON is the answer to a missing header, to wildcards
+// and to an Accept without any supported format.
+func negotiateContentType(r *http.Request) string {
+	header := r.Header.Get("Accept")
+	if header == "" {
+		return CONTENT_TYPE_JSON
+	}
+
+	best, bestQ := CONTENT_TYPE_JSON, 0.0
+	for _, part := range strings.Split(header, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if raw, ok := params["q"]; ok {
+			if value, err := strconv.ParseFloat(raw, 64); err == nil {
+				q = value
+			}
+		}
+		if q <= bestQ {
+			continue
+		}
+		for _, candidate := range mediaTypes {
+			if mediaType == candidate.name || mediaType == "*/*" || mediaType == "application/*" {
+				best, bestQ = candidate.contentType, q
+				break
+			}
+		}
+	}
+	return best
+}
+
+// requestContentType is the format of the request body, JSON by default.
+func requestContentType(r *http.Request) string {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return CONTENT_TYPE_JSON
+	}
+	for _, candidate := range mediaTypes {
+		if mediaType == candidate.name {
+			return candidate.contentType
+		}
+	}
+	return CONTENT_TYPE_JSON
+}
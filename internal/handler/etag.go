@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"hash/fnv"
+	"net/http"
+	"strconv"
+	"strings"
+	"techpark_db/internal/domain/entity"
+	"techpark_db/internal/service"
+	"time"
+)
+
+// Users, threads and posts, which take If-Match on update, have strong
+// ETags of their row version. Responses built from several rows or from a
+// page of rows get weak ETags, they are only good for conditional GET.
+// Every format has tags of its own, see formatETag.
+
+// etagFormats are the suffixes of the tags of the formats other than JSON.
+var etagFormats = map[string]string{
+	CONTENT_TYPE_MSGPACK:  "-msgpack",
+	CONTENT_TYPE_PROTOBUF: "-protobuf",
+}
+
+// formatETag tells the representations in the negotiated formats apart, a
+// strong tag promises the same bytes. JSON keeps the plain tag.
+func formatETag(r *http.Request, tag string) string {
+	suffix, ok := etagFormats[negotiateContentType(r)]
+	if !ok {
+		return tag
+	}
+	return strings.TrimSuffix(tag, `"`) + suffix + `"`
+}
+
+// weakETag combines the versions of the rows the response is built from.
+func weakETag(versions ...int) string {
+	parts := make([]string, len(versions))
+	for i, version := range versions {
+		parts[i] = strconv.Itoa(version)
+	}
+	return `W/"` + strings.Join(parts, ".") + `"`
+}
+
+// postsETag is the weak entity tag of a page of posts. Posts added to,
+// edited in or moved out of the page change it, and so does the shape of
+// the page: the nested format cut at maxDepth is another representation.
+func postsETag(posts []entity.Post, format string, maxDepth int) string {
+	h := fnv.New64a()
+	if format != "" {
+		h.Write([]byte(format + ":" + strconv.Itoa(maxDepth) + ";"))
+	}
+	for _, post := range posts {
+		h.Write([]byte(strconv.Itoa(post.Id) + ":" + strconv.Itoa(post.Version) + ","))
+	}
+	return `W/"` + strconv.FormatUint(h.Sum64(), 36) + `"`
+}
+
+// threadsETag is the weak entity tag of a page of threads. Threads added
+// to, edited in or moved out of the page change it.
+func threadsETag(threads []entity.Thread) string {
+	h := fnv.New64a()
+	for _, thread := range threads {
+		h.Write([]byte(strconv.Itoa(thread.Id) + ":" + strconv.Itoa(thread.Version) + ","))
+	}
+	return `W/"` + strconv.FormatUint(h.Sum64(), 36) + `"`
+}
+
+func setETag(w http.ResponseWriter, r *http.Request, version int) {
+	w.Header().Set("ETag", formatETag(r, service.ETag(version)))
+}
+
+// varyAccept marks the response as negotiated from Accept, once.
+func varyAccept(w http.ResponseWriter) {
+	for _, value := range w.Header().Values("Vary") {
+		for _, field := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(field), "Accept") {
+				return
+			}
+		}
+	}
+	w.Header().Add("Vary", "Accept")
+}
+
+// notModified sends the validators of a GET response and answers
+// 304 Not Modified when the client has the current representation.
+// A zero modified time sends no Last-Modified.
+func notModified(w http.ResponseWriter, r *http.Request, tag string, modified time.Time) bool {
+	tag = formatETag(r, tag)
+	w.Header().Set("ETag", tag)
+	varyAccept(w)
+	if !modified.IsZero() {
+		w.Header().Set("Last-Modified", modified.UTC().Format(http.TimeFormat))
+	}
+	if !fresh(r, tag, modified) {
+		return false
+	}
+	w.Header().Del("Content-Type")
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// fresh evaluates If-None-Match with the weak comparison, If-Modified-Since
+// only counts for requests without If-None-Match.
+func fresh(r *http.Request, tag string, modified time.Time) bool {
+	if header := r.Header.Get("If-None-Match"); header != "" {
+		if header == "*" {
+			return true
+		}
+		for _, candidate := range strings.Split(header, ",") {
+			if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == strings.TrimPrefix(tag, "W/") {
+				return true
+			}
+		}
+		return false
+	}
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || modified.IsZero() {
+		return false
+	}
+	return !modified.Truncate(time.Second).After(since)
+}
+
+// latest is the last modification of the rows the response is built from.
+func latest(times ...time.Time) time.Time {
+	var last time.Time
+	for _, t := range times {
+		if t.After(last) {
+			last = t
+		}
+	}
+	return last
+}
@@ -1,115 +1,56 @@
 package handler
 
 import (
-	"encoding/json"
 	"github.com/gorilla/mux"
-	"github.com/mailru/easyjson"
-	log "github.com/sirupsen/logrus"
 	"net/http"
-	"strconv"
+	"techpark_db/internal/apperr"
 	"techpark_db/internal/domain/entity"
+	"techpark_db/internal/handler/httperr"
+	"techpark_db/internal/service"
 	"time"
 )
 
 func (h *Handler) ForumCreate(w http.ResponseWriter, r *http.Request) {
 	w.Header().Add("Content-Type", "application/json")
 	var forumRequest entity.CreateForum
-	if err := json.NewDecoder(r.Body).Decode(&forumRequest); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := decode(r, &forumRequest); err != nil {
+		httperr.Write(w, err)
 		return
 	}
 
-	tx, err := h.storage.DB.Begin()
-	if err != nil {
-		log.Error(err)
-		w.WriteHeader(http.StatusInternalServerError)
+	forum, err := h.service.CreateForum(r.Context(), forumRequest)
+	if err == service.ErrExists {
+		write(w, r, http.StatusConflict, forum)
 		return
 	}
-
-	user, err := h.storage.GetUser(tx, forumRequest.User)
 	if err != nil {
-		tx.Rollback()
-		resp := &entity.Error{
-			Message: ErrNoUser + forumRequest.User,
-		}
-		respBytes, _ := easyjson.Marshal(resp)
-		w.WriteHeader(http.StatusNotFound)
-		w.Write(respBytes)
-		return
-	}
-	forumRequest.User = user.Nickname
-
-	forum, err := h.storage.GetForum(tx, forumRequest.Slug)
-	if err == nil {
-		tx.Rollback()
-		forumBytes, _ := easyjson.Marshal(forum)
-		w.WriteHeader(http.StatusConflict)
-		w.Write(forumBytes)
-		return
-	}
-
-	if err := h.storage.SaveForum(tx, forumRequest); err != nil {
-		tx.Rollback()
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	forum = &entity.Forum{
-		Slug:    forumRequest.Slug,
-		Title:   forumRequest.Title,
-		User:    forumRequest.User,
-		Posts:   0,
-		Threads: 0,
-	}
-
-	if err := tx.Commit(); err != nil {
-		log.Error(err)
-		w.WriteHeader(http.StatusInternalServerError)
+		httperr.Write(w, err)
 		return
 	}
 
-	forumBytes, _ := easyjson.Marshal(forum)
-	w.WriteHeader(http.StatusCreated)
-	w.Write(forumBytes)
+	write(w, r, http.StatusCreated, forum)
 }
 
 func (h *Handler) ForumDetails(w http.ResponseWriter, r *http.Request) {
 	w.Header().Add("Content-Type", "application/json")
+	store := h.reader(r)
 	vars := mux.Vars(r)
 	slug, ok := vars["slug"]
 	if !ok {
-		w.WriteHeader(http.StatusBadRequest)
+		httperr.Write(w, apperr.New(apperr.CodeBadRequest, ErrBadRequest))
 		return
 	}
 
-	//tx, err := h.storage.DB.Begin()
-	//if err != nil {
-	//	log.Error(err)
-	//	w.WriteHeader(http.StatusInternalServerError)
-	//	return
-	//}
-
-	forum, err := h.storage.GetForum(nil, slug)
+	forum, err := store.GetForum(nil, slug)
 	if err != nil {
-		//tx.Rollback()
-		resp := &entity.Error{
-			Message: ErrNoForum + slug,
-		}
-		respBytes, _ := easyjson.Marshal(resp)
-		w.WriteHeader(http.StatusNotFound)
-		w.Write(respBytes)
+		httperr.Write(w, apperr.New(apperr.CodeForumNotFound, service.ErrNoForum+slug))
 		return
 	}
 
-	//if err := tx.Commit(); err != nil {
-	//	log.Error(err)
-	//	w.WriteHeader(http.StatusInternalServerError)
-	//	return
-	//}
-
-	forumBytes, _ := easyjson.Marshal(forum)
-	w.WriteHeader(http.StatusOK)
-	w.Write(forumBytes)
+	if notModified(w, r, weakETag(forum.Version), forum.Modified) {
+		return
+	}
+	write(w, r, http.StatusOK, forum)
 }
 
 func (h *Handler) ForumCreateThread(w http.ResponseWriter, r *http.Request) {
@@ -117,118 +58,76 @@ func (h *Handler) ForumCreateThread(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	slugForum, ok := vars["slug"]
 	if !ok {
-		w.WriteHeader(http.StatusBadRequest)
+		httperr.Write(w, apperr.New(apperr.CodeBadRequest, ErrBadRequest))
 		return
 	}
 
 	var threadRequest entity.CreateThread
-	if err := json.NewDecoder(r.Body).Decode(&threadRequest); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-
-	tx, err := h.storage.DB.Begin()
-	if err != nil {
-		log.Error(err)
-		w.WriteHeader(http.StatusInternalServerError)
+	if err := decode(r, &threadRequest); err != nil {
+		httperr.Write(w, err)
 		return
 	}
 
-	if _, err := h.storage.GetUser(tx, threadRequest.Author); err != nil {
-		tx.Rollback()
-		resp := &entity.Error{
-			Message: ErrNoThreadAuthor + threadRequest.Author,
-		}
-		respBytes, _ := easyjson.Marshal(resp)
-		w.WriteHeader(http.StatusNotFound)
-		w.Write(respBytes)
+	thread, err := h.service.CreateThread(r.Context(), slugForum, threadRequest)
+	if err == service.ErrExists {
+		write(w, r, http.StatusConflict, thread)
 		return
 	}
-
-	thread, err := h.storage.GetThread(tx, threadRequest.Slug)
-	if err == nil {
-		tx.Rollback()
-		threadBytes, _ := easyjson.Marshal(thread)
-		w.WriteHeader(http.StatusConflict)
-		w.Write(threadBytes)
-		return
-	}
-
-	forum, err := h.storage.GetForum(tx, slugForum)
 	if err != nil {
-		tx.Rollback()
-		resp := &entity.Error{
-			Message: ErrNoThreadForum + slugForum,
-		}
-		respBytes, _ := easyjson.Marshal(resp)
-		w.WriteHeader(http.StatusNotFound)
-		w.Write(respBytes)
+		httperr.Write(w, err)
 		return
 	}
 
-	if threadRequest.Created == "" {
-		threadRequest.Created = time.Now().Format(time.RFC3339Nano)
-	}
-
-	insertId, err := h.storage.SaveThread(tx, threadRequest, forum.Slug)
-	if err != nil {
-		tx.Rollback()
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	thread, err = h.storage.GetThreadById(tx, insertId)
-	if err != nil {
-		tx.Rollback()
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	if err := tx.Commit(); err != nil {
-		log.Error(err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	threadBytes, _ := easyjson.Marshal(thread)
-	w.WriteHeader(http.StatusCreated)
-	w.Write(threadBytes)
+	write(w, r, http.StatusCreated, thread)
 }
 
 func (h *Handler) ForumUsers(w http.ResponseWriter, r *http.Request) {
 	w.Header().Add("Content-Type", "application/json")
+	store := h.reader(r)
 	vars := mux.Vars(r)
 	slug, ok := vars["slug"]
 	if !ok {
-		w.WriteHeader(http.StatusBadRequest)
+		httperr.Write(w, apperr.New(apperr.CodeBadRequest, ErrBadRequest))
 		return
 	}
 
+	q := newQuery(r)
 	order := DEFAULT_ORDER
-	limit := DEFAULT_LIMIT
-	since := r.FormValue("since")
-	if r.FormValue("limit") != "" {
-		limit, _ = strconv.Atoi(r.FormValue("limit"))
-	}
-	if r.FormValue("desc") == "true" {
+	limit := q.Int("limit", DEFAULT_LIMIT, h.limitRules())
+	since := q.String("since", "", "slug")
+	if q.Bool("desc") {
 		order = "DESC"
 	}
+	if err := q.Err(); err != nil {
+		httperr.Write(w, err)
+		return
+	}
 
-	//tx, err := h.storage.DB.Begin()
-	//if err != nil {
-	//	log.Error(err)
-	//	w.WriteHeader(http.StatusInternalServerError)
-	//	return
-	//}
+	if limit > STREAM_LIMIT && negotiateContentType(r) == CONTENT_TYPE_JSON {
+		stream := newArrayStream(w)
+		err := store.StreamForumUsers(nil, slug, order, limit, since, func(user *entity.User) error {
+			return stream.Add(user)
+		})
+		if err == nil && stream.count == 0 {
+			if _, err := store.GetForum(nil, slug); err != nil {
+				httperr.Write(w, apperr.New(apperr.CodeForumNotFound, service.ErrNoForum+slug))
+				return
+			}
+		}
+		if err != nil {
+			stream.Fail(err)
+			return
+		}
+		stream.Close()
+		return
+	}
 
 	ts := r.Context().Value("timestamp").(*[]time.Time)
 	*ts = append(*ts, time.Now())
 
-	users, err := h.storage.GetForumUsers(nil, slug, order, limit, since)
+	users, err := store.GetForumUsers(nil, slug, order, limit, since)
 	if err != nil {
-		//tx.Rollback()
-		log.Warning("trouble GetForumUsers")
-		w.WriteHeader(http.StatusNotFound)
+		httperr.Write(w, err)
 		return
 	}
 
@@ -236,14 +135,8 @@ func (h *Handler) ForumUsers(w http.ResponseWriter, r *http.Request) {
 	*ts = append(*ts, time.Now())
 
 	if len(*users) == 0 {
-		if _, err := h.storage.GetForum(nil, slug); err != nil {
-			//tx.Rollback()
-			resp := &entity.Error{
-				Message: ErrNoForum + slug,
-			}
-			respBytes, _ := easyjson.Marshal(resp)
-			w.WriteHeader(http.StatusNotFound)
-			w.Write(respBytes)
+		if _, err := store.GetForum(nil, slug); err != nil {
+			httperr.Write(w, apperr.New(apperr.CodeForumNotFound, service.ErrNoForum+slug))
 			return
 		}
 	}
@@ -251,59 +144,44 @@ func (h *Handler) ForumUsers(w http.ResponseWriter, r *http.Request) {
 	ts = r.Context().Value("timestamp").(*[]time.Time)
 	*ts = append(*ts, time.Now())
 
-	//if err := tx.Commit(); err != nil {
-	//	log.Error(err)
-	//	w.WriteHeader(http.StatusInternalServerError)
-	//	return
-	//}
-
 	var u entity.Users
 	u = *users
-	usersBytes, _ := easyjson.Marshal(u)
-	w.WriteHeader(http.StatusOK)
-	w.Write(usersBytes)
+	write(w, r, http.StatusOK, u)
 }
 
 func (h *Handler) ForumThreads(w http.ResponseWriter, r *http.Request) {
 	w.Header().Add("Content-Type", "application/json")
+	store := h.reader(r)
 	vars := mux.Vars(r)
 	slug, ok := vars["slug"]
 	if !ok {
-		w.WriteHeader(http.StatusBadRequest)
+		httperr.Write(w, apperr.New(apperr.CodeBadRequest, ErrBadRequest))
 		return
 	}
 
+	q := newQuery(r)
 	order := DEFAULT_ORDER
-	limit := DEFAULT_LIMIT
+	limit := q.Int("limit", DEFAULT_LIMIT, h.limitRules())
 	since := DEFAULT_SINCE_DATA_MIN
-	if r.FormValue("limit") != "" {
-		limit, _ = strconv.Atoi(r.FormValue("limit"))
-	}
-	if r.FormValue("desc") == "true" {
+	if q.Bool("desc") {
 		order = "DESC"
 		since = DEFAULT_SINCE_DATA_MAX
 	}
-	if r.FormValue("since") != "" {
-		since = r.FormValue("since")
+	since = q.String("since", since, "datetime")
+	if err := q.Err(); err != nil {
+		httperr.Write(w, err)
+		return
 	}
 
 	ts := r.Context().Value("timestamp").(*[]time.Time)
 	*ts = append(*ts, time.Now())
 
-	//tx, err := h.storage.DB.Begin()
-	//if err != nil {
-	//	log.Error(err)
-	//	w.WriteHeader(http.StatusInternalServerError)
-	//	return
-	//}
-
 	ts = r.Context().Value("timestamp").(*[]time.Time)
 	*ts = append(*ts, time.Now())
 
-	forum, err := h.storage.GetForumThreads(nil, slug, order, limit, since)
+	forum, err := store.GetForumThreads(nil, slug, order, limit, since)
 	if err != nil {
-		//tx.Rollback()
-		w.WriteHeader(http.StatusInternalServerError)
+		httperr.Write(w, err)
 		return
 	}
 
@@ -311,14 +189,8 @@ func (h *Handler) ForumThreads(w http.ResponseWriter, r *http.Request) {
 	*ts = append(*ts, time.Now())
 
 	if len(*forum) == 0 {
-		if _, err := h.storage.GetForum(nil, slug); err != nil {
-			//tx.Rollback()
-			resp := &entity.Error{
-				Message: ErrNoForum + slug,
-			}
-			respBytes, _ := easyjson.Marshal(resp)
-			w.WriteHeader(http.StatusNotFound)
-			w.Write(respBytes)
+		if _, err := store.GetForum(nil, slug); err != nil {
+			httperr.Write(w, apperr.New(apperr.CodeForumNotFound, service.ErrNoForum+slug))
 			return
 		}
 	}
@@ -326,18 +198,15 @@ func (h *Handler) ForumThreads(w http.ResponseWriter, r *http.Request) {
 	ts = r.Context().Value("timestamp").(*[]time.Time)
 	*ts = append(*ts, time.Now())
 
-	//if err := tx.Commit(); err != nil {
-	//	log.Error(err)
-	//	w.WriteHeader(http.StatusInternalServerError)
-	//	return
-	//}
-
 	ts = r.Context().Value("timestamp").(*[]time.Time)
 	*ts = append(*ts, time.Now())
 
+	// no Last-Modified: threads moved out of the page would not advance it
+	if notModified(w, r, threadsETag(*forum), time.Time{}) {
+		return
+	}
+
 	var f entity.Threads
 	f = *forum
-	forumBytes, _ := easyjson.Marshal(f)
-	w.WriteHeader(http.StatusOK)
-	w.Write(forumBytes)
+	write(w, r, http.StatusOK, f)
 }
@@ -0,0 +1,149 @@
+package gql
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"net/http"
+	"techpark_db/internal/apperr"
+	"techpark_db/internal/handler"
+	mw "techpark_db/internal/handler/middleware"
+	"techpark_db/internal/infra/psql"
+)
+
+var ErrNoQuery = "Must provide query string"
+var ErrBadRequest = "Request body can not be decoded"
+var ErrBadVariables = "Variables are invalid JSON"
+
+// Handler serves GraphQL queries over the related data of the forum, see
+// newSchema. There are no mutations, the writes stay on the REST routes.
+type Handler struct {
+	storage  *psql.Storage
+	maxLimit int
+	schema   graphql.Schema
+}
+
+func NewHandler(store *psql.Storage) (*Handler, error) {
+	h := &Handler{
+		storage:  store,
+		maxLimit: handler.DEFAULT_MAX_LIMIT,
+	}
+	schema, err := h.newSchema()
+	if err != nil {
+		return nil, err
+	}
+	h.schema = schema
+	return h, nil
+}
+
+func (h *Handler) SetMaxLimit(limit int) {
+	h.maxLimit = limit
+}
+
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// ServeHTTP takes the query from the JSON body of a POST or from the query
+// string of a GET. Requests which do not run get 400 with the errors, once
+// running the errors of the fields are part of the 200 result.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req request
+	if r.Method == http.MethodGet {
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if variables := q.Get("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				writeErrors(w, http.StatusBadRequest, gqlerrors.NewFormattedError(ErrBadVariables))
+				return
+			}
+		}
+	} else if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeErrors(w, http.StatusBadRequest, gqlerrors.NewFormattedError(ErrBadRequest))
+		return
+	}
+	if req.Query == "" {
+		writeErrors(w, http.StatusBadRequest, gqlerrors.NewFormattedError(ErrNoQuery))
+		return
+	}
+
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"}),
+	})
+	if err != nil {
+		writeErrors(w, http.StatusBadRequest, gqlerrors.FormatErrors(err)...)
+		return
+	}
+	validation := graphql.ValidateDocument(&h.schema, doc, graphql.SpecifiedRules)
+	if !validation.IsValid {
+		writeErrors(w, http.StatusBadRequest, validation.Errors...)
+		return
+	}
+	if op := operation(doc, req.OperationName); op != nil {
+		if msg := checkLimits(doc, op, req.Variables); msg != "" {
+			writeErrors(w, http.StatusBadRequest, gqlerrors.NewFormattedError(msg))
+			return
+		}
+	}
+
+	store := h.storage
+	if mw.ReadPrimary(r) {
+		store = h.storage.Primary()
+	}
+	result := graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           doc,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       context.WithValue(r.Context(), LOADERS_CONTEXT_KEY, newLoaders(store)),
+	})
+	writeJSON(w, http.StatusOK, result)
+}
+
+// operation picks the operation to run like the executor does, nil leaves
+// the error to the executor.
+func operation(doc *ast.Document, name string) *ast.OperationDefinition {
+	var found *ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if name == "" {
+			if found != nil {
+				return nil
+			}
+			found = op
+		} else if op.Name != nil && op.Name.Value == name {
+			return op
+		}
+	}
+	return found
+}
+
+func writeErrors(w http.ResponseWriter, status int, errs ...gqlerrors.FormattedError) {
+	writeJSON(w, status, &graphql.Result{Errors: errs})
+}
+
+func writeJSON(w http.ResponseWriter, status int, result *graphql.Result) {
+	body, err := json.Marshal(result)
+	if err != nil {
+		// the error result holds strings only and always marshals
+		problem := apperr.ProblemOf(err)
+		status = problem.Status
+		body, _ = json.Marshal(&graphql.Result{Errors: []gqlerrors.FormattedError{{
+			Message:    problem.Detail,
+			Extensions: map[string]interface{}{"code": problem.Code},
+		}}})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
@@ -0,0 +1,58 @@
+package gql
+
+import (
+	"encoding/json"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/parser"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLimitsComplexity(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{`{ forum(slug: "f") { threads(limit: 100) { voters(limit: 100) { voice } } } }`, ""},
+		{`{ forum(slug: "f") { threads(limit: 100) { voters(limit: 1000) { voice } } } }`, ErrQueryComplexity},
+		{`query($n: Int) { forum(slug: "f") { threads(limit: 100) { voters(limit: $n) { voice } } } }`, ErrQueryComplexity},
+		{`{ forum(slug: "f") { threads(limit: 30000) { title } } }`, ErrQueryComplexity},
+		// the product of the limits overflows int64
+		{`{ forum(slug: "f") { threads(limit: 8192) { posts(limit: 8192) { thread { posts(limit: 8192) { thread { posts(limit: 8192) { thread { posts(limit: 2048) { id } } } } } } } } } }`, ErrQueryComplexity},
+	}
+	for _, tt := range tests {
+		doc, err := parser.Parse(parser.ParseParams{Source: tt.query})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := checkLimits(doc, operation(doc, ""), map[string]interface{}{"n": float64(1000)})
+		if got != tt.want {
+			t.Errorf("%s: %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestWriteJSONError(t *testing.T) {
+	rec := httptest.NewRecorder()
+	writeJSON(rec, http.StatusOK, &graphql.Result{Data: func() {}})
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("content type %q", got)
+	}
+	var result struct {
+		Errors []struct {
+			Message    string                 `json:"message"`
+			Extensions map[string]interface{} `json:"extensions"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("body %q: %v", rec.Body.String(), err)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Extensions["code"] != "internal" {
+		t.Errorf("errors %+v", result.Errors)
+	}
+}
@@ -0,0 +1,160 @@
+package gql
+
+import (
+	"github.com/graphql-go/graphql/language/ast"
+	"strconv"
+	"strings"
+	"techpark_db/internal/handler"
+)
+
+const (
+	GRAPHQL_MAX_DEPTH      = 12
+	GRAPHQL_MAX_COMPLEXITY = 20000
+)
+
+var ErrQueryDepth = "Query is nested deeper than " + strconv.Itoa(GRAPHQL_MAX_DEPTH) + " fields"
+var ErrQueryComplexity = "Query may resolve more than " + strconv.Itoa(GRAPHQL_MAX_COMPLEXITY) + " fields"
+
+// limits measures an operation before it runs. Every resolved field costs
+// one, the fields below a list cost once per element, counted with the
+// limit argument of the list. The children of a tree are part of its limit.
+type limits struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+// checkLimits returns the message of the first limit op exceeds. The
+// document is expected to be valid, so fragments do not cycle.
+func checkLimits(doc *ast.Document, op *ast.OperationDefinition, variables map[string]interface{}) string {
+	l := &limits{
+		fragments: make(map[string]*ast.FragmentDefinition),
+		variables: make(map[string]interface{}),
+	}
+	for _, def := range op.VariableDefinitions {
+		if v, ok := def.DefaultValue.(*ast.IntValue); ok {
+			if n, err := strconv.Atoi(v.Value); err == nil {
+				l.variables[def.Variable.Name.Value] = n
+			}
+		}
+	}
+	for name, value := range variables {
+		l.variables[name] = value
+	}
+	for _, def := range doc.Definitions {
+		if fragment, ok := def.(*ast.FragmentDefinition); ok {
+			l.fragments[fragment.Name.Value] = fragment
+		}
+	}
+
+	if l.depth(op.SelectionSet) > GRAPHQL_MAX_DEPTH {
+		return ErrQueryDepth
+	}
+	if l.complexity(op.SelectionSet, 1) > GRAPHQL_MAX_COMPLEXITY {
+		return ErrQueryComplexity
+	}
+	return ""
+}
+
+// fields flattens the fragments of a selection set.
+func (l *limits) fields(set *ast.SelectionSet) []*ast.Field {
+	if set == nil {
+		return nil
+	}
+	var fields []*ast.Field
+	for _, selection := range set.Selections {
+		switch s := selection.(type) {
+		case *ast.Field:
+			if !strings.HasPrefix(s.Name.Value, "__") {
+				fields = append(fields, s)
+			}
+		case *ast.InlineFragment:
+			fields = append(fields, l.fields(s.SelectionSet)...)
+		case *ast.FragmentSpread:
+			if fragment, ok := l.fragments[s.Name.Value]; ok {
+				fields = append(fields, l.fields(fragment.SelectionSet)...)
+			}
+		}
+	}
+	return fields
+}
+
+func (l *limits) depth(set *ast.SelectionSet) int {
+	max := 0
+	for _, field := range l.fields(set) {
+		if d := 1 + l.depth(field.SelectionSet); d > max {
+			max = d
+		}
+	}
+	return max
+}
+
+// complexity stops counting once the limit is passed. Multipliers above the
+// limit are counted as just above it, nested lists would overflow otherwise.
+func (l *limits) complexity(set *ast.SelectionSet, multiplier int) int {
+	total := 0
+	for _, field := range l.fields(set) {
+		total += multiplier
+		if total > GRAPHQL_MAX_COMPLEXITY {
+			return total
+		}
+		if field.SelectionSet != nil {
+			total += l.complexity(field.SelectionSet, capped(multiplier, l.elements(field)))
+		}
+		if total > GRAPHQL_MAX_COMPLEXITY {
+			return total
+		}
+	}
+	return total
+}
+
+// capped multiplies the counts, at most to one above the limit.
+func capped(a, b int) int {
+	if b != 0 && a > GRAPHQL_MAX_COMPLEXITY/b {
+		return GRAPHQL_MAX_COMPLEXITY + 1
+	}
+	return a * b
+}
+
+// elements returns how many values a field resolves to.
+func (l *limits) elements(field *ast.Field) int {
+	switch field.Name.Value {
+	case "threads", "posts", "tree", "voters":
+		return l.intArgument(field, "limit", handler.DEFAULT_LIMIT)
+	}
+	return 1
+}
+
+func (l *limits) intArgument(field *ast.Field, name string, value int) int {
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != name {
+			continue
+		}
+		switch v := arg.Value.(type) {
+		case *ast.IntValue:
+			if n, err := strconv.Atoi(v.Value); err == nil {
+				value = n
+			} else {
+				value = GRAPHQL_MAX_COMPLEXITY + 1
+			}
+		case *ast.Variable:
+			switch n := l.variables[v.Name.Value].(type) {
+			case float64:
+				if n > GRAPHQL_MAX_COMPLEXITY {
+					n = GRAPHQL_MAX_COMPLEXITY + 1
+				}
+				value = int(n)
+			case int:
+				value = n
+			}
+		}
+	}
+	// limits below one fail in the resolver, larger ones are counted at
+	// their size even above MAX_LIMIT
+	if value < 1 {
+		return 1
+	}
+	if value > GRAPHQL_MAX_COMPLEXITY {
+		return GRAPHQL_MAX_COMPLEXITY + 1
+	}
+	return value
+}
@@ -0,0 +1,238 @@
+package gql
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"techpark_db/internal/domain/entity"
+	"techpark_db/internal/infra/psql"
+)
+
+// batchFn loads the values of many keys at once. Keys without a value are
+// left out of the result.
+type batchFn func(keys []string) (map[string]interface{}, error)
+
+// loader is a dataloader for the thunks of graphql-go. Resolvers queue
+// their keys and return a thunk. The executor calls the thunks level by
+// level, after every field of the level queued its key, so the first thunk
+// of a level loads the keys of the whole level with one query.
+type loader struct {
+	fetch batchFn
+	empty interface{}
+
+	mu      sync.Mutex
+	pending []string
+	queued  map[string]bool
+	values  map[string]interface{}
+	errs    map[string]error
+}
+
+func newLoader(fetch batchFn, empty interface{}) *loader {
+	return &loader{
+		fetch:  fetch,
+		empty:  empty,
+		queued: make(map[string]bool),
+		values: make(map[string]interface{}),
+		errs:   make(map[string]error),
+	}
+}
+
+// load queues key and returns the thunk resolving it. Keys without a value
+// resolve to the empty value of the loader.
+func (l *loader) load(key string) func() (interface{}, error) {
+	l.mu.Lock()
+	if !l.queued[key] {
+		l.queued[key] = true
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (interface{}, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if len(l.pending) > 0 {
+			keys := l.pending
+			l.pending = nil
+			values, err := l.fetch(keys)
+			for _, k := range keys {
+				if err != nil {
+					l.errs[k] = err
+				} else if value, ok := values[k]; ok {
+					l.values[k] = value
+				}
+			}
+		}
+
+		if err := l.errs[key]; err != nil {
+			return nil, err
+		}
+		if value, ok := l.values[key]; ok {
+			return value, nil
+		}
+		return l.empty, nil
+	}
+}
+
+const LOADERS_CONTEXT_KEY = "graphql_loaders"
+
+// loaders keep the loaders of one request, list loaders are kept apart by
+// their arguments.
+type loaders struct {
+	store *psql.Storage
+
+	mu     sync.Mutex
+	byName map[string]*loader
+}
+
+func newLoaders(store *psql.Storage) *loaders {
+	return &loaders{
+		store:  store,
+		byName: make(map[string]*loader),
+	}
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(LOADERS_CONTEXT_KEY).(*loaders)
+}
+
+func (ls *loaders) get(name string, empty interface{}, fetch batchFn) *loader {
+	ls.mu.Lock()
+	defer ls.mu.Unlock()
+
+	l, ok := ls.byName[name]
+	if !ok {
+		l = newLoader(fetch, empty)
+		ls.byName[name] = l
+	}
+	return l
+}
+
+// Nicknames and slugs are citext, their keys are lowercased.
+
+func (ls *loaders) user(nickname string) func() (interface{}, error) {
+	return ls.get("user", nil, func(keys []string) (map[string]interface{}, error) {
+		users, err := ls.store.GetUsersByNicknames(nil, keys)
+		if err != nil {
+			return nil, err
+		}
+		values := make(map[string]interface{}, len(users))
+		for i := range users {
+			values[strings.ToLower(users[i].Nickname)] = &users[i]
+		}
+		return values, nil
+	}).load(strings.ToLower(nickname))
+}
+
+func (ls *loaders) forum(slug string) func() (interface{}, error) {
+	return ls.get("forum", nil, func(keys []string) (map[string]interface{}, error) {
+		forums, err := ls.store.GetForumsBySlugs(nil, keys)
+		if err != nil {
+			return nil, err
+		}
+		values := make(map[string]interface{}, len(forums))
+		for i := range forums {
+			values[strings.ToLower(forums[i].Slug)] = &forums[i]
+		}
+		return values, nil
+	}).load(strings.ToLower(slug))
+}
+
+func (ls *loaders) thread(id int) func() (interface{}, error) {
+	return ls.get("thread", nil, func(keys []string) (map[string]interface{}, error) {
+		threads, err := ls.store.GetThreadsByIds(nil, atoi(keys))
+		if err != nil {
+			return nil, err
+		}
+		values := make(map[string]interface{}, len(threads))
+		for i := range threads {
+			values[strconv.Itoa(threads[i].Id)] = &threads[i]
+		}
+		return values, nil
+	}).load(strconv.Itoa(id))
+}
+
+func (ls *loaders) post(id int) func() (interface{}, error) {
+	return ls.get("post", nil, func(keys []string) (map[string]interface{}, error) {
+		posts, err := ls.store.GetPostsByIds(nil, atoi(keys))
+		if err != nil {
+			return nil, err
+		}
+		values := make(map[string]interface{}, len(posts))
+		for i := range posts {
+			values[strconv.Itoa(posts[i].Id)] = &posts[i]
+		}
+		return values, nil
+	}).load(strconv.Itoa(id))
+}
+
+func (ls *loaders) forumThreads(slug string, limit int, order string) func() (interface{}, error) {
+	name := "forumThreads:" + strconv.Itoa(limit) + ":" + order
+	return ls.get(name, []*entity.Thread{}, func(keys []string) (map[string]interface{}, error) {
+		threads, err := ls.store.GetForumsThreads(nil, keys, limit, order)
+		if err != nil {
+			return nil, err
+		}
+		grouped := make(map[string][]*entity.Thread)
+		for i := range threads {
+			key := strings.ToLower(threads[i].Forum)
+			grouped[key] = append(grouped[key], &threads[i])
+		}
+		values := make(map[string]interface{}, len(grouped))
+		for key, list := range grouped {
+			values[key] = list
+		}
+		return values, nil
+	}).load(strings.ToLower(slug))
+}
+
+func (ls *loaders) threadPosts(id int, limit int, sort string, order string) func() (interface{}, error) {
+	name := "threadPosts:" + strconv.Itoa(limit) + ":" + sort + ":" + order
+	return ls.get(name, []entity.Post{}, func(keys []string) (map[string]interface{}, error) {
+		posts, err := ls.store.GetThreadsPosts(nil, atoi(keys), limit, sort, order)
+		if err != nil {
+			return nil, err
+		}
+		grouped := make(map[string][]entity.Post)
+		for _, post := range posts {
+			key := strconv.Itoa(post.Thread)
+			grouped[key] = append(grouped[key], post)
+		}
+		values := make(map[string]interface{}, len(grouped))
+		for key, list := range grouped {
+			values[key] = list
+		}
+		return values, nil
+	}).load(strconv.Itoa(id))
+}
+
+func (ls *loaders) threadVotes(id int, limit int) func() (interface{}, error) {
+	name := "threadVotes:" + strconv.Itoa(limit)
+	return ls.get(name, []*entity.Vote{}, func(keys []string) (map[string]interface{}, error) {
+		votes, err := ls.store.GetThreadsVotes(nil, atoi(keys), limit)
+		if err != nil {
+			return nil, err
+		}
+		grouped := make(map[string][]*entity.Vote)
+		for i := range votes {
+			key := strconv.Itoa(votes[i].IdThread)
+			grouped[key] = append(grouped[key], &votes[i])
+		}
+		values := make(map[string]interface{}, len(grouped))
+		for key, list := range grouped {
+			values[key] = list
+		}
+		return values, nil
+	}).load(strconv.Itoa(id))
+}
+
+func atoi(keys []string) []int {
+	ids := make([]int, 0, len(keys))
+	for _, key := range keys {
+		if id, err := strconv.Atoi(key); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,362 @@
+package gql
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/graphql-go/graphql"
+	"strconv"
+	"techpark_db/internal/domain/entity"
+	"techpark_db/internal/handler"
+)
+
+var ErrLimit = "limit must be between 1 and "
+var ErrMaxDepth = "maxDepth must not be negative"
+
+var postSortEnum = graphql.NewEnum(graphql.EnumConfig{
+	Name: "PostSort",
+	Values: graphql.EnumValueConfigMap{
+		"FLAT": &graphql.EnumValueConfig{Value: "flat"},
+		"TREE": &graphql.EnumValueConfig{Value: "tree"},
+	},
+})
+
+// newSchema builds the schema. The fields of the types are resolved from
+// the entity structs: *entity.User, *entity.Forum, *entity.Thread,
+// *entity.Post, *entity.Vote and entity.PostNode.
+func (h *Handler) newSchema() (graphql.Schema, error) {
+	var forumType, threadType, postType, postNodeType, voteType *graphql.Object
+
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "User",
+		Fields: graphql.Fields{
+			"nickname": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"fullname": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"about":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"email":    &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+
+	forumType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Forum",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"slug":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"title": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"user": &graphql.Field{
+					Type: userType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return loadersFrom(p.Context).user(p.Source.(*entity.Forum).User), nil
+					},
+				},
+				"postCount": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.Int),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Source.(*entity.Forum).Posts, nil
+					},
+				},
+				"threadCount": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.Int),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return p.Source.(*entity.Forum).Threads, nil
+					},
+				},
+				"threads": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(threadType))),
+					Args: graphql.FieldConfigArgument{
+						"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: handler.DEFAULT_LIMIT},
+						"desc":  &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						limit, err := h.limit(p)
+						if err != nil {
+							return nil, err
+						}
+						forum := p.Source.(*entity.Forum)
+						return loadersFrom(p.Context).forumThreads(forum.Slug, limit, order(p)), nil
+					},
+				},
+			}
+		}),
+	})
+
+	threadType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Thread",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":      &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"slug":    &graphql.Field{Type: graphql.String},
+				"title":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"message": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"created": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"votes":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"author": &graphql.Field{
+					Type: userType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return loadersFrom(p.Context).user(p.Source.(*entity.Thread).Author), nil
+					},
+				},
+				"forum": &graphql.Field{
+					Type: forumType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return loadersFrom(p.Context).forum(p.Source.(*entity.Thread).Forum), nil
+					},
+				},
+				"posts": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(postType))),
+					Args: graphql.FieldConfigArgument{
+						"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: handler.DEFAULT_LIMIT},
+						"sort":  &graphql.ArgumentConfig{Type: postSortEnum, DefaultValue: handler.DEFAUTL_SORT},
+						"desc":  &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: false},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						limit, err := h.limit(p)
+						if err != nil {
+							return nil, err
+						}
+						thread := p.Source.(*entity.Thread)
+						sort, _ := p.Args["sort"].(string)
+						load := loadersFrom(p.Context).threadPosts(thread.Id, limit, sort, order(p))
+						return then(load, func(value interface{}) interface{} {
+							posts := value.([]entity.Post)
+							result := make([]*entity.Post, len(posts))
+							for i := range posts {
+								result[i] = &posts[i]
+							}
+							return result
+						}), nil
+					},
+				},
+				"tree": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(postNodeType))),
+					Description: "The first posts of the thread in tree order, nested like format=nested of the posts route.",
+					Args: graphql.FieldConfigArgument{
+						"limit":    &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: handler.DEFAULT_LIMIT},
+						"maxDepth": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: handler.DEFAULT_MAX_DEPTH},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						limit, err := h.limit(p)
+						if err != nil {
+							return nil, err
+						}
+						maxDepth, _ := p.Args["maxDepth"].(int)
+						if maxDepth < 0 {
+							return nil, errors.New(ErrMaxDepth)
+						}
+						thread := p.Source.(*entity.Thread)
+						load := loadersFrom(p.Context).threadPosts(thread.Id, limit, "tree", handler.DEFAULT_ORDER)
+						return then(load, func(value interface{}) interface{} {
+							return []entity.PostNode(handler.NestPosts(value.([]entity.Post), maxDepth))
+						}), nil
+					},
+				},
+				"voters": &graphql.Field{
+					Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(voteType))),
+					Description: "The first votes of the thread by nickname.",
+					Args: graphql.FieldConfigArgument{
+						"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: handler.DEFAULT_LIMIT},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						limit, err := h.limit(p)
+						if err != nil {
+							return nil, err
+						}
+						return loadersFrom(p.Context).threadVotes(p.Source.(*entity.Thread).Id, limit), nil
+					},
+				},
+			}
+		}),
+	})
+
+	postType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Post",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"id":       &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+				"message":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"isEdited": &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean)},
+				"created":  &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+				"author": &graphql.Field{
+					Type: userType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return loadersFrom(p.Context).user(p.Source.(*entity.Post).Author), nil
+					},
+				},
+				"forum": &graphql.Field{
+					Type: forumType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return loadersFrom(p.Context).forum(p.Source.(*entity.Post).Forum), nil
+					},
+				},
+				"thread": &graphql.Field{
+					Type: threadType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return loadersFrom(p.Context).thread(p.Source.(*entity.Post).Thread), nil
+					},
+				},
+				"parent": &graphql.Field{
+					Type: postType,
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						post := p.Source.(*entity.Post)
+						if post.Parent == 0 {
+							return nil, nil
+						}
+						return loadersFrom(p.Context).post(post.Parent), nil
+					},
+				},
+			}
+		}),
+	})
+
+	postNodeType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "PostNode",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"post": &graphql.Field{
+					Type: graphql.NewNonNull(postType),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						node := p.Source.(entity.PostNode)
+						return &entity.Post{
+							Id:       node.Id,
+							Parent:   node.Parent,
+							Author:   node.Author,
+							Message:  node.Message,
+							IsEdited: node.IsEdited,
+							Forum:    node.Forum,
+							Thread:   node.Thread,
+							Created:  node.Created,
+						}, nil
+					},
+				},
+				"children": &graphql.Field{
+					Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(postNodeType))),
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						return []entity.PostNode(p.Source.(entity.PostNode).Children), nil
+					},
+				},
+				"more": &graphql.Field{
+					Type:        graphql.Int,
+					Description: "Replies below maxDepth, null when there are none.",
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						node := p.Source.(entity.PostNode)
+						if node.More == nil {
+							return nil, nil
+						}
+						return node.More.Count, nil
+					},
+				},
+			}
+		}),
+	})
+
+	voteType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Vote",
+		Fields: graphql.Fields{
+			"voice": &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"user": &graphql.Field{
+				Type: userType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).user(p.Source.(*entity.Vote).Nickname), nil
+				},
+			},
+			"thread": &graphql.Field{
+				Type: threadType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return loadersFrom(p.Context).thread(p.Source.(*entity.Vote).IdThread), nil
+				},
+			},
+		},
+	})
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"user": &graphql.Field{
+				Type: userType,
+				Args: graphql.FieldConfigArgument{
+					"nickname": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					user, err := loadersFrom(p.Context).store.GetUser(nil, p.Args["nickname"].(string))
+					return found(user, err)
+				},
+			},
+			"forum": &graphql.Field{
+				Type: forumType,
+				Args: graphql.FieldConfigArgument{
+					"slug": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					forum, err := loadersFrom(p.Context).store.GetForum(nil, p.Args["slug"].(string))
+					return found(forum, err)
+				},
+			},
+			"thread": &graphql.Field{
+				Type: threadType,
+				Args: graphql.FieldConfigArgument{
+					"slugOrId": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					slugOrId := p.Args["slugOrId"].(string)
+					if slugOrId == "" {
+						return nil, nil
+					}
+					thread, err := loadersFrom(p.Context).store.GetThread(nil, slugOrId)
+					return found(thread, err)
+				},
+			},
+			"post": &graphql.Field{
+				Type: postType,
+				Args: graphql.FieldConfigArgument{
+					"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					post, err := loadersFrom(p.Context).store.GetPostById(nil, p.Args["id"].(int))
+					return found(post, err)
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{
+		Query: queryType,
+	})
+}
+
+// limit reads the limit argument of a list field.
+func (h *Handler) limit(p graphql.ResolveParams) (int, error) {
+	limit, _ := p.Args["limit"].(int)
+	if limit < 1 || limit > h.maxLimit {
+		return 0, errors.New(ErrLimit + strconv.Itoa(h.maxLimit))
+	}
+	return limit, nil
+}
+
+func order(p graphql.ResolveParams) string {
+	if desc, _ := p.Args["desc"].(bool); desc {
+		return "DESC"
+	}
+	return handler.DEFAULT_ORDER
+}
+
+// found resolves a missing row to null. The typed nil pointers of the
+// getters are not null for graphql-go, so they are dropped here.
+func found(v interface{}, err error) (interface{}, error) {
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// then maps the value of a loader thunk.
+func then(thunk func() (interface{}, error), fn func(interface{}) interface{}) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		value, err := thunk()
+		if err != nil {
+			return nil, err
+		}
+		return fn(value), nil
+	}
+}
@@ -1,7 +1,11 @@
 package handler
 
 import (
+	"net/http"
+	"strconv"
+	mw "techpark_db/internal/handler/middleware"
 	"techpark_db/internal/infra/psql"
+	"techpark_db/internal/service"
 	"time"
 )
 
@@ -12,26 +16,50 @@ const (
 	DEFAULT_SINCE_ASC  = ""
 	DEFAULT_SINCE_DESC = "ZZZZZZZZZZZZZZ"
 	DEFAUTL_SORT       = "flat"
+	DEFAULT_DEPTH      = 100000000
+	DEFAULT_REPLY_SORT = "tree"
+	DEFAULT_MAX_DEPTH  = 0
+	FORMAT_NESTED      = "nested"
+	DEFAULT_MAX_LIMIT  = 10000
 )
 
 var DEFAULT_SINCE_DATA_MIN = time.Date(1000, 00, 0, 0, 0, 0, 0, time.UTC).Format(time.RFC3339)
 var DEFAULT_SINCE_DATA_MAX = time.Date(4000, 00, 0, 0, 0, 0, 0, time.UTC).Format(time.RFC3339)
 
 type Handler struct {
-	storage *psql.Storage
+	storage  *psql.Storage
+	service  *service.Service
+	maxLimit int
 }
 
 func NewHandler(store *psql.Storage) *Handler {
 	return &Handler{
-		storage: store,
+		storage:  store,
+		service:  service.NewService(store),
+		maxLimit: DEFAULT_MAX_LIMIT,
 	}
 }
 
-var ErrNoUser = "Can't find user by nickname: "
-var ErrEmailAlreadyRegistered = "This email is already registered by user: "
-var ErrNoForum = "Can't find forum with slug: "
-var ErrNoThread = "Can't find thread with slug: "
-var ErrNoThreadAuthor = "Can't find thread author by nickname: "
-var ErrNoThreadForum = "Can't find thread forum by slug: "
-var ErrNoPost = "Can't find post by id: "
-var ErrNoPostAuthor = "Can't find post author by nickname: "
+// SetMaxLimit bounds the "limit" of list requests, so one request can't pull
+// a whole table into memory.
+func (h *Handler) SetMaxLimit(limit int) {
+	h.maxLimit = limit
+}
+
+func (h *Handler) limitRules() string {
+	return "min=1,max=" + strconv.Itoa(h.maxLimit)
+}
+
+// reader returns the storage for the reads of a request, which goes to
+// the primary DB for a while after the client's last write.
+func (h *Handler) reader(r *http.Request) *psql.Storage {
+	if mw.ReadPrimary(r) {
+		return h.storage.Primary()
+	}
+	return h.storage
+}
+
+var ErrBadRequest = "Bad request"
+var ErrInvalidBody = "Request body can not be decoded"
+var ErrInvalidFields = "Request body has invalid fields"
+var ErrInvalidQuery = "Request has invalid query parameters"
@@ -0,0 +1,18 @@
+package httperr
+
+import (
+	"github.com/mailru/easyjson"
+	"net/http"
+	"techpark_db/internal/apperr"
+)
+
+const PROBLEM_CONTENT_TYPE = "application/problem+json"
+
+// Write answers with the problem of err.
+func Write(w http.ResponseWriter, err error) {
+	problem := apperr.ProblemOf(err)
+	problemBytes, _ := easyjson.Marshal(problem)
+	w.Header().Set("Content-Type", PROBLEM_CONTENT_TYPE)
+	w.WriteHeader(problem.Status)
+	w.Write(problemBytes)
+}
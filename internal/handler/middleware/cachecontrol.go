@@ -0,0 +1,79 @@
+package mw
+
+import (
+	"errors"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"net/http"
+)
+
+// CacheControl sets the Cache-Control header of successful and 304 responses.
+// Policies are keyed by method and route template like the rate limit
+// policies, e.g. "GET /api/thread/{slug_or_id}/posts"; error responses and
+// routes without a policy get no header.
+type CacheControl struct {
+	policies map[string]string
+}
+
+func NewCacheControl(policies map[string]string) *CacheControl {
+	return &CacheControl{
+		policies: policies,
+	}
+}
+
+// LoadCacheControlPolicies reads the policies by method and route template
+// from a YAML file, see configs/cache-control.yaml:
+//
+//	GET /api/thread/{slug_or_id}/posts: no-cache
+func LoadCacheControlPolicies(path string) (map[string]string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	policies := make(map[string]string)
+	if err := yaml.UnmarshalStrict(data, &policies); err != nil {
+		return nil, err
+	}
+	for route, policy := range policies {
+		if policy == "" {
+			return nil, errors.New("cache control of " + route + " is empty")
+		}
+	}
+	return policies, nil
+}
+
+func (cc *CacheControl) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		policy, ok := cc.policies[routeKey(r)]
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(&cacheControlWriter{ResponseWriter: w, policy: policy}, r)
+	})
+}
+
+type cacheControlWriter struct {
+	http.ResponseWriter
+	policy      string
+	wroteHeader bool
+}
+
+func (w *cacheControlWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	if status < http.StatusBadRequest {
+		w.Header().Set("Cache-Control", w.policy)
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *cacheControlWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
@@ -0,0 +1,209 @@
+package mw
+
+import (
+	"compress/gzip"
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// COMPRESS_MIN_SIZE keeps small responses plain, compressing them costs
+// more than it saves.
+const COMPRESS_MIN_SIZE = 1024
+
+// encodings are the supported content codings in the order of preference
+// of the server, it decides between codings with the same q-value.
+var encodings = []string{"zstd", "br", "gzip"}
+
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+var encoderPools = map[string]*sync.Pool{
+	"zstd": {New: func() interface{} {
+		enc, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest), zstd.WithEncoderConcurrency(1))
+		return enc
+	}},
+	"br": {New: func() interface{} {
+		return brotli.NewWriterLevel(nil, 4)
+	}},
+	"gzip": {New: func() interface{} {
+		enc, _ := gzip.NewWriterLevel(nil, gzip.BestSpeed)
+		return enc
+	}},
+}
+
+// CompressMiddleware compresses responses with the best coding the client
+// accepts. ETags are left as they are: they name the row version, not the
+// bytes, and clients send them back in If-Match whatever the coding was.
+func CompressMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressWriter{ResponseWriter: w, encoding: encoding}
+		next.ServeHTTP(cw, r)
+		if err := cw.Close(); err != nil {
+			log.Warning("compress: ", err)
+		}
+	})
+}
+
+// negotiateEncoding picks the coding with the highest q-value in the
+// Accept-Encoding header, "" stands for no compression.
+func negotiateEncoding(header string) string {
+	if header == "" {
+		return ""
+	}
+	accepted := make(map[string]float64)
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(params[0]))
+		q := 1.0
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if value, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					q = value
+				}
+			}
+		}
+		accepted[name] = q
+	}
+
+	best, bestQ := "", 0.0
+	for _, encoding := range encodings {
+		q, ok := accepted[encoding]
+		if !ok {
+			q, ok = accepted["*"]
+		}
+		if ok && q > bestQ {
+			best, bestQ = encoding, q
+		}
+	}
+	return best
+}
+
+// compressWriter holds the body back until it grows to COMPRESS_MIN_SIZE,
+// then starts compressing. Shorter responses are written plain on Close.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	enc      encoder
+
+	status      int
+	wroteHeader bool
+	started     bool
+	pending     []byte
+}
+
+func (w *compressWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = status
+
+	if !bodyAllowed(status) || w.Header().Get("Content-Encoding") != "" {
+		w.startPlain()
+	}
+}
+
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.started {
+		if w.enc != nil {
+			return w.enc.Write(b)
+		}
+		return w.ResponseWriter.Write(b)
+	}
+
+	w.pending = append(w.pending, b...)
+	if len(w.pending) >= COMPRESS_MIN_SIZE {
+		if err := w.startCompressed(); err != nil {
+			return 0, err
+		}
+	}
+	return len(b), nil
+}
+
+// Flush sends what is written so far, a flushed response is compressed
+// whatever its size.
+func (w *compressWriter) Flush() {
+	if w.wroteHeader && !w.started {
+		if err := w.startCompressed(); err != nil {
+			return
+		}
+	}
+	if w.enc != nil {
+		if err := w.enc.Flush(); err != nil {
+			return
+		}
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *compressWriter) Close() error {
+	if !w.wroteHeader {
+		return nil
+	}
+	if !w.started {
+		if err := w.startPlain(); err != nil {
+			return err
+		}
+	}
+	if w.enc == nil {
+		return nil
+	}
+
+	err := w.enc.Close()
+	w.enc.Reset(nil)
+	encoderPools[w.encoding].Put(w.enc)
+	w.enc = nil
+	return err
+}
+
+func (w *compressWriter) startPlain() error {
+	w.started = true
+	w.ResponseWriter.WriteHeader(w.status)
+	return w.writePending(w.ResponseWriter)
+}
+
+func (w *compressWriter) startCompressed() error {
+	w.started = true
+	w.Header().Set("Content-Encoding", w.encoding)
+	w.Header().Del("Content-Length")
+	w.ResponseWriter.WriteHeader(w.status)
+
+	w.enc = encoderPools[w.encoding].Get().(encoder)
+	w.enc.Reset(w.ResponseWriter)
+	return w.writePending(w.enc)
+}
+
+func (w *compressWriter) writePending(out io.Writer) error {
+	if len(w.pending) == 0 {
+		return nil
+	}
+	_, err := out.Write(w.pending)
+	w.pending = nil
+	return err
+}
+
+func bodyAllowed(status int) bool {
+	return status >= http.StatusOK && status != http.StatusNoContent && status != http.StatusNotModified
+}
@@ -0,0 +1,190 @@
+package mw
+
+import (
+	"bytes"
+	"crypto/sha256"
+	log "github.com/sirupsen/logrus"
+	"io/ioutil"
+	"net/http"
+	"sync/atomic"
+	"techpark_db/internal/apperr"
+	"techpark_db/internal/handler/httperr"
+	"techpark_db/internal/infra/psql"
+	"time"
+)
+
+const (
+	IDEMPOTENCY_KEY_HEADER      = "Idempotency-Key"
+	IDEMPOTENCY_REPLAYED_HEADER = "Idempotent-Replayed"
+	IDEMPOTENCY_KEY_MAX_LEN     = 255
+	IDEMPOTENCY_TTL             = 24 * time.Hour
+	IDEMPOTENCY_LEASE           = time.Minute
+	IDEMPOTENCY_SWEEP_INTERVAL  = time.Minute
+)
+
+var ErrReadBody = "Request body can not be read"
+var ErrKeyTooLong = "Idempotency key is too long"
+var ErrKeyReused = "Idempotency key is already used with another request"
+var ErrKeyInProgress = "Request with the same idempotency key is in progress, retry later"
+
+// Idempotency makes POST requests carrying an Idempotency-Key header safe to
+// retry: the first request stores its response under the key and the
+// repeats with the same method, URL, Accept and body get the stored response
+// back. Keys are scoped by the client IP, clients picking the same key do
+// not get each other's responses.
+// Server errors are not stored, the request runs again on retry.
+// A key is held by a request in progress for the lease only, so a request
+// lost with the process does not lock its key for the whole ttl.
+type Idempotency struct {
+	store     *psql.Storage
+	ttl       time.Duration
+	lease     time.Duration
+	lastSweep int64
+}
+
+func NewIdempotency(store *psql.Storage, ttl, lease time.Duration) *Idempotency {
+	return &Idempotency{
+		store: store,
+		ttl:   ttl,
+		lease: lease,
+	}
+}
+
+func (idem *Idempotency) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(IDEMPOTENCY_KEY_HEADER)
+		if key == "" || r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+		if len(key) > IDEMPOTENCY_KEY_MAX_LEN {
+			httperr.Write(w, apperr.New(apperr.CodeBadRequest, ErrKeyTooLong))
+			return
+		}
+
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			httperr.Write(w, apperr.Wrap(apperr.CodeInvalidBody, ErrReadBody, err))
+			return
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+		client := clientIP(r)
+		resp, leaseUntil, err := idem.Claim(client, key, requestHash(r, body))
+		switch {
+		case err == psql.ErrIdempotencyKeyReused:
+			httperr.Write(w, apperr.New(apperr.CodeKeyReused, ErrKeyReused))
+			return
+		case err == psql.ErrIdempotencyKeyInProgress:
+			httperr.Write(w, apperr.New(apperr.CodeKeyInProgress, ErrKeyInProgress))
+			return
+		case err != nil:
+			httperr.Write(w, err)
+			return
+		case resp != nil:
+			if resp.ContentType != "" {
+				w.Header().Set("Content-Type", resp.ContentType)
+			}
+			if resp.ETag != "" {
+				w.Header().Set("ETag", resp.ETag)
+			}
+			if resp.Location != "" {
+				w.Header().Set("Location", resp.Location)
+			}
+			w.Header().Set(IDEMPOTENCY_REPLAYED_HEADER, "true")
+			w.WriteHeader(resp.Status)
+			w.Write(resp.Body)
+			return
+		}
+
+		rec := &recordingWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		if rec.status >= http.StatusInternalServerError {
+			idem.Release(client, key, leaseUntil)
+			return
+		}
+		idem.Save(client, key, leaseUntil, psql.IdempotentResponse{
+			Status:      rec.status,
+			ContentType: rec.Header().Get("Content-Type"),
+			ETag:        rec.Header().Get("ETag"),
+			Location:    rec.Header().Get("Location"),
+			Body:        rec.body.Bytes(),
+		})
+	})
+}
+
+// Claim claims the key of the client for a request with the hash, see
+// psql.Storage.ClaimIdempotencyKey. The gRPC API claims its keys here too.
+func (idem *Idempotency) Claim(client, key string, hash []byte) (*psql.IdempotentResponse, time.Time, error) {
+	idem.sweep()
+	return idem.store.ClaimIdempotencyKey(scopedKey(client, key), hash, idem.ttl, idem.lease)
+}
+
+// Save stores the response of the request holding the lease on the key.
+func (idem *Idempotency) Save(client, key string, leaseUntil time.Time, resp psql.IdempotentResponse) {
+	if err := idem.store.SaveIdempotentResponse(scopedKey(client, key), leaseUntil, resp); err != nil {
+		// the key stays in progress until the lease expires
+		log.Warning("idempotency key ", key, ": ", err)
+	}
+}
+
+// Release lets the request with the key run again.
+func (idem *Idempotency) Release(client, key string, leaseUntil time.Time) {
+	if err := idem.store.ReleaseIdempotencyKey(scopedKey(client, key), leaseUntil); err != nil {
+		log.Warning("idempotency key ", key, ": ", err)
+	}
+}
+
+// scopedKey is the stored key, "ip:" + client IP + " " + the key like the
+// buckets of RateLimit.
+func scopedKey(client, key string) string {
+	return "ip:" + client + " " + key
+}
+
+// requestHash covers Accept too: the stored body is in the negotiated format.
+func requestHash(r *http.Request, body []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	h.Write([]byte(r.Header.Get("Accept") + "\n"))
+	h.Write(body)
+	return h.Sum(nil)
+}
+
+func (idem *Idempotency) sweep() {
+	now := time.Now().UnixNano()
+	last := atomic.LoadInt64(&idem.lastSweep)
+	if time.Duration(now-last) < IDEMPOTENCY_SWEEP_INTERVAL || !atomic.CompareAndSwapInt64(&idem.lastSweep, last, now) {
+		return
+	}
+	go func() {
+		if err := idem.store.SweepIdempotencyKeys(idem.ttl); err != nil {
+			log.Warning("idempotency sweep: ", err)
+		}
+	}()
+}
+
+// recordingWriter passes the response through and keeps a copy of it.
+type recordingWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+func (w *recordingWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
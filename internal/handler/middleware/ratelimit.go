@@ -0,0 +1,100 @@
+package mw
+
+import (
+	"github.com/gorilla/mux"
+	log "github.com/sirupsen/logrus"
+	"math"
+	"net"
+	"net/http"
+	"regexp"
+	"strconv"
+	"techpark_db/internal/apperr"
+	"techpark_db/internal/handler/httperr"
+	"techpark_db/internal/infra/ratelimit"
+	"time"
+)
+
+var ErrRateLimited = "Too many requests, retry later"
+
+// routeVarPattern strips the regexps from route variables:
+// "/thread/{slug_or_id:[A-Za-z0-9._-]+}/vote" is "/thread/{slug_or_id}/vote".
+var routeVarPattern = regexp.MustCompile(`\{(\w+):[^}]*\}`)
+
+// RateLimit limits requests per client IP. The API has no authentication,
+// so there are no per-user buckets: the nickname in a body proves nothing.
+// Policies are keyed by method and route template, e.g.
+// "POST /api/thread/{slug_or_id}/vote"; routes without a policy are not limited.
+type RateLimit struct {
+	limiter  ratelimit.Limiter
+	policies map[string]ratelimit.Policy
+}
+
+func NewRateLimit(limiter ratelimit.Limiter, policies map[string]ratelimit.Policy) *RateLimit {
+	return &RateLimit{
+		limiter:  limiter,
+		policies: policies,
+	}
+}
+
+func (rl *RateLimit) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		res, ok := rl.Allow(clientIP(r), routeKey(r))
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+		w.Header().Set("RateLimit-Reset", ceilSeconds(res.Reset))
+		if !res.Allowed {
+			w.Header().Set("Retry-After", ceilSeconds(res.RetryAfter))
+			httperr.Write(w, apperr.New(apperr.CodeRateLimited, ErrRateLimited))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Allow takes a token from the bucket of the client IP on the route, the
+// gRPC API shares the buckets of the routes its calls mirror. It is false
+// for a route without a policy and when the limiter fails: a broken shared
+// limiter must not take the API down.
+func (rl *RateLimit) Allow(ip string, route string) (ratelimit.Result, bool) {
+	policy, ok := rl.policies[route]
+	if !ok {
+		return ratelimit.Result{}, false
+	}
+	res, err := rl.limiter.Allow("ip:"+ip+" "+route, policy)
+	if err != nil {
+		log.Warning("rate limit: ", err)
+		return ratelimit.Result{}, false
+	}
+	return res, true
+}
+
+func routeKey(r *http.Request) string {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return ""
+	}
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return ""
+	}
+	return r.Method + " " + routeVarPattern.ReplaceAllString(template, "{$1}")
+}
+
+// clientIP is the address of the peer. Proxies are not trusted, a proxy in
+// front of the server should do its own limiting.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
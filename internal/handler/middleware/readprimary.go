@@ -0,0 +1,78 @@
+package mw
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	READ_PRIMARY_COOKIE = "read_primary"
+	READ_PRIMARY_HEADER = "X-Read-Primary"
+	READ_PRIMARY_TTL    = 5 * time.Second
+)
+
+// ReadYourWritesMiddleware marks requests which must read from the primary
+// DB: a successful write sends back a cookie and a header holding the
+// deadline (unix milliseconds), clients without cookies repeat the header.
+func ReadYourWritesMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if readPrimaryRequested(r) {
+			ctx := context.WithValue(r.Context(), "read_primary", true)
+			r = r.WithContext(ctx)
+		}
+
+		if r.Method == http.MethodGet || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(&stickyWriter{ResponseWriter: w}, r)
+	})
+}
+
+// ReadPrimary reports whether the request must not be served by a replica.
+func ReadPrimary(r *http.Request) bool {
+	readPrimary, _ := r.Context().Value("read_primary").(bool)
+	return readPrimary
+}
+
+func readPrimaryRequested(r *http.Request) bool {
+	if _, err := r.Cookie(READ_PRIMARY_COOKIE); err == nil {
+		return true
+	}
+	deadline, err := strconv.ParseInt(r.Header.Get(READ_PRIMARY_HEADER), 10, 64)
+	return err == nil && time.Now().UnixNano()/int64(time.Millisecond) < deadline
+}
+
+type stickyWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *stickyWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+
+	if status < http.StatusBadRequest {
+		deadline := time.Now().Add(READ_PRIMARY_TTL)
+		http.SetCookie(w.ResponseWriter, &http.Cookie{
+			Name:     READ_PRIMARY_COOKIE,
+			Value:    "1",
+			Path:     "/",
+			MaxAge:   int(READ_PRIMARY_TTL / time.Second),
+			HttpOnly: true,
+		})
+		w.Header().Set(READ_PRIMARY_HEADER, strconv.FormatInt(deadline.UnixNano()/int64(time.Millisecond), 10))
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *stickyWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
@@ -0,0 +1,113 @@
+package openapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"embed"
+	"github.com/gorilla/mux"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"techpark_db/internal/handler/httperr"
+)
+
+// Spec is the OpenAPI 3 document of the routes under /api. Bump its
+// info.version with every change of the contract.
+//
+//go:embed openapi.json
+var Spec []byte
+
+// swaggerUI holds the gzipped swagger-ui-dist files the docs page loads,
+// see swagger-ui/README.md.
+//
+//go:embed swagger-ui/*.gz
+var swaggerUI embed.FS
+
+const (
+	SPEC_PATH = "/api/openapi.json"
+	DOCS_PATH = "/api/docs"
+)
+
+// SWAGGER_UI_VERSION is the swagger-ui-dist release in swagger-ui.
+const SWAGGER_UI_VERSION = "5.29.1"
+
+const swaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Forum API</title>
+<link rel="stylesheet" href="` + DOCS_PATH + `/swagger-ui.css">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="` + DOCS_PATH + `/swagger-ui-bundle.js"></script>
+<script>
+window.ui = SwaggerUIBundle({url: "` + SPEC_PATH + `", dom_id: "#swagger-ui"});
+</script>
+</body>
+</html>
+`
+
+func SpecHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(Spec)
+}
+
+// DocsHandler serves Swagger UI for the spec.
+func DocsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write([]byte(swaggerUIPage))
+}
+
+// AssetHandler serves the Swagger UI file named by the "file" variable,
+// gzipped as it is stored to the clients accepting gzip.
+func AssetHandler(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["file"]
+	body, err := swaggerUI.ReadFile("swagger-ui/" + name + ".gz")
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", mime.TypeByExtension(path.Ext(name)))
+	w.Header().Set("ETag", `"`+SWAGGER_UI_VERSION+`"`)
+	if r.Header.Get("If-None-Match") == w.Header().Get("ETag") {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	if acceptsGzip(r.Header.Get("Accept-Encoding")) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(body)
+		return
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		httperr.Write(w, err)
+		return
+	}
+	io.Copy(w, zr)
+}
+
+// acceptsGzip reports whether the Accept-Encoding header allows gzip with
+// a q-value above zero.
+func acceptsGzip(header string) bool {
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(params[0]))
+		if name != "gzip" && name != "*" {
+			continue
+		}
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil && q == 0 {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
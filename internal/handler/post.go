@@ -1,90 +1,93 @@
 package handler
 
 import (
-	"encoding/json"
 	"github.com/gorilla/mux"
-	"github.com/mailru/easyjson"
-	log "github.com/sirupsen/logrus"
 	"net/http"
 	"strconv"
 	"strings"
+	"techpark_db/internal/apperr"
 	"techpark_db/internal/domain/entity"
+	"techpark_db/internal/domain/validate"
+	"techpark_db/internal/handler/httperr"
+	"techpark_db/internal/service"
 )
 
 func (h *Handler) PostGet(w http.ResponseWriter, r *http.Request) {
 	w.Header().Add("Content-Type", "application/json")
+	store := h.reader(r)
 	vars := mux.Vars(r)
 	idRaw, ok := vars["id"]
 	if !ok {
-		w.WriteHeader(http.StatusBadRequest)
+		httperr.Write(w, apperr.New(apperr.CodeBadRequest, ErrBadRequest))
 		return
 	}
 
 	argsRaw := r.FormValue("related")
 	args := strings.Split(argsRaw, ",")
-	//log.Info(args, "///", argsRaw, "///")
-
-	//tx, err := h.storage.DB.Begin()
-	//if err != nil {
-	//	log.Error(err)
-	//	w.WriteHeader(http.StatusInternalServerError)
-	//	return
-	//}
+	if argsRaw != "" {
+		q := newQuery(r)
+		for _, arg := range args {
+			q.errors = append(q.errors, validate.Var("related", arg, "oneof=user forum thread")...)
+		}
+		if err := q.Err(); err != nil {
+			httperr.Write(w, err)
+			return
+		}
+	}
 
 	id, _ := strconv.Atoi(idRaw)
-	post, err := h.storage.GetPostById(nil, id)
+	post, err := store.GetPostById(nil, id)
 	if err != nil {
-		//tx.Rollback()
-		resp := &entity.Error{
-			Message: ErrNoPost + idRaw,
-		}
-		respBytes, _ := easyjson.Marshal(resp)
-		w.WriteHeader(http.StatusNotFound)
-		w.Write(respBytes)
+		httperr.Write(w, apperr.New(apperr.CodePostNotFound, service.ErrNoPost+idRaw))
 		return
 	}
 
 	var postDetails entity.PostDetails
 	postDetails.DPost = post
+	versions := []int{post.Version}
+	modified := post.Modified
 
 	for _, arg := range args {
 		switch arg {
 		case "user":
-			author, err := h.storage.GetUser(nil, post.Author)
+			author, err := store.GetUser(nil, post.Author)
 			if err != nil {
-				//tx.Rollback()
-				w.WriteHeader(http.StatusInternalServerError)
+				httperr.Write(w, err)
 				return
 			}
 			postDetails.DAuthor = author
+			versions = append(versions, author.Version)
+			modified = latest(modified, author.Modified)
 		case "forum":
-			forum, err := h.storage.GetForum(nil, post.Forum)
+			forum, err := store.GetForum(nil, post.Forum)
 			if err != nil {
-				//tx.Rollback()
-				w.WriteHeader(http.StatusInternalServerError)
+				httperr.Write(w, err)
 				return
 			}
 			postDetails.DForum = forum
+			versions = append(versions, forum.Version)
+			modified = latest(modified, forum.Modified)
 		case "thread":
-			thread, err := h.storage.GetThreadById(nil, post.Thread)
+			thread, err := store.GetThreadById(nil, post.Thread)
 			if err != nil {
-				//tx.Rollback()
-				w.WriteHeader(http.StatusInternalServerError)
+				httperr.Write(w, err)
 				return
 			}
 			postDetails.DThread = thread
+			versions = append(versions, thread.Version)
+			modified = latest(modified, thread.Modified)
 		}
 	}
 
-	//if err := tx.Commit(); err != nil {
-	//	log.Error(err)
-	//	w.WriteHeader(http.StatusInternalServerError)
-	//	return
-	//}
-
-	postDetailsBytes, _ := easyjson.Marshal(postDetails)
-	w.WriteHeader(http.StatusOK)
-	w.Write(postDetailsBytes)
+	// the related rows make the response a combination of versions
+	tag := service.ETag(post.Version)
+	if len(versions) > 1 {
+		tag = weakETag(versions...)
+	}
+	if notModified(w, r, tag, modified) {
+		return
+	}
+	write(w, r, http.StatusOK, &postDetails)
 }
 
 func (h *Handler) PostUpdate(w http.ResponseWriter, r *http.Request) {
@@ -92,39 +95,26 @@ func (h *Handler) PostUpdate(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	idRaw, ok := vars["id"]
 	if !ok {
-		w.WriteHeader(http.StatusBadRequest)
+		httperr.Write(w, apperr.New(apperr.CodeBadRequest, ErrBadRequest))
 		return
 	}
-	//log.Info(r.FormValue("related"))
 	id, _ := strconv.Atoi(idRaw)
 
 	var postRequest entity.UpdatePost
-	if err := json.NewDecoder(r.Body).Decode(&postRequest); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := decode(r, &postRequest); err != nil {
+		httperr.Write(w, err)
 		return
 	}
 
-	tx, err := h.storage.DB.Begin()
+	post, edited, err := h.service.UpdatePost(r.Context(), id, postRequest, r.Header.Get("If-Match"))
 	if err != nil {
-		log.Error(err)
-		w.WriteHeader(http.StatusInternalServerError)
+		httperr.Write(w, err)
 		return
 	}
 
-	post, err := h.storage.GetPostById(tx, id)
-	if err != nil {
-		tx.Rollback()
-		resp := &entity.Error{
-			Message: ErrNoPost + idRaw,
-		}
-		respBytes, _ := easyjson.Marshal(resp)
-		w.WriteHeader(http.StatusNotFound)
-		w.Write(respBytes)
-		return
-	}
+	setETag(w, r, post.Version)
 
-	if postRequest.Message == "" || postRequest.Message == post.Message {
-		tx.Rollback()
+	if !edited {
 		postWithoutEdited := entity.PostWithoutEdited{
 			Id:      post.Id,
 			Parent:  post.Parent,
@@ -135,28 +125,109 @@ func (h *Handler) PostUpdate(w http.ResponseWriter, r *http.Request) {
 			Created: post.Created,
 		}
 
-		postWithoutEditedBytes, _ := easyjson.Marshal(postWithoutEdited)
-		w.WriteHeader(http.StatusOK)
-		w.Write(postWithoutEditedBytes)
+		write(w, r, http.StatusOK, &postWithoutEdited)
 		return
 	}
 
-	if err := h.storage.UpdatePost(tx, id, postRequest.Message); err != nil {
-		tx.Rollback()
-		w.WriteHeader(http.StatusInternalServerError)
+	write(w, r, http.StatusOK, post)
+}
+
+func (h *Handler) PostSplit(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Content-Type", "application/json")
+	vars := mux.Vars(r)
+	idRaw, ok := vars["id"]
+	if !ok {
+		httperr.Write(w, apperr.New(apperr.CodeBadRequest, ErrBadRequest))
 		return
 	}
+	id, _ := strconv.Atoi(idRaw)
 
-	post.Message = postRequest.Message
-	post.IsEdited = true
+	var splitReq entity.SplitPost
+	if err := decode(r, &splitReq); err != nil {
+		httperr.Write(w, err)
+		return
+	}
 
-	if err := tx.Commit(); err != nil {
-		log.Error(err)
-		w.WriteHeader(http.StatusInternalServerError)
+	thread, err := h.service.SplitPost(r.Context(), id, splitReq)
+	if err == service.ErrExists {
+		write(w, r, http.StatusConflict, thread)
+		return
+	}
+	if err != nil {
+		httperr.Write(w, err)
 		return
 	}
 
-	postBytes, _ := easyjson.Marshal(post)
-	w.WriteHeader(http.StatusOK)
-	w.Write(postBytes)
+	write(w, r, http.StatusCreated, thread)
+}
+
+func (h *Handler) PostReplies(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Content-Type", "application/json")
+	store := h.reader(r)
+	vars := mux.Vars(r)
+	idRaw, ok := vars["id"]
+	if !ok {
+		httperr.Write(w, apperr.New(apperr.CodeBadRequest, ErrBadRequest))
+		return
+	}
+	id, _ := strconv.Atoi(idRaw)
+
+	q := newQuery(r)
+	limit := q.Int("limit", DEFAULT_LIMIT, h.limitRules())
+	depth := q.Int("depth", DEFAULT_DEPTH, "min=1")
+	sort := q.String("sort", DEFAULT_REPLY_SORT, "oneof=tree flat")
+	order := DEFAULT_ORDER
+	if q.Bool("desc") {
+		order = "DESC"
+	}
+	if err := q.Err(); err != nil {
+		httperr.Write(w, err)
+		return
+	}
+
+	posts, err := store.GetPostReplies(nil, id, depth, limit, sort, order)
+	if err != nil {
+		httperr.Write(w, err)
+		return
+	}
+
+	if len(*posts) == 0 {
+		if _, err := store.GetPostById(nil, id); err != nil {
+			httperr.Write(w, apperr.New(apperr.CodePostNotFound, service.ErrNoPost+idRaw))
+			return
+		}
+	}
+
+	var p entity.Posts
+	p = *posts
+	write(w, r, http.StatusOK, p)
+}
+
+func (h *Handler) PostAncestors(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Content-Type", "application/json")
+	store := h.reader(r)
+	vars := mux.Vars(r)
+	idRaw, ok := vars["id"]
+	if !ok {
+		httperr.Write(w, apperr.New(apperr.CodeBadRequest, ErrBadRequest))
+		return
+	}
+	id, _ := strconv.Atoi(idRaw)
+
+	posts, err := store.GetPostAncestors(nil, id)
+	if err != nil {
+		httperr.Write(w, err)
+		return
+	}
+
+	if len(*posts) == 0 {
+		if _, err := store.GetPostById(nil, id); err != nil {
+			httperr.Write(w, apperr.New(apperr.CodePostNotFound, service.ErrNoPost+idRaw))
+			return
+		}
+	}
+
+	var p entity.Posts
+	p = *posts
+	write(w, r, http.StatusOK, p)
 }
@@ -0,0 +1,120 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/tinylib/msgp/msgp"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"techpark_db/internal/apperr"
+	"techpark_db/internal/domain/entity"
+	"techpark_db/internal/domain/validate"
+)
+
+// decode reads the body into v in the format of the Content-Type header
+// and checks its validate tags.
+func decode(r *http.Request, v interface{}) error {
+	var err error
+	switch requestContentType(r) {
+	case CONTENT_TYPE_MSGPACK:
+		err = decodeMsgpack(r, v)
+	case CONTENT_TYPE_PROTOBUF:
+		err = decodeProto(r, v)
+	default:
+		err = json.NewDecoder(r.Body).Decode(v)
+	}
+	if err != nil {
+		return apperr.Wrap(apperr.CodeInvalidBody, ErrInvalidBody, err)
+	}
+	if errs := validate.Struct(v); errs != nil {
+		return apperr.Invalid(apperr.CodeInvalidFields, ErrInvalidFields, errs)
+	}
+	return nil
+}
+
+func decodeMsgpack(r *http.Request, v interface{}) error {
+	unmarshaler, ok := v.(msgp.Unmarshaler)
+	if !ok {
+		return errors.New("body can't be decoded from msgpack")
+	}
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	_, err = unmarshaler.UnmarshalMsg(data)
+	return err
+}
+
+func decodeProto(r *http.Request, v interface{}) error {
+	unmarshaler, ok := v.(protoUnmarshaler)
+	if !ok {
+		return errors.New("body can't be decoded from protobuf")
+	}
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	return unmarshaler.UnmarshalProto(data)
+}
+
+// query parses the query parameters with the rules of the validate package.
+// Every invalid parameter is reported by Err at once.
+type query struct {
+	r      *http.Request
+	errors validate.Errors
+}
+
+func newQuery(r *http.Request) *query {
+	return &query{r: r}
+}
+
+func (q *query) Int(name string, def int, rules string) int {
+	raw := q.r.FormValue(name)
+	if raw == "" {
+		return def
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil {
+		q.errors = append(q.errors, entity.FieldError{
+			Field:   name,
+			Rule:    "int",
+			Message: "must be an integer",
+		})
+		return def
+	}
+	q.errors = append(q.errors, validate.Var(name, value, rules)...)
+	return value
+}
+
+func (q *query) Bool(name string) bool {
+	raw := q.r.FormValue(name)
+	if raw == "" {
+		return false
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		q.errors = append(q.errors, entity.FieldError{
+			Field:   name,
+			Rule:    "bool",
+			Message: "must be true or false",
+		})
+	}
+	return value
+}
+
+func (q *query) String(name string, def string, rules string) string {
+	raw := q.r.FormValue(name)
+	if raw == "" {
+		return def
+	}
+	q.errors = append(q.errors, validate.Var(name, raw, rules)...)
+	return raw
+}
+
+func (q *query) Err() error {
+	if len(q.errors) == 0 {
+		return nil
+	}
+	return apperr.Invalid(apperr.CodeInvalidQuery, ErrInvalidQuery, q.errors)
+}
@@ -0,0 +1,63 @@
+package router
+
+import (
+	"github.com/gorilla/mux"
+	"net/http"
+	"techpark_db/internal/handler"
+	mw "techpark_db/internal/handler/middleware"
+	"techpark_db/internal/handler/openapi"
+)
+
+// NewRouter routes the REST API, GraphQL and the docs under /api. The
+// middlewares wrap the routes under /api in the order given, the handlers
+// get the request timestamps from TimeLogMiddleware inside them.
+func NewRouter(handler *handler.Handler, gqlHandler http.Handler, middlewares ...mux.MiddlewareFunc) *mux.Router {
+	router := mux.NewRouter()
+	routerAPI := router.PathPrefix("/api").Subrouter()
+
+	/*====================== FORUM ======================*/
+	routerAPI.HandleFunc("/forum/create", handler.ForumCreate).Methods("POST")
+	routerAPI.HandleFunc("/forum/{slug:[A-Za-z0-9._-]+}/details", handler.ForumDetails).Methods("GET")
+	routerAPI.HandleFunc("/forum/{slug:[A-Za-z0-9._-]+}/create", handler.ForumCreateThread).Methods("POST")
+	routerAPI.HandleFunc("/forum/{slug:[A-Za-z0-9._-]+}/users", handler.ForumUsers).Methods("GET")
+	routerAPI.HandleFunc("/forum/{slug:[A-Za-z0-9._-]+}/threads", handler.ForumThreads).Methods("GET")
+
+	/*====================== THREAD ======================*/
+	routerAPI.HandleFunc("/thread/{slug_or_id:[A-Za-z0-9._-]+}/create", handler.ThreadCreatePosts).Methods("POST")
+	routerAPI.HandleFunc("/thread/{slug_or_id:[A-Za-z0-9._-]+}/vote", handler.ThreadVote).Methods("POST")
+	routerAPI.HandleFunc("/thread/{slug_or_id:[A-Za-z0-9._-]+}/details", handler.ThreadDetails).Methods("GET")
+	routerAPI.HandleFunc("/thread/{slug_or_id:[A-Za-z0-9._-]+}/details", handler.ThreadUpdate).Methods("POST")
+	routerAPI.HandleFunc("/thread/{slug_or_id:[A-Za-z0-9._-]+}/posts", handler.ThreadPosts).Methods("GET")
+	routerAPI.HandleFunc("/thread/{slug_or_id:[A-Za-z0-9._-]+}/move", handler.ThreadMove).Methods("POST")
+	routerAPI.HandleFunc("/thread/{slug_or_id:[A-Za-z0-9._-]+}/merge", handler.ThreadMerge).Methods("POST")
+
+	/*====================== POST ======================*/
+	routerAPI.HandleFunc("/post/{id:[0-9]+}/details", handler.PostGet).Methods("GET")
+	routerAPI.HandleFunc("/post/{id:[0-9]+}/details", handler.PostUpdate).Methods("POST")
+	routerAPI.HandleFunc("/post/{id:[0-9]+}/split", handler.PostSplit).Methods("POST")
+	routerAPI.HandleFunc("/post/{id:[0-9]+}/replies", handler.PostReplies).Methods("GET")
+	routerAPI.HandleFunc("/post/{id:[0-9]+}/ancestors", handler.PostAncestors).Methods("GET")
+
+	/*====================== USER ======================*/
+	routerAPI.HandleFunc("/user/{nickname:[A-Za-z0-9._-]+}/create", handler.UserCreate).Methods("POST")
+	routerAPI.HandleFunc("/user/{nickname:[A-Za-z0-9._-]+}/profile", handler.UserDetails).Methods("GET")
+	routerAPI.HandleFunc("/user/{nickname:[A-Za-z0-9._-]+}/profile", handler.UserUpdate).Methods("POST")
+	routerAPI.HandleFunc("/user/{nickname:[A-Za-z0-9._-]+}/rename", handler.UserRename).Methods("POST")
+
+	/*====================== SERVICE ======================*/
+	routerAPI.HandleFunc("/service/status", handler.ServiceStatus).Methods("GET")
+	routerAPI.HandleFunc("/service/clear", handler.ServiceClear).Methods("POST")
+	routerAPI.HandleFunc("/service/cache", handler.ServiceCache).Methods("GET")
+
+	/*====================== GRAPHQL ======================*/
+	routerAPI.Handle("/graphql", gqlHandler).Methods("GET", "POST")
+
+	/*====================== DOCS ======================*/
+	routerAPI.HandleFunc("/openapi.json", openapi.SpecHandler).Methods("GET")
+	routerAPI.HandleFunc("/docs", openapi.DocsHandler).Methods("GET")
+	routerAPI.HandleFunc("/docs/{file:[A-Za-z0-9._-]+}", openapi.AssetHandler).Methods("GET")
+
+	routerAPI.Use(middlewares...)
+	routerAPI.Use(mw.TimeLogMiddleware)
+	return router
+}
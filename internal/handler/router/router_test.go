@@ -0,0 +1,238 @@
+package router
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"techpark_db/internal/handler"
+	"techpark_db/internal/handler/gql"
+	mw "techpark_db/internal/handler/middleware"
+	"techpark_db/internal/handler/openapi"
+	"techpark_db/internal/infra/psql"
+	"techpark_db/internal/infra/psql/psqltest"
+	"testing"
+)
+
+func init() {
+	// the docs are text, the spec types them as strings
+	for _, contentType := range []string{"text/html", "text/css", "text/javascript"} {
+		openapi3filter.RegisterBodyDecoder(contentType, openapi3filter.RegisteredBodyDecoder("text/plain"))
+	}
+}
+
+// contract serves requests with the real router and checks them and their
+// responses against openapi.json.
+type contract struct {
+	t      *testing.T
+	router http.Handler
+	spec   routers.Router
+}
+
+func newContract(t *testing.T, store *psql.Storage) *contract {
+	t.Helper()
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(openapi.Spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := doc.Validate(loader.Context); err != nil {
+		t.Fatal(err)
+	}
+	spec, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gqlHandler, err := gql.NewHandler(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	router := NewRouter(handler.NewHandler(store), gqlHandler, mw.CompressMiddleware, mw.ReadYourWritesMiddleware)
+	return &contract{t: t, router: router, spec: spec}
+}
+
+// do sends a request the spec allows and expects the status want.
+func (c *contract) do(method, target, body string, want int, header ...string) *httptest.ResponseRecorder {
+	c.t.Helper()
+	return c.serve(method, target, body, want, true, header)
+}
+
+// reject sends a request the spec does not allow, the server has to reject
+// it with the status want.
+func (c *contract) reject(method, target, body string, want int, header ...string) *httptest.ResponseRecorder {
+	c.t.Helper()
+	return c.serve(method, target, body, want, false, header)
+}
+
+func (c *contract) serve(method, target, body string, want int, valid bool, header []string) *httptest.ResponseRecorder {
+	c.t.Helper()
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for i := 0; i+1 < len(header); i += 2 {
+		req.Header.Set(header[i], header[i+1])
+	}
+
+	route, params, err := c.spec.FindRoute(req)
+	if err != nil {
+		c.t.Fatalf("%s %s is not in the spec: %v", method, target, err)
+	}
+	input := &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: params,
+		Route:      route,
+		Options:    &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+	}
+	err = openapi3filter.ValidateRequest(context.Background(), input)
+	if valid && err != nil {
+		c.t.Fatalf("%s %s: invalid request: %v", method, target, err)
+	}
+	if !valid && err == nil {
+		c.t.Fatalf("%s %s: the spec allows the request", method, target)
+	}
+
+	rec := httptest.NewRecorder()
+	c.router.ServeHTTP(rec, req)
+	if rec.Code != want {
+		c.t.Fatalf("%s %s: status %d, want %d: %s", method, target, rec.Code, want, rec.Body.String())
+	}
+
+	output := &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 rec.Code,
+		Header:                 rec.Header(),
+		Options:                &openapi3filter.Options{IncludeResponseStatus: true},
+	}
+	output.SetBodyBytes(rec.Body.Bytes())
+	if err := openapi3filter.ValidateResponse(context.Background(), output); err != nil {
+		c.t.Fatalf("%s %s: invalid response: %v", method, target, err)
+	}
+	return rec
+}
+
+func decodeBody(t *testing.T, rec *httptest.ResponseRecorder, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+		t.Fatalf("body %q: %v", rec.Body.String(), err)
+	}
+}
+
+// TestContractWithoutDB covers the routes answering before the storage.
+func TestContractWithoutDB(t *testing.T) {
+	c := newContract(t, psql.NewStorage(nil))
+
+	c.do("GET", "/api/openapi.json", "", http.StatusOK)
+	docs := c.do("GET", "/api/docs", "", http.StatusOK)
+	if strings.Contains(docs.Body.String(), "://") {
+		t.Error("the docs page loads files from another host")
+	}
+	c.do("GET", "/api/docs/swagger-ui.css", "", http.StatusOK)
+	gzipped := c.do("GET", "/api/docs/swagger-ui-bundle.js", "", http.StatusOK, "Accept-Encoding", "gzip")
+	if got := gzipped.Header().Get("Content-Encoding"); got != "gzip" {
+		t.Errorf("Content-Encoding %q, want gzip", got)
+	}
+	tag := gzipped.Header().Get("ETag")
+	c.do("GET", "/api/docs/swagger-ui-bundle.js", "", http.StatusNotModified, "If-None-Match", tag)
+	c.reject("GET", "/api/docs/missing.js", "", http.StatusNotFound)
+
+	c.reject("POST", "/api/forum/create", `{"title": "Forum"`, http.StatusBadRequest)
+	c.reject("POST", "/api/user/author/create", `{"fullname": "Author"}`, http.StatusBadRequest)
+	c.reject("POST", "/api/forum/forum/create", `{"title": "Thread", "author": "author", "message": "message", "slug": "42"}`, http.StatusBadRequest)
+	c.reject("GET", "/api/forum/forum/users?limit=0", "", http.StatusBadRequest)
+	c.reject("GET", "/api/thread/thread/posts?sort=random", "", http.StatusBadRequest)
+
+	c.do("POST", "/api/graphql", `{"query": "{ nope }"}`, http.StatusBadRequest)
+	c.do("GET", "/api/graphql?query=%7B", "", http.StatusBadRequest)
+}
+
+// TestContract runs every route on a test database.
+func TestContract(t *testing.T) {
+	c := newContract(t, psql.NewStorage(psqltest.Open(t)))
+
+	c.do("POST", "/api/service/clear", "", http.StatusOK)
+
+	user := `{"fullname": "Author", "about": "about", "email": "author@example.com"}`
+	c.do("POST", "/api/user/author/create", user, http.StatusCreated)
+	c.do("POST", "/api/user/author/create", user, http.StatusConflict)
+	profile := c.do("GET", "/api/user/author/profile", "", http.StatusOK)
+	c.do("GET", "/api/user/author/profile", "", http.StatusNotModified, "If-None-Match", profile.Header().Get("ETag"))
+	c.do("POST", "/api/user/author/profile", `{"about": "new"}`, http.StatusOK, "If-Match", profile.Header().Get("ETag"))
+	c.do("POST", "/api/user/author/profile", `{"about": "newer"}`, http.StatusPreconditionFailed, "If-Match", profile.Header().Get("ETag"))
+	c.do("GET", "/api/user/nobody/profile", "", http.StatusNotFound)
+
+	forum := `{"title": "Forum", "user": "author", "slug": "forum"}`
+	c.do("POST", "/api/forum/create", forum, http.StatusCreated)
+	c.do("POST", "/api/forum/create", forum, http.StatusConflict)
+	c.do("POST", "/api/forum/create", `{"title": "Other", "user": "author", "slug": "other"}`, http.StatusCreated)
+	c.do("POST", "/api/forum/create", `{"title": "Forum", "user": "nobody", "slug": "nobody"}`, http.StatusNotFound)
+	c.do("GET", "/api/forum/forum/details", "", http.StatusOK)
+	c.do("GET", "/api/forum/missing/details", "", http.StatusNotFound)
+
+	thread := `{"title": "Thread", "author": "author", "message": "message", "slug": "thread"}`
+	c.do("POST", "/api/forum/forum/create", thread, http.StatusCreated)
+	c.do("POST", "/api/forum/forum/create", thread, http.StatusConflict)
+	c.do("POST", "/api/forum/forum/create", `{"title": "Second", "author": "author", "message": "message", "slug": "second"}`, http.StatusCreated)
+	threads := c.do("GET", "/api/forum/forum/threads?limit=10&desc=true", "", http.StatusOK)
+	c.do("GET", "/api/forum/forum/threads?limit=10&desc=true", "", http.StatusNotModified, "If-None-Match", threads.Header().Get("ETag"))
+
+	var created []struct {
+		Id int `json:"id"`
+	}
+	rec := c.do("POST", "/api/thread/thread/create", `[{"author": "author", "message": "first"}]`, http.StatusCreated)
+	decodeBody(t, rec, &created)
+	first := strconv.Itoa(created[0].Id)
+	rec = c.do("POST", "/api/thread/thread/create", `[{"author": "author", "message": "reply", "parent": `+first+`}]`, http.StatusCreated)
+	decodeBody(t, rec, &created)
+	reply := strconv.Itoa(created[0].Id)
+	c.do("POST", "/api/thread/thread/create", `[{"author": "author", "message": "orphan", "parent": 1000000}]`, http.StatusConflict)
+	c.do("POST", "/api/thread/missing/create", `[{"author": "author", "message": "lost"}]`, http.StatusNotFound)
+
+	c.do("POST", "/api/thread/thread/vote", `{"nickname": "author", "voice": 1}`, http.StatusOK)
+	c.do("POST", "/api/thread/thread/vote", `{"nickname": "nobody", "voice": -1}`, http.StatusNotFound)
+	details := c.do("GET", "/api/thread/thread/details", "", http.StatusOK)
+	cached := c.do("GET", "/api/thread/thread/details", "", http.StatusNotModified, "If-None-Match", details.Header().Get("ETag"))
+	if vary := strings.Join(cached.Header().Values("Vary"), ","); !strings.Contains(vary, "Accept") {
+		t.Errorf("304 varies on %q, want Accept", vary)
+	}
+	c.do("POST", "/api/thread/thread/details", `{"message": "edited"}`, http.StatusOK, "If-Match", details.Header().Get("ETag"))
+	c.do("GET", "/api/thread/missing/details", "", http.StatusNotFound)
+	c.do("GET", "/api/thread/thread/posts?limit=10&sort=flat", "", http.StatusOK)
+	c.do("GET", "/api/thread/thread/posts?limit=10&sort=tree&desc=true", "", http.StatusOK)
+	flat := c.do("GET", "/api/thread/thread/posts?limit=1&sort=parent_tree", "", http.StatusOK)
+	nested := c.do("GET", "/api/thread/thread/posts?limit=1&sort=parent_tree&format=nested&max_depth=1", "", http.StatusOK, "If-None-Match", flat.Header().Get("ETag"))
+	c.do("GET", "/api/thread/thread/posts?limit=1&sort=parent_tree&format=nested&max_depth=2", "", http.StatusOK, "If-None-Match", nested.Header().Get("ETag"))
+
+	c.do("GET", "/api/forum/forum/users?limit=10", "", http.StatusOK)
+	c.do("GET", "/api/forum/missing/users", "", http.StatusNotFound)
+
+	post := c.do("GET", "/api/post/"+first+"/details?related=user,forum,thread", "", http.StatusOK)
+	c.do("GET", "/api/post/"+first+"/details", "", http.StatusOK)
+	c.do("POST", "/api/post/"+first+"/details", `{"message": "edited"}`, http.StatusOK)
+	c.do("POST", "/api/post/"+first+"/details", `{"message": "again"}`, http.StatusPreconditionFailed, "If-Match", post.Header().Get("ETag"))
+	c.do("GET", "/api/post/1000000/details", "", http.StatusNotFound)
+	c.do("GET", "/api/post/"+first+"/replies?limit=10&depth=2", "", http.StatusOK)
+	c.do("GET", "/api/post/"+reply+"/ancestors", "", http.StatusOK)
+	c.do("GET", "/api/post/1000000/ancestors", "", http.StatusNotFound)
+	c.do("POST", "/api/post/"+reply+"/split", `{"title": "Split", "slug": "split"}`, http.StatusCreated)
+	c.do("POST", "/api/post/1000000/split", `{"title": "Lost", "slug": "lost"}`, http.StatusNotFound)
+
+	c.do("POST", "/api/thread/second/move", `{"forum": "other", "stub": true}`, http.StatusOK)
+	c.do("POST", "/api/thread/second/move", `{"forum": "missing"}`, http.StatusNotFound)
+	c.do("POST", "/api/thread/split/merge", `{"target": "thread"}`, http.StatusOK)
+	c.do("POST", "/api/thread/thread/merge", `{"target": "thread"}`, http.StatusConflict)
+
+	c.do("POST", "/api/user/author/rename", `{"nickname": "writer"}`, http.StatusOK)
+	c.do("POST", "/api/user/nobody/rename", `{"nickname": "somebody"}`, http.StatusNotFound)
+
+	query := `{"query": "{ forum(slug: \"forum\") { title threads(limit: 10) { title voters(limit: 10) { voice } } } }"}`
+	c.do("POST", "/api/graphql", query, http.StatusOK)
+	c.do("GET", "/api/service/status", "", http.StatusOK)
+	c.do("GET", "/api/service/cache", "", http.StatusOK)
+}
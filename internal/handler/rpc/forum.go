@@ -0,0 +1,113 @@
+package rpc
+
+import (
+	"context"
+	"techpark_db/internal/apperr"
+	"techpark_db/internal/domain/entity"
+	"techpark_db/internal/domain/entity/pb"
+	"techpark_db/internal/handler"
+	"techpark_db/internal/service"
+)
+
+func (s *Server) ForumCreate(ctx context.Context, req *pb.CreateForum) (*pb.Forum, error) {
+	forumRequest := entity.CreateForumFromProto(req)
+	if err := check(&forumRequest); err != nil {
+		return nil, statusError(err)
+	}
+
+	forum, err := s.service.CreateForum(ctx, forumRequest)
+	if err == service.ErrExists {
+		return nil, existsError(ErrForumExists+forum.Slug, forum.Proto())
+	}
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return forum.Proto(), nil
+}
+
+func (s *Server) ForumDetails(ctx context.Context, req *pb.ForumRequest) (*pb.Forum, error) {
+	forum, err := s.reader(ctx).GetForum(nil, req.GetSlug())
+	if err != nil {
+		return nil, statusError(apperr.New(apperr.CodeForumNotFound, service.ErrNoForum+req.GetSlug()))
+	}
+	return forum.Proto(), nil
+}
+
+func (s *Server) ForumCreateThread(ctx context.Context, req *pb.ForumCreateThreadRequest) (*pb.Thread, error) {
+	threadRequest := entity.CreateThreadFromProto(req.GetThread())
+	if err := check(&threadRequest); err != nil {
+		return nil, statusError(err)
+	}
+
+	thread, err := s.service.CreateThread(ctx, req.GetSlug(), threadRequest)
+	if err == service.ErrExists {
+		return nil, existsError(ErrThreadExists+thread.Slug, thread.Proto())
+	}
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return thread.Proto(), nil
+}
+
+func (s *Server) ForumUsers(ctx context.Context, req *pb.ForumUsersRequest) (*pb.Users, error) {
+	store := s.reader(ctx)
+	slug := req.GetSlug()
+
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = handler.DEFAULT_LIMIT
+	}
+	q := &query{}
+	q.check("limit", limit, s.limitRules())
+	q.check("since", req.GetSince(), "slug")
+	if err := q.Err(); err != nil {
+		return nil, statusError(err)
+	}
+
+	users, err := store.GetForumUsers(nil, slug, order(req.GetDesc()), limit, req.GetSince())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	if len(*users) == 0 {
+		if _, err := store.GetForum(nil, slug); err != nil {
+			return nil, statusError(apperr.New(apperr.CodeForumNotFound, service.ErrNoForum+slug))
+		}
+	}
+	return entity.Users(*users).Proto(), nil
+}
+
+func (s *Server) ForumThreads(ctx context.Context, req *pb.ForumThreadsRequest) (*pb.Threads, error) {
+	store := s.reader(ctx)
+	slug := req.GetSlug()
+
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = handler.DEFAULT_LIMIT
+	}
+	since := req.GetSince()
+	if since == "" {
+		since = handler.DEFAULT_SINCE_DATA_MIN
+		if req.GetDesc() {
+			since = handler.DEFAULT_SINCE_DATA_MAX
+		}
+	}
+	q := &query{}
+	q.check("limit", limit, s.limitRules())
+	q.check("since", since, "datetime")
+	if err := q.Err(); err != nil {
+		return nil, statusError(err)
+	}
+
+	threads, err := store.GetForumThreads(nil, slug, order(req.GetDesc()), limit, since)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	if len(*threads) == 0 {
+		if _, err := store.GetForum(nil, slug); err != nil {
+			return nil, statusError(apperr.New(apperr.CodeForumNotFound, service.ErrNoForum+slug))
+		}
+	}
+	return entity.Threads(*threads).Proto(), nil
+}
@@ -0,0 +1,183 @@
+package rpc
+
+import (
+	"context"
+	"crypto/sha256"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"techpark_db/internal/apperr"
+	"techpark_db/internal/domain/entity/pb"
+	mw "techpark_db/internal/handler/middleware"
+	"techpark_db/internal/infra/psql"
+)
+
+// The metadata of the REST headers with the same names.
+var (
+	IDEMPOTENCY_KEY_METADATA      = strings.ToLower(mw.IDEMPOTENCY_KEY_HEADER)
+	IDEMPOTENCY_REPLAYED_METADATA = strings.ToLower(mw.IDEMPOTENCY_REPLAYED_HEADER)
+	RETRY_AFTER_METADATA          = "retry-after"
+)
+
+// IDEMPOTENT_CONTENT_TYPE marks the stored responses of gRPC calls,
+// which are the response messages in the protobuf wire format.
+const IDEMPOTENT_CONTENT_TYPE = "application/grpc+proto"
+
+// routes are the REST routes the calls mirror. A call is limited by the
+// policy of its route and takes its tokens from the same bucket, so a client
+// has one budget on both APIs.
+var routes = map[string]string{
+	pb.ForumAPI_ForumCreate_FullMethodName:       "POST /api/forum/create",
+	pb.ForumAPI_ForumDetails_FullMethodName:      "GET /api/forum/{slug}/details",
+	pb.ForumAPI_ForumCreateThread_FullMethodName: "POST /api/forum/{slug}/create",
+	pb.ForumAPI_ForumUsers_FullMethodName:        "GET /api/forum/{slug}/users",
+	pb.ForumAPI_ForumThreads_FullMethodName:      "GET /api/forum/{slug}/threads",
+	pb.ForumAPI_ThreadCreatePosts_FullMethodName: "POST /api/thread/{slug_or_id}/create",
+	pb.ForumAPI_ThreadVote_FullMethodName:        "POST /api/thread/{slug_or_id}/vote",
+	pb.ForumAPI_ThreadDetails_FullMethodName:     "GET /api/thread/{slug_or_id}/details",
+	pb.ForumAPI_ThreadUpdate_FullMethodName:      "POST /api/thread/{slug_or_id}/details",
+	pb.ForumAPI_ThreadPosts_FullMethodName:       "GET /api/thread/{slug_or_id}/posts",
+	pb.ForumAPI_ThreadMove_FullMethodName:        "POST /api/thread/{slug_or_id}/move",
+	pb.ForumAPI_ThreadMerge_FullMethodName:       "POST /api/thread/{slug_or_id}/merge",
+	pb.ForumAPI_PostGet_FullMethodName:           "GET /api/post/{id}/details",
+	pb.ForumAPI_PostUpdate_FullMethodName:        "POST /api/post/{id}/details",
+	pb.ForumAPI_PostSplit_FullMethodName:         "POST /api/post/{id}/split",
+	pb.ForumAPI_PostReplies_FullMethodName:       "GET /api/post/{id}/replies",
+	pb.ForumAPI_PostAncestors_FullMethodName:     "GET /api/post/{id}/ancestors",
+	pb.ForumAPI_UserCreate_FullMethodName:        "POST /api/user/{nickname}/create",
+	pb.ForumAPI_UserDetails_FullMethodName:       "GET /api/user/{nickname}/profile",
+	pb.ForumAPI_UserUpdate_FullMethodName:        "POST /api/user/{nickname}/profile",
+	pb.ForumAPI_UserRename_FullMethodName:        "POST /api/user/{nickname}/rename",
+	pb.ForumAPI_ServiceStatus_FullMethodName:     "GET /api/service/status",
+	pb.ForumAPI_ServiceClear_FullMethodName:      "POST /api/service/clear",
+	pb.ForumAPI_ServiceCache_FullMethodName:      "GET /api/service/cache",
+}
+
+func (s *Server) rateLimitInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (interface{}, error) {
+	if err := s.allow(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return next(ctx, req)
+}
+
+func (s *Server) rateLimitStreamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, next grpc.StreamHandler) error {
+	if err := s.allow(stream.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return next(srv, stream)
+}
+
+// allow takes a token for the call like the RateLimit middleware.
+func (s *Server) allow(ctx context.Context, method string) error {
+	res, ok := s.rateLimit.Allow(peerIP(ctx), routes[method])
+	if !ok || res.Allowed {
+		return nil
+	}
+	retryAfter := strconv.Itoa(int(math.Ceil(res.RetryAfter.Seconds())))
+	grpc.SetHeader(ctx, metadata.Pairs(RETRY_AFTER_METADATA, retryAfter))
+	return statusError(apperr.New(apperr.CodeRateLimited, mw.ErrRateLimited))
+}
+
+// peerIP is the address of the client, proxies are not trusted like in
+// the REST API.
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// idempotencyInterceptor makes the write calls carrying the
+// "idempotency-key" metadata safe to retry like the Idempotency middleware
+// does for POST requests. Keys are shared with the REST API calls of the same
+// client IP, a key used for a request of the other API is reused. Only responses are stored, a call
+// which failed runs again on retry.
+func (s *Server) idempotencyInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (interface{}, error) {
+	key := incoming(ctx, IDEMPOTENCY_KEY_METADATA)
+	if key == "" || !writeMethods[info.FullMethod] {
+		return next(ctx, req)
+	}
+	if len(key) > mw.IDEMPOTENCY_KEY_MAX_LEN {
+		return nil, statusError(apperr.New(apperr.CodeBadRequest, mw.ErrKeyTooLong))
+	}
+
+	hash, err := callHash(info.FullMethod, req)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	client := peerIP(ctx)
+	stored, leaseUntil, err := s.idempotency.Claim(client, key, hash)
+	switch {
+	case err == psql.ErrIdempotencyKeyReused:
+		return nil, statusError(apperr.New(apperr.CodeKeyReused, mw.ErrKeyReused))
+	case err == psql.ErrIdempotencyKeyInProgress:
+		return nil, statusError(apperr.New(apperr.CodeKeyInProgress, mw.ErrKeyInProgress))
+	case err != nil:
+		return nil, statusError(err)
+	case stored != nil:
+		return replay(ctx, info.FullMethod, stored)
+	}
+
+	resp, err := next(ctx, req)
+	if err != nil {
+		s.idempotency.Release(client, key, leaseUntil)
+		return resp, err
+	}
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(resp.(proto.Message))
+	if err != nil {
+		s.idempotency.Release(client, key, leaseUntil)
+		return resp, nil
+	}
+	s.idempotency.Save(client, key, leaseUntil, psql.IdempotentResponse{
+		Status:      http.StatusOK,
+		ContentType: IDEMPOTENT_CONTENT_TYPE,
+		Body:        body,
+	})
+	return resp, nil
+}
+
+func callHash(method string, req interface{}) ([]byte, error) {
+	body, err := proto.MarshalOptions{Deterministic: true}.Marshal(req.(proto.Message))
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.New()
+	h.Write([]byte(method + "\n"))
+	h.Write(body)
+	return h.Sum(nil), nil
+}
+
+// replay decodes the stored response into the response message of method.
+func replay(ctx context.Context, method string, stored *psql.IdempotentResponse) (interface{}, error) {
+	if stored.ContentType != IDEMPOTENT_CONTENT_TYPE {
+		return nil, statusError(apperr.New(apperr.CodeKeyReused, mw.ErrKeyReused))
+	}
+	name := protoreflect.FullName(strings.Replace(strings.TrimPrefix(method, "/"), "/", ".", 1))
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	output, err := protoregistry.GlobalTypes.FindMessageByName(desc.(protoreflect.MethodDescriptor).Output().FullName())
+	if err != nil {
+		return nil, statusError(err)
+	}
+	resp := output.New().Interface()
+	if err := proto.Unmarshal(stored.Body, resp); err != nil {
+		return nil, statusError(err)
+	}
+	grpc.SetHeader(ctx, metadata.Pairs(IDEMPOTENCY_REPLAYED_METADATA, "true"))
+	return resp, nil
+}
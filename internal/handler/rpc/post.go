@@ -0,0 +1,184 @@
+package rpc
+
+import (
+	"context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strconv"
+	"strings"
+	"techpark_db/internal/apperr"
+	"techpark_db/internal/domain/entity"
+	"techpark_db/internal/domain/entity/pb"
+	"techpark_db/internal/handler"
+	"techpark_db/internal/infra/feed"
+	"techpark_db/internal/service"
+)
+
+var ErrNoFeed = "Post feed is not enabled on this server"
+var ErrFeedBehind = "Post feed client fell behind, subscribe again"
+
+func (s *Server) PostGet(ctx context.Context, req *pb.PostGetRequest) (*pb.PostDetails, error) {
+	store := s.reader(ctx)
+	id := int(req.GetId())
+	idRaw := strconv.Itoa(id)
+
+	q := &query{}
+	for _, arg := range req.GetRelated() {
+		q.check("related", arg, "oneof=user forum thread")
+	}
+	if err := q.Err(); err != nil {
+		return nil, statusError(err)
+	}
+
+	post, err := store.GetPostById(nil, id)
+	if err != nil {
+		return nil, statusError(apperr.New(apperr.CodePostNotFound, service.ErrNoPost+idRaw))
+	}
+
+	var postDetails entity.PostDetails
+	postDetails.DPost = post
+
+	for _, arg := range req.GetRelated() {
+		switch arg {
+		case "user":
+			author, err := store.GetUser(nil, post.Author)
+			if err != nil {
+				return nil, statusError(err)
+			}
+			postDetails.DAuthor = author
+		case "forum":
+			forum, err := store.GetForum(nil, post.Forum)
+			if err != nil {
+				return nil, statusError(err)
+			}
+			postDetails.DForum = forum
+		case "thread":
+			thread, err := store.GetThreadById(nil, post.Thread)
+			if err != nil {
+				return nil, statusError(err)
+			}
+			postDetails.DThread = thread
+		}
+	}
+
+	// the related rows have ETags of their own
+	if len(req.GetRelated()) == 0 {
+		setETag(ctx, service.ETag(post.Version))
+	}
+	return postDetails.Proto(), nil
+}
+
+func (s *Server) PostUpdate(ctx context.Context, req *pb.PostUpdateRequest) (*pb.Post, error) {
+	postRequest := entity.UpdatePostFromProto(req.GetPost())
+	if err := check(&postRequest); err != nil {
+		return nil, statusError(err)
+	}
+
+	post, _, err := s.service.UpdatePost(ctx, int(req.GetId()), postRequest, incoming(ctx, IF_MATCH_METADATA))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	setETag(ctx, service.ETag(post.Version))
+	return post.Proto(), nil
+}
+
+func (s *Server) PostSplit(ctx context.Context, req *pb.PostSplitRequest) (*pb.Thread, error) {
+	splitReq := entity.SplitPostFromProto(req.GetSplit())
+	if err := check(&splitReq); err != nil {
+		return nil, statusError(err)
+	}
+
+	thread, err := s.service.SplitPost(ctx, int(req.GetId()), splitReq)
+	if err == service.ErrExists {
+		return nil, existsError(ErrThreadExists+thread.Slug, thread.Proto())
+	}
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return thread.Proto(), nil
+}
+
+func (s *Server) PostReplies(ctx context.Context, req *pb.PostRepliesRequest) (*pb.Posts, error) {
+	store := s.reader(ctx)
+	id := int(req.GetId())
+
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = handler.DEFAULT_LIMIT
+	}
+	depth := int(req.GetDepth())
+	if depth == 0 {
+		depth = handler.DEFAULT_DEPTH
+	}
+	sort := req.GetSort()
+	if sort == "" {
+		sort = handler.DEFAULT_REPLY_SORT
+	}
+	q := &query{}
+	q.check("limit", limit, s.limitRules())
+	q.check("depth", depth, "min=1")
+	q.check("sort", sort, "oneof=tree flat")
+	if err := q.Err(); err != nil {
+		return nil, statusError(err)
+	}
+
+	posts, err := store.GetPostReplies(nil, id, depth, limit, sort, order(req.GetDesc()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	if len(*posts) == 0 {
+		if _, err := store.GetPostById(nil, id); err != nil {
+			return nil, statusError(apperr.New(apperr.CodePostNotFound, service.ErrNoPost+strconv.Itoa(id)))
+		}
+	}
+	return entity.Posts(*posts).Proto(), nil
+}
+
+func (s *Server) PostAncestors(ctx context.Context, req *pb.PostRequest) (*pb.Posts, error) {
+	store := s.reader(ctx)
+	id := int(req.GetId())
+
+	posts, err := store.GetPostAncestors(nil, id)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	if len(*posts) == 0 {
+		if _, err := store.GetPostById(nil, id); err != nil {
+			return nil, statusError(apperr.New(apperr.CodePostNotFound, service.ErrNoPost+strconv.Itoa(id)))
+		}
+	}
+	return entity.Posts(*posts).Proto(), nil
+}
+
+// PostFeed sends the posts created on any instance until the client leaves.
+// A client which reads slower than posts are created is cut off with
+// RESOURCE_EXHAUSTED.
+func (s *Server) PostFeed(req *pb.PostFeedRequest, stream pb.ForumAPI_PostFeedServer) error {
+	postFeed := s.storage.PostFeed()
+	if postFeed == nil {
+		return status.Error(codes.Unavailable, ErrNoFeed)
+	}
+
+	forum, thread := req.GetForum(), int(req.GetThread())
+	sub := postFeed.Subscribe(feed.DEFAULT_BUFFER, func(post *entity.Post) bool {
+		return (forum == "" || strings.EqualFold(post.Forum, forum)) && (thread == 0 || post.Thread == thread)
+	})
+	defer sub.Close()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case post, ok := <-sub.C:
+			if !ok {
+				return status.Error(codes.ResourceExhausted, ErrFeedBehind)
+			}
+			if err := stream.Send(post.Proto()); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package rpc
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"strconv"
+	"strings"
+	"techpark_db/internal/apperr"
+	"techpark_db/internal/domain/entity/pb"
+	"techpark_db/internal/domain/validate"
+	"techpark_db/internal/handler"
+	mw "techpark_db/internal/handler/middleware"
+	"techpark_db/internal/infra/psql"
+	"techpark_db/internal/service"
+	"time"
+)
+
+const (
+	ETAG_METADATA     = "etag"
+	IF_MATCH_METADATA = "if-match"
+)
+
+// READ_PRIMARY_METADATA carries the read-your-writes deadline like the
+// X-Read-Primary header of the REST API.
+var READ_PRIMARY_METADATA = strings.ToLower(mw.READ_PRIMARY_HEADER)
+
+var ErrForumExists = "Forum already exists: "
+var ErrThreadExists = "Thread already exists: "
+var ErrUserExists = "User already exists: "
+
+var grpcCodes = map[apperr.Code]codes.Code{
+	apperr.CodeBadRequest:     codes.InvalidArgument,
+	apperr.CodeInvalidBody:    codes.InvalidArgument,
+	apperr.CodeInvalidFields:  codes.InvalidArgument,
+	apperr.CodeInvalidQuery:   codes.InvalidArgument,
+	apperr.CodeUserNotFound:   codes.NotFound,
+	apperr.CodeForumNotFound:  codes.NotFound,
+	apperr.CodeThreadNotFound: codes.NotFound,
+	apperr.CodePostNotFound:   codes.NotFound,
+	apperr.CodeParentNotFound: codes.FailedPrecondition,
+	apperr.CodeEmailTaken:     codes.AlreadyExists,
+	apperr.CodeNicknameTaken:  codes.AlreadyExists,
+	apperr.CodeSameThread:     codes.FailedPrecondition,
+	apperr.CodeVoteRejected:   codes.FailedPrecondition,
+	apperr.CodeRateLimited:    codes.ResourceExhausted,
+	apperr.CodeKeyReused:      codes.FailedPrecondition,
+	apperr.CodeKeyInProgress:  codes.Aborted,
+	apperr.CodeVersionChanged: codes.FailedPrecondition,
+	apperr.CodeExists:         codes.AlreadyExists,
+	apperr.CodeInternal:       codes.Internal,
+}
+
+// writeMethods are the RPCs after which the client reads from the primary
+// for a while, like after the POST routes.
+var writeMethods = map[string]bool{
+	pb.ForumAPI_ForumCreate_FullMethodName:       true,
+	pb.ForumAPI_ForumCreateThread_FullMethodName: true,
+	pb.ForumAPI_ThreadCreatePosts_FullMethodName: true,
+	pb.ForumAPI_ThreadVote_FullMethodName:        true,
+	pb.ForumAPI_ThreadUpdate_FullMethodName:      true,
+	pb.ForumAPI_ThreadMove_FullMethodName:        true,
+	pb.ForumAPI_ThreadMerge_FullMethodName:       true,
+	pb.ForumAPI_PostUpdate_FullMethodName:        true,
+	pb.ForumAPI_PostSplit_FullMethodName:         true,
+	pb.ForumAPI_UserCreate_FullMethodName:        true,
+	pb.ForumAPI_UserUpdate_FullMethodName:        true,
+	pb.ForumAPI_UserRename_FullMethodName:        true,
+	pb.ForumAPI_ServiceClear_FullMethodName:      true,
+}
+
+// Server implements the ForumAPI gRPC service on the storage and the
+// service the REST handlers use. Errors carry the Problem of the REST API
+// in their details.
+type Server struct {
+	pb.UnimplementedForumAPIServer
+
+	storage     *psql.Storage
+	service     *service.Service
+	maxLimit    int
+	rateLimit   *mw.RateLimit
+	idempotency *mw.Idempotency
+}
+
+func NewServer(store *psql.Storage) *Server {
+	return &Server{
+		storage:  store,
+		service:  service.NewService(store),
+		maxLimit: handler.DEFAULT_MAX_LIMIT,
+	}
+}
+
+// SetMaxLimit bounds the limit of list requests like Handler.SetMaxLimit.
+func (s *Server) SetMaxLimit(limit int) {
+	s.maxLimit = limit
+}
+
+// SetRateLimit limits the calls with the policies of the REST routes they
+// mirror, see routes.
+func (s *Server) SetRateLimit(rateLimit *mw.RateLimit) {
+	s.rateLimit = rateLimit
+}
+
+// SetIdempotency stores the responses of write calls with an idempotency key
+// where the REST API stores its responses.
+func (s *Server) SetIdempotency(idempotency *mw.Idempotency) {
+	s.idempotency = idempotency
+}
+
+// NewGRPCServer returns a gRPC server serving s. The interceptors run in
+// the order of the REST middlewares.
+func NewGRPCServer(s *Server, opts ...grpc.ServerOption) *grpc.Server {
+	unary := make([]grpc.UnaryServerInterceptor, 0)
+	if s.rateLimit != nil {
+		unary = append(unary, s.rateLimitInterceptor)
+		opts = append(opts, grpc.ChainStreamInterceptor(s.rateLimitStreamInterceptor))
+	}
+	unary = append(unary, readYourWritesInterceptor)
+	if s.idempotency != nil {
+		unary = append(unary, s.idempotencyInterceptor)
+	}
+	opts = append(opts, grpc.ChainUnaryInterceptor(unary...))
+	server := grpc.NewServer(opts...)
+	pb.RegisterForumAPIServer(server, s)
+	return server
+}
+
+// readYourWritesInterceptor sends the read-your-writes deadline after
+// a successful write.
+func readYourWritesInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, next grpc.UnaryHandler) (interface{}, error) {
+	resp, err := next(ctx, req)
+	if err == nil && writeMethods[info.FullMethod] {
+		deadline := time.Now().Add(mw.READ_PRIMARY_TTL).UnixNano() / int64(time.Millisecond)
+		grpc.SetHeader(ctx, metadata.Pairs(READ_PRIMARY_METADATA, strconv.FormatInt(deadline, 10)))
+	}
+	return resp, err
+}
+
+// reader returns the storage for the reads of a call, which goes to
+// the primary DB until the read-your-writes deadline the client repeats.
+func (s *Server) reader(ctx context.Context) *psql.Storage {
+	deadline, err := strconv.ParseInt(incoming(ctx, READ_PRIMARY_METADATA), 10, 64)
+	if err == nil && time.Now().UnixNano()/int64(time.Millisecond) < deadline {
+		return s.storage.Primary()
+	}
+	return s.storage
+}
+
+func (s *Server) limitRules() string {
+	return "min=1,max=" + strconv.Itoa(s.maxLimit)
+}
+
+func incoming(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return strings.Join(values, ",")
+}
+
+func setETag(ctx context.Context, tag string) {
+	grpc.SetHeader(ctx, metadata.Pairs(ETAG_METADATA, tag))
+}
+
+// check validates a request entity like the decoding of REST bodies.
+func check(v interface{}) error {
+	if errs := validate.Struct(v); errs != nil {
+		return apperr.Invalid(apperr.CodeInvalidFields, handler.ErrInvalidFields, errs)
+	}
+	return nil
+}
+
+// query collects the invalid parameters of a list request.
+type query struct {
+	errors validate.Errors
+}
+
+func (q *query) check(name string, value interface{}, rules string) {
+	q.errors = append(q.errors, validate.Var(name, value, rules)...)
+}
+
+func (q *query) Err() error {
+	if len(q.errors) == 0 {
+		return nil
+	}
+	return apperr.Invalid(apperr.CodeInvalidQuery, handler.ErrInvalidQuery, q.errors)
+}
+
+func order(desc bool) string {
+	if desc {
+		return "DESC"
+	}
+	return handler.DEFAULT_ORDER
+}
+
+// statusError turns err into a status with the Problem in its details.
+func statusError(err error) error {
+	problem := apperr.ProblemOf(err)
+	code, ok := grpcCodes[apperr.Code(problem.Code)]
+	if !ok {
+		code = codes.Internal
+	}
+	return withDetails(status.New(code, problem.Detail), problem.Proto())
+}
+
+// existsError is the ALREADY_EXISTS status holding the existing entity
+// next to the Problem.
+func existsError(detail string, existing protoadapt.MessageV1) error {
+	problem := apperr.ProblemOf(apperr.New(apperr.CodeExists, detail))
+	return withDetails(status.New(codes.AlreadyExists, detail), existing, problem.Proto())
+}
+
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) error {
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
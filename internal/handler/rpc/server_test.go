@@ -0,0 +1,189 @@
+package rpc
+
+import (
+	"context"
+	"github.com/gorilla/mux"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"techpark_db/internal/domain/entity/pb"
+	mw "techpark_db/internal/handler/middleware"
+	"techpark_db/internal/infra/psql"
+	"techpark_db/internal/infra/psql/psqltest"
+	"techpark_db/internal/infra/ratelimit"
+	"testing"
+	"time"
+)
+
+const BUFCONN_SIZE = 1 << 20
+
+// dial serves s on an in-memory listener and returns its client. The peer
+// address of the calls is "bufconn".
+func dial(t *testing.T, s *Server) pb.ForumAPIClient {
+	t.Helper()
+	listener := bufconn.Listen(BUFCONN_SIZE)
+	server := NewGRPCServer(s)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pb.NewForumAPIClient(conn)
+}
+
+// testServer serves a cleared test database.
+func testServer(t *testing.T) (*Server, *psql.Storage) {
+	t.Helper()
+	store := psql.NewStorage(psqltest.Open(t))
+	if err := store.ClearData(); err != nil {
+		t.Fatal(err)
+	}
+	return NewServer(store), store
+}
+
+func TestRateLimitSharesRESTBuckets(t *testing.T) {
+	route := "POST /api/thread/{slug_or_id}/vote"
+	rateLimit := mw.NewRateLimit(ratelimit.NewMemory(), map[string]ratelimit.Policy{
+		route: {Rate: 0.001, Burst: 1},
+	})
+
+	router := mux.NewRouter()
+	api := router.PathPrefix("/api").Subrouter()
+	api.HandleFunc("/thread/{slug_or_id:[A-Za-z0-9._-]+}/vote", func(w http.ResponseWriter, r *http.Request) {}).Methods("POST")
+	api.Use(rateLimit.Middleware)
+
+	// the REST request of the same client takes the only token
+	req := httptest.NewRequest(http.MethodPost, "/api/thread/slug/vote", nil)
+	req.RemoteAddr = "bufconn:1"
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("REST status %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	// the call is rejected before it reaches the storage
+	s := NewServer(psql.NewStorage(nil))
+	s.SetRateLimit(rateLimit)
+	client := dial(t, s)
+
+	var header metadata.MD
+	_, err := client.ThreadVote(context.Background(), &pb.ThreadVoteRequest{
+		SlugOrId: "slug",
+		Vote:     &pb.Vote{Nickname: "bench", Voice: 1},
+	}, grpc.Header(&header))
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("vote: %v, want %v", err, codes.ResourceExhausted)
+	}
+	if len(header.Get(RETRY_AFTER_METADATA)) == 0 {
+		t.Error("no retry-after metadata")
+	}
+}
+
+func TestPostFeedOff(t *testing.T) {
+	client := dial(t, NewServer(psql.NewStorage(nil)))
+
+	stream, err := client.PostFeed(context.Background(), &pb.PostFeedRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); status.Code(err) != codes.Unavailable {
+		t.Fatalf("feed: %v, want %v", err, codes.Unavailable)
+	}
+}
+
+func TestIdempotentCall(t *testing.T) {
+	s, store := testServer(t)
+	s.SetIdempotency(mw.NewIdempotency(store, time.Hour, time.Minute))
+	client := dial(t, s)
+	ctx := context.Background()
+
+	_, err := client.UserCreate(ctx, &pb.UserCreateRequest{
+		Nickname: "author",
+		User:     &pb.CreateUser{Fullname: "Author", Email: "author@example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyed := metadata.AppendToOutgoingContext(ctx, IDEMPOTENCY_KEY_METADATA, "forum-1")
+	req := &pb.CreateForum{Title: "Forum", User: "author", Slug: "forum-1"}
+	first, err := client.ForumCreate(keyed, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the repeat gets the stored forum instead of ALREADY_EXISTS
+	var header metadata.MD
+	repeat, err := client.ForumCreate(keyed, req, grpc.Header(&header))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repeat.GetSlug() != first.GetSlug() || repeat.GetUser() != first.GetUser() {
+		t.Errorf("replayed %v, want %v", repeat, first)
+	}
+	if got := header.Get(IDEMPOTENCY_REPLAYED_METADATA); len(got) == 0 || got[0] != "true" {
+		t.Errorf("replayed metadata %v", got)
+	}
+
+	other := &pb.CreateForum{Title: "Other", User: "author", Slug: "forum-2"}
+	if _, err := client.ForumCreate(keyed, other); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("reused key: %v, want %v", err, codes.FailedPrecondition)
+	}
+	if _, err := client.ForumCreate(ctx, req); status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("without key: %v, want %v", err, codes.AlreadyExists)
+	}
+}
+
+func TestThreadCreatePostsCurrentNickname(t *testing.T) {
+	s, _ := testServer(t)
+	client := dial(t, s)
+	ctx := context.Background()
+
+	_, err := client.UserCreate(ctx, &pb.UserCreateRequest{
+		Nickname: "old",
+		User:     &pb.CreateUser{Fullname: "Author", Email: "author@example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.ForumCreate(ctx, &pb.CreateForum{Title: "Forum", User: "old", Slug: "forum"}); err != nil {
+		t.Fatal(err)
+	}
+	thread, err := client.ForumCreateThread(ctx, &pb.ForumCreateThreadRequest{
+		Slug:   "forum",
+		Thread: &pb.CreateThread{Title: "Thread", Author: "old", Message: "message"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.UserRename(ctx, &pb.UserRenameRequest{Nickname: "old", Rename: &pb.RenameUser{Nickname: "new"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the old nickname resolves, the post is saved under the new one
+	posts, err := client.ThreadCreatePosts(ctx, &pb.ThreadCreatePostsRequest{
+		SlugOrId: strconv.FormatInt(thread.GetId(), 10),
+		Posts:    []*pb.CreatePost{{Author: "old", Message: "post"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := posts.GetPosts()[0]; got.GetAuthor() != "new" || got.GetThread() != thread.GetId() {
+		t.Errorf("post by %q in thread %d, want by %q in thread %d", got.GetAuthor(), got.GetThread(), "new", thread.GetId())
+	}
+}
@@ -0,0 +1,36 @@
+package rpc
+
+import (
+	"context"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"techpark_db/internal/domain/entity/pb"
+)
+
+func (s *Server) ServiceStatus(ctx context.Context, _ *emptypb.Empty) (*pb.ServStatus, error) {
+	tx, err := s.storage.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	servStatus, err := s.storage.GetServiceStatus(tx)
+	if err != nil {
+		tx.Rollback()
+		return nil, statusError(err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, statusError(err)
+	}
+	return servStatus.Proto(), nil
+}
+
+func (s *Server) ServiceClear(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	if err := s.storage.ClearData(); err != nil {
+		return nil, statusError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *Server) ServiceCache(ctx context.Context, _ *emptypb.Empty) (*pb.CacheStatus, error) {
+	return s.storage.CacheStatus().Proto(), nil
+}
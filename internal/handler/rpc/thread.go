@@ -0,0 +1,134 @@
+package rpc
+
+import (
+	"context"
+	"strconv"
+	"techpark_db/internal/apperr"
+	"techpark_db/internal/domain/entity"
+	"techpark_db/internal/domain/entity/pb"
+	"techpark_db/internal/handler"
+	"techpark_db/internal/service"
+)
+
+func (s *Server) ThreadCreatePosts(ctx context.Context, req *pb.ThreadCreatePostsRequest) (*pb.Posts, error) {
+	postReq := entity.CreatePostsFromProto(&pb.CreatePosts{Posts: req.GetPosts()})
+	if err := check(postReq); err != nil {
+		return nil, statusError(err)
+	}
+
+	posts, err := s.service.CreatePosts(ctx, req.GetSlugOrId(), postReq)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return posts.Proto(), nil
+}
+
+func (s *Server) ThreadVote(ctx context.Context, req *pb.ThreadVoteRequest) (*pb.Thread, error) {
+	voteReq := entity.VoteFromProto(req.GetVote())
+	if err := check(&voteReq); err != nil {
+		return nil, statusError(err)
+	}
+
+	thread, err := s.service.Vote(ctx, req.GetSlugOrId(), voteReq)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return thread.Proto(), nil
+}
+
+func (s *Server) ThreadDetails(ctx context.Context, req *pb.ThreadRequest) (*pb.Thread, error) {
+	thread, err := s.reader(ctx).GetThread(nil, req.GetSlugOrId())
+	if err != nil {
+		return nil, statusError(apperr.New(apperr.CodeThreadNotFound, service.ErrNoThread+req.GetSlugOrId()))
+	}
+
+	setETag(ctx, service.ETag(thread.Version))
+	return thread.Proto(), nil
+}
+
+func (s *Server) ThreadUpdate(ctx context.Context, req *pb.ThreadUpdateRequest) (*pb.Thread, error) {
+	threadReq := entity.ThreadFromProto(req.GetThread())
+	if err := check(&threadReq); err != nil {
+		return nil, statusError(err)
+	}
+
+	thread, err := s.service.UpdateThread(ctx, req.GetSlugOrId(), threadReq, incoming(ctx, IF_MATCH_METADATA))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	setETag(ctx, service.ETag(thread.Version))
+	return thread.Proto(), nil
+}
+
+func (s *Server) ThreadPosts(req *pb.ThreadPostsRequest, stream pb.ForumAPI_ThreadPostsServer) error {
+	store := s.reader(stream.Context())
+	slug_or_id := req.GetSlugOrId()
+
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = handler.DEFAULT_LIMIT
+	}
+	sort := req.GetSort()
+	if sort == "" {
+		sort = handler.DEFAUTL_SORT
+	}
+	q := &query{}
+	q.check("limit", limit, s.limitRules())
+	q.check("since", int(req.GetSince()), "min=0")
+	q.check("sort", sort, "oneof=flat tree parent_tree")
+	if err := q.Err(); err != nil {
+		return statusError(err)
+	}
+
+	id, err := strconv.Atoi(slug_or_id)
+	if err != nil {
+		thread, err := store.GetThread(nil, slug_or_id)
+		if err != nil {
+			return statusError(apperr.New(apperr.CodeThreadNotFound, service.ErrNoThread+slug_or_id))
+		}
+		id = thread.Id
+	}
+
+	count := 0
+	err = store.StreamThreadPosts(nil, id, limit, int(req.GetSince()), sort, order(req.GetDesc()), func(post *entity.Post) error {
+		count++
+		return stream.Send(post.Proto())
+	})
+	if err != nil {
+		return statusError(err)
+	}
+
+	if count == 0 {
+		if _, err := store.GetThread(nil, slug_or_id); err != nil {
+			return statusError(apperr.New(apperr.CodeThreadNotFound, service.ErrNoThread+slug_or_id))
+		}
+	}
+	return nil
+}
+
+func (s *Server) ThreadMove(ctx context.Context, req *pb.ThreadMoveRequest) (*pb.Thread, error) {
+	moveReq := entity.MoveThreadFromProto(req.GetMove())
+	if err := check(&moveReq); err != nil {
+		return nil, statusError(err)
+	}
+
+	thread, err := s.service.MoveThread(ctx, req.GetSlugOrId(), moveReq)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return thread.Proto(), nil
+}
+
+func (s *Server) ThreadMerge(ctx context.Context, req *pb.ThreadMergeRequest) (*pb.Thread, error) {
+	mergeReq := entity.MergeThreadFromProto(req.GetMerge())
+	if err := check(&mergeReq); err != nil {
+		return nil, statusError(err)
+	}
+
+	target, err := s.service.MergeThreads(ctx, req.GetSlugOrId(), mergeReq)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return target.Proto(), nil
+}
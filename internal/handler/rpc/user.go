@@ -0,0 +1,64 @@
+package rpc
+
+import (
+	"context"
+	"techpark_db/internal/apperr"
+	"techpark_db/internal/domain/entity"
+	"techpark_db/internal/domain/entity/pb"
+	"techpark_db/internal/service"
+)
+
+func (s *Server) UserCreate(ctx context.Context, req *pb.UserCreateRequest) (*pb.User, error) {
+	nickname := req.GetNickname()
+	userReq := entity.CreateUserFromProto(req.GetUser())
+	if err := check(&userReq); err != nil {
+		return nil, statusError(err)
+	}
+
+	user, users, err := s.service.CreateUser(ctx, nickname, userReq)
+	if err == service.ErrExists {
+		return nil, existsError(ErrUserExists+nickname, users.Proto())
+	}
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return user.Proto(), nil
+}
+
+func (s *Server) UserDetails(ctx context.Context, req *pb.UserRequest) (*pb.User, error) {
+	user, err := s.reader(ctx).GetUser(nil, req.GetNickname())
+	if err != nil {
+		return nil, statusError(apperr.New(apperr.CodeUserNotFound, service.ErrNoUser+req.GetNickname()))
+	}
+
+	setETag(ctx, service.ETag(user.Version))
+	return user.Proto(), nil
+}
+
+func (s *Server) UserUpdate(ctx context.Context, req *pb.UserUpdateRequest) (*pb.User, error) {
+	userReq := entity.UpdateUserFromProto(req.GetUser())
+	if err := check(&userReq); err != nil {
+		return nil, statusError(err)
+	}
+
+	user, err := s.service.UpdateUser(ctx, req.GetNickname(), userReq, incoming(ctx, IF_MATCH_METADATA))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	setETag(ctx, service.ETag(user.Version))
+	return user.Proto(), nil
+}
+
+func (s *Server) UserRename(ctx context.Context, req *pb.UserRenameRequest) (*pb.User, error) {
+	renameReq := entity.RenameUserFromProto(req.GetRename())
+	if err := check(&renameReq); err != nil {
+		return nil, statusError(err)
+	}
+
+	user, err := s.service.RenameUser(ctx, req.GetNickname(), renameReq)
+	if err != nil {
+		return nil, statusError(err)
+	}
+	return user.Proto(), nil
+}
@@ -1,9 +1,8 @@
 package handler
 
 import (
-	"github.com/mailru/easyjson"
-	log "github.com/sirupsen/logrus"
 	"net/http"
+	"techpark_db/internal/handler/httperr"
 )
 
 func (h *Handler) ServiceStatus(w http.ResponseWriter, r *http.Request) {
@@ -11,34 +10,36 @@ func (h *Handler) ServiceStatus(w http.ResponseWriter, r *http.Request) {
 
 	tx, err := h.storage.DB.Begin()
 	if err != nil {
-		log.Error(err)
-		w.WriteHeader(http.StatusInternalServerError)
+		httperr.Write(w, err)
 		return
 	}
 
 	servStatus, err := h.storage.GetServiceStatus(tx)
 	if err != nil {
 		tx.Rollback()
-		w.WriteHeader(http.StatusInternalServerError)
+		httperr.Write(w, err)
 		return
 	}
 
 	if err := tx.Commit(); err != nil {
-		log.Error(err)
-		w.WriteHeader(http.StatusInternalServerError)
+		httperr.Write(w, err)
 		return
 	}
 
-	servStatusBytes, _ := easyjson.Marshal(servStatus)
-	w.WriteHeader(http.StatusOK)
-	w.Write(servStatusBytes)
+	write(w, r, http.StatusOK, servStatus)
 }
 
 func (h *Handler) ServiceClear(w http.ResponseWriter, r *http.Request) {
 	if err := h.storage.ClearData(); err != nil {
-		w.WriteHeader(http.StatusInternalServerError)
+		httperr.Write(w, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
 }
+
+func (h *Handler) ServiceCache(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Content-Type", "application/json")
+
+	write(w, r, http.StatusOK, h.storage.CacheStatus())
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"github.com/mailru/easyjson"
+	"github.com/mailru/easyjson/jwriter"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+	"techpark_db/internal/handler/httperr"
+)
+
+const (
+	// STREAM_LIMIT is the page size above which lists are written row by row
+	// as they are read instead of being marshalled at once. Streamed pages
+	// have no ETag, it would need the whole page.
+	STREAM_LIMIT = 1000
+	// STREAM_CHUNK_SIZE is how much JSON is buffered before it is written out.
+	STREAM_CHUNK_SIZE = 32 * 1024
+)
+
+// arrayStream writes a JSON array to the response element by element.
+// The status is only sent with the first element, so a stream that fails
+// or turns out empty can still be answered with an error.
+type arrayStream struct {
+	w     http.ResponseWriter
+	jw    jwriter.Writer
+	count int
+}
+
+func newArrayStream(w http.ResponseWriter) *arrayStream {
+	return &arrayStream{w: w}
+}
+
+func (s *arrayStream) Add(v easyjson.Marshaler) error {
+	if s.count == 0 {
+		s.w.WriteHeader(http.StatusOK)
+		s.jw.RawByte('[')
+	} else {
+		s.jw.RawByte(',')
+	}
+	s.count++
+
+	v.MarshalEasyJSON(&s.jw)
+	if s.jw.Size() < STREAM_CHUNK_SIZE {
+		return nil
+	}
+	_, err := s.jw.DumpTo(s.w)
+	return err
+}
+
+func (s *arrayStream) Close() {
+	if s.count == 0 {
+		s.w.WriteHeader(http.StatusOK)
+		s.jw.RawByte('[')
+	}
+	s.jw.RawByte(']')
+	if _, err := s.jw.DumpTo(s.w); err != nil {
+		log.Warning("stream: ", err)
+	}
+}
+
+// Fail answers an error of the stream. After the first element the status is
+// gone, the connection is cut so the client can't take the truncated array
+// for a whole one.
+func (s *arrayStream) Fail(err error) {
+	if s.count == 0 {
+		httperr.Write(s.w, err)
+		return
+	}
+	log.Error("stream: ", err)
+	panic(http.ErrAbortHandler)
+}
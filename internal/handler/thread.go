@@ -1,13 +1,13 @@
 package handler
 
 import (
-	"encoding/json"
 	"github.com/gorilla/mux"
-	"github.com/mailru/easyjson"
-	log "github.com/sirupsen/logrus"
 	"net/http"
 	"strconv"
+	"techpark_db/internal/apperr"
 	"techpark_db/internal/domain/entity"
+	"techpark_db/internal/handler/httperr"
+	"techpark_db/internal/service"
 	"time"
 )
 
@@ -16,106 +16,23 @@ func (h *Handler) ThreadCreatePosts(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	slug_or_id, ok := vars["slug_or_id"]
 	if !ok {
-		w.WriteHeader(http.StatusBadRequest)
+		httperr.Write(w, apperr.New(apperr.CodeBadRequest, ErrBadRequest))
 		return
 	}
 
-	var postReq []entity.CreatePost
-	if err := json.NewDecoder(r.Body).Decode(&postReq); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	var postReq entity.CreatePosts
+	if err := decode(r, &postReq); err != nil {
+		httperr.Write(w, err)
 		return
 	}
 
-	tx, err := h.storage.DB.Begin()
+	posts, err := h.service.CreatePosts(r.Context(), slug_or_id, postReq)
 	if err != nil {
-		log.Error(err)
-		w.WriteHeader(http.StatusInternalServerError)
+		httperr.Write(w, err)
 		return
 	}
 
-	thread, err := h.storage.GetThread(tx, slug_or_id)
-	if err != nil {
-		tx.Rollback()
-		resp := &entity.Error{
-			Message: ErrNoThread + slug_or_id,
-		}
-		respBytes, _ := easyjson.Marshal(resp)
-		w.WriteHeader(http.StatusNotFound)
-		w.Write(respBytes)
-		return
-	}
-
-	if len(postReq) == 0 {
-		tx.Rollback()
-		postsBytes, _ := json.Marshal(postReq)
-		w.WriteHeader(http.StatusCreated)
-		w.Write(postsBytes)
-		return
-	}
-
-	if _, err := h.storage.GetUser(tx, postReq[0].Author); err != nil {
-		tx.Rollback()
-		resp := &entity.Error{
-			Message: ErrNoPostAuthor + postReq[0].Author,
-		}
-		respBytes, _ := easyjson.Marshal(resp)
-		w.WriteHeader(http.StatusNotFound)
-		w.Write(respBytes)
-		return
-	}
-
-	if postReq[0].Parent != 0 {
-		ok, err := h.storage.CheckParentPost(tx, postReq[0].Parent, thread.Id)
-		if err != nil {
-			tx.Rollback()
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
-		if !ok {
-			tx.Rollback()
-			resp := &entity.Error{
-				Message: ErrNoThread + slug_or_id,
-			}
-			respBytes, _ := easyjson.Marshal(resp)
-			w.WriteHeader(http.StatusConflict)
-			w.Write(respBytes)
-			return
-		}
-	}
-
-	created := time.Now().Format(time.RFC3339Nano)
-	created = created[:len(created)-4]
-
-	ids, err := h.storage.SavePosts(tx, postReq, thread.Forum, thread.Id, created)
-	if err != nil {
-		tx.Rollback()
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	if err := tx.Commit(); err != nil {
-		log.Error(err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	posts := make([]entity.Post, len(*ids))
-	for i := 0; i < len(*ids); i++ {
-		posts[i] = entity.Post{
-			Id:       (*ids)[i],
-			Parent:   postReq[i].Parent,
-			Author:   postReq[i].Author,
-			Message:  postReq[i].Message,
-			Created:  created,
-			IsEdited: false,
-			Forum:    thread.Forum,
-			Thread:   thread.Id,
-		}
-	}
-
-	postsBytes, _ := json.Marshal(posts)
-	w.WriteHeader(http.StatusCreated)
-	w.Write(postsBytes)
+	write(w, r, http.StatusCreated, posts)
 }
 
 func (h *Handler) ThreadVote(w http.ResponseWriter, r *http.Request) {
@@ -123,119 +40,51 @@ func (h *Handler) ThreadVote(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	slug_or_id, ok := vars["slug_or_id"]
 	if !ok {
-		w.WriteHeader(http.StatusBadRequest)
+		httperr.Write(w, apperr.New(apperr.CodeBadRequest, ErrBadRequest))
 		return
 	}
 
 	var voteReq entity.Vote
-	if err := json.NewDecoder(r.Body).Decode(&voteReq); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-
-	tx, err := h.storage.DB.Begin()
-	if err != nil {
-		log.Error(err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	thread, err := h.storage.GetThread(tx, slug_or_id)
-	if err != nil {
-		tx.Rollback()
-		resp := &entity.Error{
-			Message: ErrNoThread + slug_or_id,
-		}
-		respBytes, _ := easyjson.Marshal(resp)
-		w.WriteHeader(http.StatusNotFound)
-		w.Write(respBytes)
-		return
-	}
-	voteReq.IdThread = thread.Id
-
-	user, err := h.storage.GetUser(tx, voteReq.Nickname)
-	if err != nil {
-		tx.Rollback()
-		resp := &entity.Error{
-			Message: ErrNoUser + voteReq.Nickname,
-		}
-		respBytes, _ := easyjson.Marshal(resp)
-		w.WriteHeader(http.StatusNotFound)
-		w.Write(respBytes)
+	if err := decode(r, &voteReq); err != nil {
+		httperr.Write(w, err)
 		return
 	}
-	voteReq.Nickname = user.Nickname
 
-	err = h.storage.SetVote(tx, voteReq)
+	thread, err := h.service.Vote(r.Context(), slug_or_id, voteReq)
 	if err != nil {
-		tx.Rollback()
-		w.WriteHeader(http.StatusConflict)
-		return
-	}
-
-	voteCount, err := h.storage.CountVote(tx, thread.Id)
-	if err != nil {
-		tx.Rollback()
-		log.Error(err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-	thread.Votes = *voteCount
-
-	if err := tx.Commit(); err != nil {
-		log.Error(err)
-		w.WriteHeader(http.StatusInternalServerError)
+		httperr.Write(w, err)
 		return
 	}
 
-	threadBytes, _ := easyjson.Marshal(thread)
-	w.WriteHeader(http.StatusOK)
-	w.Write(threadBytes)
+	write(w, r, http.StatusOK, thread)
 }
 
 func (h *Handler) ThreadDetails(w http.ResponseWriter, r *http.Request) {
 	w.Header().Add("Content-Type", "application/json")
+	store := h.reader(r)
 	vars := mux.Vars(r)
 	slug_or_id, ok := vars["slug_or_id"]
 	if !ok {
-		w.WriteHeader(http.StatusBadRequest)
+		httperr.Write(w, apperr.New(apperr.CodeBadRequest, ErrBadRequest))
 		return
 	}
 
-	//tx, err := h.storage.DB.Begin()
-	//if err != nil {
-	//	log.Error(err)
-	//	w.WriteHeader(http.StatusInternalServerError)
-	//	return
-	//}
-
 	ts := r.Context().Value("timestamp").(*[]time.Time)
 	*ts = append(*ts, time.Now())
 
-	thread, err := h.storage.GetThread(nil, slug_or_id)
+	thread, err := store.GetThread(nil, slug_or_id)
 	if err != nil {
-		//tx.Rollback()
-		resp := &entity.Error{
-			Message: ErrNoThread + slug_or_id,
-		}
-		respBytes, _ := easyjson.Marshal(resp)
-		w.WriteHeader(http.StatusNotFound)
-		w.Write(respBytes)
+		httperr.Write(w, apperr.New(apperr.CodeThreadNotFound, service.ErrNoThread+slug_or_id))
 		return
 	}
 
 	ts = r.Context().Value("timestamp").(*[]time.Time)
 	*ts = append(*ts, time.Now())
 
-	//if err := tx.Commit(); err != nil {
-	//	log.Error(err)
-	//	w.WriteHeader(http.StatusInternalServerError)
-	//	return
-	//}
-
-	threadBytes, _ := easyjson.Marshal(thread)
-	w.WriteHeader(http.StatusOK)
-	w.Write(threadBytes)
+	if notModified(w, r, service.ETag(thread.Version), thread.Modified) {
+		return
+	}
+	write(w, r, http.StatusOK, thread)
 }
 
 func (h *Handler) ThreadUpdate(w http.ResponseWriter, r *http.Request) {
@@ -243,186 +92,224 @@ func (h *Handler) ThreadUpdate(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	slug_or_id, ok := vars["slug_or_id"]
 	if !ok {
-		w.WriteHeader(http.StatusBadRequest)
+		httperr.Write(w, apperr.New(apperr.CodeBadRequest, ErrBadRequest))
 		return
 	}
 
 	var threadReq entity.Thread
-	if err := json.NewDecoder(r.Body).Decode(&threadReq); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := decode(r, &threadReq); err != nil {
+		httperr.Write(w, err)
 		return
 	}
 
-	tx, err := h.storage.DB.Begin()
+	thread, err := h.service.UpdateThread(r.Context(), slug_or_id, threadReq, r.Header.Get("If-Match"))
 	if err != nil {
-		log.Error(err)
-		w.WriteHeader(http.StatusInternalServerError)
+		httperr.Write(w, err)
 		return
 	}
 
-	thread, err := h.storage.GetThread(tx, slug_or_id)
-	if err != nil {
-		tx.Rollback()
-		resp := &entity.Error{
-			Message: ErrNoThread + slug_or_id,
-		}
-		respBytes, _ := easyjson.Marshal(resp)
-		w.WriteHeader(http.StatusNotFound)
-		w.Write(respBytes)
+	setETag(w, r, thread.Version)
+	write(w, r, http.StatusOK, thread)
+}
+
+func (h *Handler) ThreadMove(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Content-Type", "application/json")
+	vars := mux.Vars(r)
+	slug_or_id, ok := vars["slug_or_id"]
+	if !ok {
+		httperr.Write(w, apperr.New(apperr.CodeBadRequest, ErrBadRequest))
 		return
 	}
 
-	if threadReq.Title != "" {
-		thread.Title = threadReq.Title
+	var moveReq entity.MoveThread
+	if err := decode(r, &moveReq); err != nil {
+		httperr.Write(w, err)
+		return
 	}
-	if threadReq.Message != "" {
-		thread.Message = threadReq.Message
+
+	thread, err := h.service.MoveThread(r.Context(), slug_or_id, moveReq)
+	if err != nil {
+		httperr.Write(w, err)
+		return
+	}
+
+	write(w, r, http.StatusOK, thread)
+}
+
+func (h *Handler) ThreadMerge(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Content-Type", "application/json")
+	vars := mux.Vars(r)
+	slug_or_id, ok := vars["slug_or_id"]
+	if !ok {
+		httperr.Write(w, apperr.New(apperr.CodeBadRequest, ErrBadRequest))
+		return
 	}
 
-	if err := h.storage.UpdateThread(tx, *thread); err != nil {
-		tx.Rollback()
-		w.WriteHeader(http.StatusInternalServerError)
+	var mergeReq entity.MergeThread
+	if err := decode(r, &mergeReq); err != nil {
+		httperr.Write(w, err)
 		return
 	}
 
-	if err := tx.Commit(); err != nil {
-		log.Error(err)
-		w.WriteHeader(http.StatusInternalServerError)
+	target, err := h.service.MergeThreads(r.Context(), slug_or_id, mergeReq)
+	if err != nil {
+		httperr.Write(w, err)
 		return
 	}
 
-	threadBytes, _ := easyjson.Marshal(thread)
-	w.WriteHeader(http.StatusOK)
-	w.Write(threadBytes)
+	write(w, r, http.StatusOK, target)
 }
 
 func (h *Handler) ThreadPosts(w http.ResponseWriter, r *http.Request) {
 	w.Header().Add("Content-Type", "application/json")
+	store := h.reader(r)
 	vars := mux.Vars(r)
 	slug_or_id, ok := vars["slug_or_id"]
 	if !ok {
-		w.WriteHeader(http.StatusBadRequest)
+		httperr.Write(w, apperr.New(apperr.CodeBadRequest, ErrBadRequest))
 		return
 	}
 
-	limit := DEFAULT_LIMIT
-	since := DEFAULT_SINCE_ID
-	sort := DEFAUTL_SORT
+	q := newQuery(r)
+	limit := q.Int("limit", DEFAULT_LIMIT, h.limitRules())
+	since := q.Int("since", DEFAULT_SINCE_ID, "min=0")
+	sort := q.String("sort", DEFAUTL_SORT, "oneof=flat tree parent_tree")
 	order := DEFAULT_ORDER
-	if r.FormValue("limit") != "" {
-		limit, _ = strconv.Atoi(r.FormValue("limit"))
-	}
-	if r.FormValue("since") != "" {
-		since, _ = strconv.Atoi(r.FormValue("since"))
-	}
-	if r.FormValue("sort") != "" {
-		sort = r.FormValue("sort")
-	}
-	if r.FormValue("desc") == "true" {
+	if q.Bool("desc") {
 		order = "DESC"
 	}
-
-	//tx, err := h.storage.DB.Begin()
-	//if err != nil {
-	//	log.Error(err)
-	//	w.WriteHeader(http.StatusInternalServerError)
-	//	return
-	//}
-
-	//ts := r.Context().Value("timestamp").(*[]time.Time)
-	//*ts = append(*ts, time.Now())
+	format := q.String("format", "", "oneof="+FORMAT_NESTED)
+	maxDepth := q.Int("max_depth", DEFAULT_MAX_DEPTH, "min=0")
+	if err := q.Err(); err != nil {
+		httperr.Write(w, err)
+		return
+	}
 
 	id, err := strconv.Atoi(slug_or_id)
 	if err != nil {
-		thread, err := h.storage.GetThread(nil, slug_or_id)
+		thread, err := store.GetThread(nil, slug_or_id)
 		if err != nil {
-			//tx.Rollback()
-			resp := &entity.Error{
-				Message: ErrNoThread + slug_or_id,
-			}
-			respBytes, _ := easyjson.Marshal(resp)
-			w.WriteHeader(http.StatusNotFound)
-			w.Write(respBytes)
+			httperr.Write(w, apperr.New(apperr.CodeThreadNotFound, service.ErrNoThread+slug_or_id))
 			return
 		}
 		id = thread.Id
 	}
 
-	//ts = r.Context().Value("timestamp").(*[]time.Time)
-	//*ts = append(*ts, time.Now())
+	if limit > STREAM_LIMIT && format != FORMAT_NESTED && negotiateContentType(r) == CONTENT_TYPE_JSON {
+		stream := newArrayStream(w)
+		err := store.StreamThreadPosts(nil, id, limit, since, sort, order, func(post *entity.Post) error {
+			return stream.Add(post)
+		})
+		if err == nil && stream.count == 0 {
+			if _, err := store.GetThread(nil, slug_or_id); err != nil {
+				httperr.Write(w, apperr.New(apperr.CodeThreadNotFound, service.ErrNoThread+slug_or_id))
+				return
+			}
+		}
+		if err != nil {
+			stream.Fail(err)
+			return
+		}
+		stream.Close()
+		return
+	}
 
 	var posts *[]entity.Post
 	switch sort {
 	case "flat":
-		posts, err = h.storage.GetPostsByThreadFlat(nil, id, limit, since, sort, order)
+		posts, err = store.GetPostsByThreadFlat(nil, id, limit, since, sort, order)
 	case "tree":
-		posts, err = h.storage.GetPostsTree(nil, id, limit, since, sort, order)
+		posts, err = store.GetPostsTree(nil, id, limit, since, sort, order)
 	case "parent_tree":
-		posts, err = h.storage.GetPostsParentTree(nil, id, limit, since, sort, order)
+		posts, err = store.GetPostsParentTree(nil, id, limit, since, sort, order)
 	}
 
 	if err != nil {
-		//tx.Rollback()
-		w.WriteHeader(http.StatusInternalServerError)
+		httperr.Write(w, err)
 		return
 	}
 
 	if len(*posts) == 0 {
-		if _, err := h.storage.GetThread(nil, slug_or_id); err != nil {
-			//tx.Rollback()
-			resp := &entity.Error{
-				Message: ErrNoThread + slug_or_id,
-			}
-			respBytes, _ := easyjson.Marshal(resp)
-			w.WriteHeader(http.StatusNotFound)
-			w.Write(respBytes)
+		if _, err := store.GetThread(nil, slug_or_id); err != nil {
+			httperr.Write(w, apperr.New(apperr.CodeThreadNotFound, service.ErrNoThread+slug_or_id))
 			return
 		}
 	}
 
-	//ts = r.Context().Value("timestamp").(*[]time.Time)
-	//*ts = append(*ts, time.Now())
+	// no Last-Modified: posts moved out of the page would not advance it
+	if notModified(w, r, postsETag(*posts, format, maxDepth), time.Time{}) {
+		return
+	}
 
-	//if err := tx.Commit(); err != nil {
-	//	log.Error(err)
-	//	w.WriteHeader(http.StatusInternalServerError)
-	//	return
-	//}
+	if format == FORMAT_NESTED {
+		write(w, r, http.StatusOK, NestPosts(*posts, maxDepth))
+		return
+	}
 
 	var p entity.Posts
 	p = *posts
-	postsBytes, _ := easyjson.Marshal(p)
-	//postsBytes, _ := json.Marshal(posts)
-	w.WriteHeader(http.StatusOK)
-	w.Write(postsBytes)
+	write(w, r, http.StatusOK, p)
 }
 
-//
-//user, err := h.storage.GetUser(voteReq.Nickname)
-//if err != nil {
-//	resp := &entity.Error{
-//		Message: ErrNoUser + voteReq.Nickname,
-//	}
-//	respBytes, _ := json.Marshal(resp)
-//	w.WriteHeader(http.StatusNotFound)
-//	w.Write(respBytes)
-//	return
-//}
-//voteReq.Nickname = user.Nickname
-//
-//voteReq.IdThread = thread.Id
-//voteReq.SlugThread = thread.Slug
-//
-//vote, err := h.storage.GetVote(voteReq.IdThread, voteReq.Nickname)
-//if err != nil {
-//	h.storage.SaveVote(voteReq)
-//	thread.Votes += voteReq.Voice
-//} else {
-//	h.storage.UpdateVote(voteReq)
-//	thread.Votes += voteReq.Voice - vote.Voice
-//}
-//
-//if err := h.storage.UpdateThreadVote(*thread); err != nil {
-//	w.WriteHeader(http.StatusInternalServerError)
-//	return
-//}
+type postTreeNode struct {
+	post     entity.Post
+	children []*postTreeNode
+}
+
+// NestPosts turns a page of posts into a tree. Posts whose parent is not on
+// the page become top-level nodes, siblings keep the page order. Nodes deeper
+// than maxDepth are dropped and counted in the "more" stub of their ancestor
+// on the last kept level. A maxDepth below 1 means no limit.
+func NestPosts(posts []entity.Post, maxDepth int) entity.PostNodes {
+	nodes := make(map[int]*postTreeNode, len(posts))
+	for _, post := range posts {
+		nodes[post.Id] = &postTreeNode{post: post}
+	}
+
+	roots := make([]*postTreeNode, 0)
+	for _, post := range posts {
+		node := nodes[post.Id]
+		if parent, ok := nodes[post.Parent]; ok {
+			parent.children = append(parent.children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	return convertPostNodes(roots, 1, maxDepth)
+}
+
+func convertPostNodes(nodes []*postTreeNode, depth int, maxDepth int) entity.PostNodes {
+	result := make(entity.PostNodes, 0, len(nodes))
+	for _, node := range nodes {
+		postNode := entity.PostNode{
+			Id:       node.post.Id,
+			Parent:   node.post.Parent,
+			Author:   node.post.Author,
+			Message:  node.post.Message,
+			IsEdited: node.post.IsEdited,
+			Forum:    node.post.Forum,
+			Thread:   node.post.Thread,
+			Created:  node.post.Created,
+		}
+		if maxDepth < 1 || depth < maxDepth {
+			postNode.Children = convertPostNodes(node.children, depth+1, maxDepth)
+		} else {
+			postNode.Children = make(entity.PostNodes, 0)
+			if more := countPostNodes(node.children); more > 0 {
+				postNode.More = &entity.MoreReplies{
+					Count: more,
+				}
+			}
+		}
+		result = append(result, postNode)
+	}
+	return result
+}
+
+func countPostNodes(nodes []*postTreeNode) int {
+	count := len(nodes)
+	for _, node := range nodes {
+		count += countPostNodes(node.children)
+	}
+	return count
+}
@@ -1,12 +1,12 @@
 package handler
 
 import (
-	"encoding/json"
 	"github.com/gorilla/mux"
-	"github.com/mailru/easyjson"
-	log "github.com/sirupsen/logrus"
 	"net/http"
+	"techpark_db/internal/apperr"
 	"techpark_db/internal/domain/entity"
+	"techpark_db/internal/handler/httperr"
+	"techpark_db/internal/service"
 	"time"
 )
 
@@ -15,99 +15,55 @@ func (h *Handler) UserCreate(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	nickname, ok := vars["nickname"]
 	if !ok {
-		w.WriteHeader(http.StatusBadRequest)
+		httperr.Write(w, apperr.New(apperr.CodeBadRequest, ErrBadRequest))
 		return
 	}
 
 	var userReq entity.CreateUser
-	if err := json.NewDecoder(r.Body).Decode(&userReq); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+	if err := decode(r, &userReq); err != nil {
+		httperr.Write(w, err)
 		return
 	}
 
-	tx, err := h.storage.DB.Begin()
-	if err != nil {
-		log.Error(err)
-		w.WriteHeader(http.StatusInternalServerError)
-		return
-	}
-
-	users, err := h.storage.FindUser(tx, nickname, userReq.Email)
-	if err == nil && len(*users) > 0 {
-		tx.Rollback()
-		usersBytes, _ := json.Marshal(users)
-		w.WriteHeader(http.StatusConflict)
-		w.Write(usersBytes)
+	user, users, err := h.service.CreateUser(r.Context(), nickname, userReq)
+	if err == service.ErrExists {
+		write(w, r, http.StatusConflict, users)
 		return
 	}
-
-	if err := h.storage.SaveUser(tx, userReq, nickname); err != nil {
-		tx.Rollback()
-		http.Error(w, err.Error(), http.StatusInternalServerError)
-		return
-	}
-
-	user := &entity.User{
-		Nickname: nickname,
-		Fullname: userReq.Fullname,
-		About:    userReq.About,
-		Email:    userReq.Email,
-	}
-
-	if err := tx.Commit(); err != nil {
-		log.Error(err)
-		w.WriteHeader(http.StatusInternalServerError)
+	if err != nil {
+		httperr.Write(w, err)
 		return
 	}
 
-	forumBytes, _ := easyjson.Marshal(user)
-	w.WriteHeader(http.StatusCreated)
-	w.Write(forumBytes)
+	write(w, r, http.StatusCreated, user)
 }
 
 func (h *Handler) UserDetails(w http.ResponseWriter, r *http.Request) {
 	w.Header().Add("Content-Type", "application/json")
+	store := h.reader(r)
 	vars := mux.Vars(r)
 	nickname, ok := vars["nickname"]
 	if !ok {
-		w.WriteHeader(http.StatusBadRequest)
+		httperr.Write(w, apperr.New(apperr.CodeBadRequest, ErrBadRequest))
 		return
 	}
 
-	//tx, err := h.storage.DB.Begin()
-	//if err != nil {
-	//	log.Error(err)
-	//	w.WriteHeader(http.StatusInternalServerError)
-	//	return
-	//}
-
 	ts := r.Context().Value("timestamp").(*[]time.Time)
 	*ts = append(*ts, time.Now())
 
-	user, err := h.storage.GetUser(nil, nickname)
+	user, err := store.GetUser(nil, nickname)
 	if err != nil {
-		//tx.Rollback()
-		resp := &entity.Error{
-			Message: ErrNoUser + nickname,
-		}
-		errorBytes, _ := easyjson.Marshal(resp)
-		w.WriteHeader(http.StatusNotFound)
-		w.Write(errorBytes)
+		httperr.Write(w, apperr.New(apperr.CodeUserNotFound, service.ErrNoUser+nickname))
 		return
 	}
 
 	ts = r.Context().Value("timestamp").(*[]time.Time)
 	*ts = append(*ts, time.Now())
 
-	//if err := tx.Commit(); err != nil {
-	//	log.Error(err)
-	//	w.WriteHeader(http.StatusInternalServerError)
-	//	return
-	//}
-
-	userBytes, _ := easyjson.Marshal(user)
-	w.WriteHeader(http.StatusOK)
-	w.Write(userBytes)
+	if notModified(w, r, service.ETag(user.Version), user.Modified) {
+		return
+	}
+	write(w, r, http.StatusOK, user)
 	return
 }
 
@@ -116,83 +72,47 @@ func (h *Handler) UserUpdate(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	nickname, ok := vars["nickname"]
 	if !ok {
-		w.WriteHeader(http.StatusBadRequest)
+		httperr.Write(w, apperr.New(apperr.CodeBadRequest, ErrBadRequest))
 		return
 	}
 
 	var userReq entity.UpdateUser
-	if err := json.NewDecoder(r.Body).Decode(&userReq); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
-		return
-	}
-
-	tx, err := h.storage.DB.Begin()
-	if err != nil {
-		log.Error(err)
-		w.WriteHeader(http.StatusInternalServerError)
+	if err := decode(r, &userReq); err != nil {
+		httperr.Write(w, err)
 		return
 	}
 
-	user, err := h.storage.GetUser(tx, nickname)
+	user, err := h.service.UpdateUser(r.Context(), nickname, userReq, r.Header.Get("If-Match"))
 	if err != nil {
-		tx.Rollback()
-		resp := &entity.Error{
-			Message: ErrNoUser + nickname,
-		}
-		respBytes, _ := easyjson.Marshal(resp)
-		w.WriteHeader(http.StatusNotFound)
-		w.Write(respBytes)
+		httperr.Write(w, err)
 		return
 	}
 
-	if userReq.Fullname == "" {
-		userReq.Fullname = user.Fullname
-	}
-	if userReq.About == "" {
-		userReq.About = user.About
-	}
-	if userReq.Email == "" {
-		userReq.Email = user.Email
-	}
+	setETag(w, r, user.Version)
+	write(w, r, http.StatusOK, user)
+	return
+}
 
-	users, err := h.storage.FindUser(tx, nickname, userReq.Email)
-	if err == nil && len(*users) > 1 {
-		tx.Rollback()
-		resp := &entity.Error{
-			Message: ErrEmailAlreadyRegistered + nickname,
-		}
-		respBytes, _ := easyjson.Marshal(resp)
-		w.WriteHeader(http.StatusConflict)
-		w.Write(respBytes)
+func (h *Handler) UserRename(w http.ResponseWriter, r *http.Request) {
+	w.Header().Add("Content-Type", "application/json")
+	vars := mux.Vars(r)
+	nickname, ok := vars["nickname"]
+	if !ok {
+		httperr.Write(w, apperr.New(apperr.CodeBadRequest, ErrBadRequest))
 		return
 	}
 
-	if err := h.storage.UpdateUser(tx, userReq, nickname); err != nil {
-		tx.Rollback()
-		resp := &entity.Error{
-			Message: ErrEmailAlreadyRegistered + nickname,
-		}
-		respBytes, _ := easyjson.Marshal(resp)
-		w.WriteHeader(http.StatusConflict)
-		w.Write(respBytes)
+	var renameReq entity.RenameUser
+	if err := decode(r, &renameReq); err != nil {
+		httperr.Write(w, err)
 		return
 	}
 
-	user = &entity.User{
-		Nickname: nickname,
-		Fullname: userReq.Fullname,
-		About:    userReq.About,
-		Email:    userReq.Email,
-	}
-
-	if err := tx.Commit(); err != nil {
-		log.Error(err)
-		w.WriteHeader(http.StatusInternalServerError)
+	user, err := h.service.RenameUser(r.Context(), nickname, renameReq)
+	if err != nil {
+		httperr.Write(w, err)
 		return
 	}
 
-	userBytes, _ := easyjson.Marshal(user)
-	w.WriteHeader(http.StatusOK)
-	w.Write(userBytes)
-	return
+	write(w, r, http.StatusOK, user)
 }
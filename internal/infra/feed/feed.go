@@ -0,0 +1,91 @@
+package feed
+
+import (
+	"sync"
+	"techpark_db/internal/domain/entity"
+)
+
+const DEFAULT_BUFFER = 256
+
+// Feed fans the created posts out to its subscribers. Publish never waits
+// for a subscriber: one which falls behind by more than its buffer is
+// dropped and its channel is closed.
+type Feed struct {
+	mu   sync.Mutex
+	subs map[*Subscription]struct{}
+}
+
+// Subscription receives the posts matching its filter on C until it is
+// closed. C is closed by the feed when the subscriber falls behind.
+type Subscription struct {
+	C <-chan entity.Post
+
+	c      chan entity.Post
+	filter func(*entity.Post) bool
+	feed   *Feed
+}
+
+func New() *Feed {
+	return &Feed{
+		subs: make(map[*Subscription]struct{}),
+	}
+}
+
+// Subscribe starts a subscription. A nil filter matches every post.
+func (f *Feed) Subscribe(buffer int, filter func(*entity.Post) bool) *Subscription {
+	c := make(chan entity.Post, buffer)
+	sub := &Subscription{
+		C:      c,
+		c:      c,
+		filter: filter,
+		feed:   f,
+	}
+
+	f.mu.Lock()
+	f.subs[sub] = struct{}{}
+	f.mu.Unlock()
+	return sub
+}
+
+// Close stops the subscription, it is safe to call it more than once.
+func (s *Subscription) Close() {
+	s.feed.mu.Lock()
+	defer s.feed.mu.Unlock()
+	s.feed.remove(s)
+}
+
+func (f *Feed) Publish(posts []entity.Post) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for sub := range f.subs {
+		for i := range posts {
+			if sub.filter != nil && !sub.filter(&posts[i]) {
+				continue
+			}
+			select {
+			case sub.c <- posts[i]:
+			default:
+				f.remove(sub)
+			}
+			if _, ok := f.subs[sub]; !ok {
+				break
+			}
+		}
+	}
+}
+
+func (f *Feed) remove(sub *Subscription) {
+	if _, ok := f.subs[sub]; !ok {
+		return
+	}
+	delete(f.subs, sub)
+	close(sub.c)
+}
+
+// Len is the number of subscribers.
+func (f *Feed) Len() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.subs)
+}
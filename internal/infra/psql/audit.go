@@ -0,0 +1,19 @@
+package psql
+
+import (
+	"database/sql"
+	"github.com/mailru/easyjson"
+)
+
+const querySaveAudit = "INSERT INTO AuditLog(Action, Details) VALUES ($1, $2)"
+
+func (store *Storage) SaveAudit(tx *sql.Tx, action string, details easyjson.Marshaler) error {
+	detailsBytes, err := easyjson.Marshal(details)
+	if err != nil {
+		return err
+	}
+	if _, err := store.exec(tx, querySaveAudit, action, string(detailsBytes)); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package psql
+
+import (
+	"database/sql"
+	"github.com/lib/pq"
+	"techpark_db/internal/domain/entity"
+)
+
+// The batch reads load the rows of many keys with one query, for the
+// GraphQL loaders. Keys without rows are left out of the result. List reads
+// take the first limit rows of every key, one index scan per key.
+
+const queryGetUsersByNicknames = `SELECT Nickname, Fullname, About, Email, Version, Modified FROM Users
+WHERE Nickname = ANY($1::citext[])`
+
+func (store *Storage) GetUsersByNicknames(tx *sql.Tx, nicknames []string) ([]entity.User, error) {
+	rows, err := store.query(tx, queryGetUsersByNicknames, pq.Array(nicknames))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make([]entity.User, 0, len(nicknames))
+	for rows.Next() {
+		var user entity.User
+		if err := rows.Scan(&user.Nickname, &user.Fullname, &user.About, &user.Email, &user.Version, &user.Modified); err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return users, rows.Err()
+}
+
+const queryGetForumsBySlugs = `SELECT Slug, Title, Nickname, Posts, Threads, Version, Modified FROM Forum
+WHERE Slug = ANY($1::citext[])`
+
+func (store *Storage) GetForumsBySlugs(tx *sql.Tx, slugs []string) ([]entity.Forum, error) {
+	rows, err := store.query(tx, queryGetForumsBySlugs, pq.Array(slugs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	forums := make([]entity.Forum, 0, len(slugs))
+	for rows.Next() {
+		var forum entity.Forum
+		if err := rows.Scan(&forum.Slug, &forum.Title, &forum.User, &forum.Posts, &forum.Threads, &forum.Version, &forum.Modified); err != nil {
+			return nil, err
+		}
+		forums = append(forums, forum)
+	}
+	return forums, rows.Err()
+}
+
+const queryGetThreadsByIds = `SELECT Id, Title, Author, Forum, Message, Votes, Slug, Created, Version, Modified FROM Thread
+WHERE Id = ANY($1::int[])`
+
+func (store *Storage) GetThreadsByIds(tx *sql.Tx, ids []int) ([]entity.Thread, error) {
+	rows, err := store.query(tx, queryGetThreadsByIds, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	return scanThreads(rows)
+}
+
+const queryGetPostsByIds = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created, Version FROM Posts
+WHERE Id = ANY($1::int[])
+ORDER BY Id`
+
+func (store *Storage) GetPostsByIds(tx *sql.Tx, ids []int) ([]entity.Post, error) {
+	rows, err := store.query(tx, queryGetPostsByIds, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	return scanPosts(rows)
+}
+
+const queryGetForumsThreads = `SELECT Id, Title, Author, Forum, Message, Votes, Slug, Created, Version, Modified
+FROM unnest($1::citext[]) AS Batch(BatchKey)
+JOIN LATERAL (
+	SELECT * FROM Thread WHERE Forum = Batch.BatchKey
+	ORDER BY Created, Id
+	LIMIT $2
+) AS Threads ON true
+ORDER BY Forum, Created, Id`
+
+const queryGetForumsThreadsDesc = `SELECT Id, Title, Author, Forum, Message, Votes, Slug, Created, Version, Modified
+FROM unnest($1::citext[]) AS Batch(BatchKey)
+JOIN LATERAL (
+	SELECT * FROM Thread WHERE Forum = Batch.BatchKey
+	ORDER BY Created DESC, Id DESC
+	LIMIT $2
+) AS Threads ON true
+ORDER BY Forum, Created DESC, Id DESC`
+
+// GetForumsThreads returns the first limit threads of every forum by creation.
+func (store *Storage) GetForumsThreads(tx *sql.Tx, slugs []string, limit int, order string) ([]entity.Thread, error) {
+	query := queryGetForumsThreads
+	if order == "DESC" {
+		query = queryGetForumsThreadsDesc
+	}
+	rows, err := store.query(tx, query, pq.Array(slugs), limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanThreads(rows)
+}
+
+const queryGetThreadsPostsFlat = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created, Version
+FROM unnest($1::int[]) AS Batch(BatchKey)
+JOIN LATERAL (
+	SELECT * FROM Posts WHERE Thread = Batch.BatchKey
+	ORDER BY Created, Id
+	LIMIT $2
+) AS Page ON true
+ORDER BY Thread, Created, Id`
+
+const queryGetThreadsPostsFlatDesc = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created, Version
+FROM unnest($1::int[]) AS Batch(BatchKey)
+JOIN LATERAL (
+	SELECT * FROM Posts WHERE Thread = Batch.BatchKey
+	ORDER BY Created DESC, Id DESC
+	LIMIT $2
+) AS Page ON true
+ORDER BY Thread, Created DESC, Id DESC`
+
+const queryGetThreadsPostsTree = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created, Version
+FROM unnest($1::int[]) AS Batch(BatchKey)
+JOIN LATERAL (
+	SELECT * FROM Posts WHERE Thread = Batch.BatchKey
+	ORDER BY TreePath
+	LIMIT $2
+) AS Page ON true
+ORDER BY Thread, TreePath`
+
+const queryGetThreadsPostsTreeDesc = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created, Version
+FROM unnest($1::int[]) AS Batch(BatchKey)
+JOIN LATERAL (
+	SELECT * FROM Posts WHERE Thread = Batch.BatchKey
+	ORDER BY TreePath DESC
+	LIMIT $2
+) AS Page ON true
+ORDER BY Thread, TreePath DESC`
+
+// GetThreadsPosts returns the first limit posts of every thread, sorted
+// "flat" or "tree" like the posts page of a thread.
+func (store *Storage) GetThreadsPosts(tx *sql.Tx, ids []int, limit int, sort string, order string) ([]entity.Post, error) {
+	query := queryGetThreadsPostsFlat
+	switch {
+	case sort == "tree" && order == "DESC":
+		query = queryGetThreadsPostsTreeDesc
+	case sort == "tree":
+		query = queryGetThreadsPostsTree
+	case order == "DESC":
+		query = queryGetThreadsPostsFlatDesc
+	}
+	rows, err := store.query(tx, query, pq.Array(ids), limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanPosts(rows)
+}
+
+const queryGetThreadsVotes = `SELECT IdThread, Nickname, Voice
+FROM unnest($1::int[]) AS Batch(BatchKey)
+JOIN LATERAL (
+	SELECT * FROM Vote WHERE IdThread = Batch.BatchKey
+	ORDER BY Nickname
+	LIMIT $2
+) AS Votes ON true
+ORDER BY IdThread, Nickname`
+
+// GetThreadsVotes returns the first limit votes of every thread by nickname.
+func (store *Storage) GetThreadsVotes(tx *sql.Tx, ids []int, limit int) ([]entity.Vote, error) {
+	rows, err := store.query(tx, queryGetThreadsVotes, pq.Array(ids), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	votes := make([]entity.Vote, 0)
+	for rows.Next() {
+		var vote entity.Vote
+		if err := rows.Scan(&vote.IdThread, &vote.Nickname, &vote.Voice); err != nil {
+			return nil, err
+		}
+		votes = append(votes, vote)
+	}
+	return votes, rows.Err()
+}
+
+func scanThreads(rows *sql.Rows) ([]entity.Thread, error) {
+	defer rows.Close()
+
+	threads := make([]entity.Thread, 0)
+	for rows.Next() {
+		var thread entity.Thread
+		if err := rows.Scan(&thread.Id, &thread.Title, &thread.Author, &thread.Forum, &thread.Message, &thread.Votes, &thread.Slug, &thread.Created, &thread.Version, &thread.Modified); err != nil {
+			return nil, err
+		}
+		threads = append(threads, thread)
+	}
+	return threads, rows.Err()
+}
+
+func scanPosts(rows *sql.Rows) ([]entity.Post, error) {
+	defer rows.Close()
+
+	posts := make([]entity.Post, 0)
+	for rows.Next() {
+		var post entity.Post
+		if err := rows.Scan(&post.Id, &post.Parent, &post.Author, &post.Message, &post.IsEdited, &post.Forum, &post.Thread, &post.Created, &post.Version); err != nil {
+			return nil, err
+		}
+		posts = append(posts, post)
+	}
+	return posts, rows.Err()
+}
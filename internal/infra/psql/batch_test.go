@@ -0,0 +1,35 @@
+package psql
+
+import (
+	"strconv"
+	"techpark_db/internal/domain/entity"
+	"testing"
+)
+
+func TestGetThreadsVotesLimit(t *testing.T) {
+	store := testStorage(t)
+	_, thread := seedThread(t, store)
+	for i := 0; i < 5; i++ {
+		nickname := "voter" + strconv.Itoa(i)
+		user := entity.CreateUser{Fullname: "Voter", Email: nickname + "@example.com"}
+		if err := store.SaveUser(nil, user, nickname); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.SetVote(nil, entity.Vote{Nickname: nickname, Voice: 1, IdThread: thread}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	votes, err := store.GetThreadsVotes(nil, []int{thread}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(votes) != 3 {
+		t.Fatalf("%d votes, want 3", len(votes))
+	}
+	for i, vote := range votes {
+		if want := "voter" + strconv.Itoa(i); vote.Nickname != want {
+			t.Errorf("vote %d by %q, want %q", i, vote.Nickname, want)
+		}
+	}
+}
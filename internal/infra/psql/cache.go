@@ -0,0 +1,165 @@
+package psql
+
+import (
+	"database/sql"
+	"github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"strings"
+	"techpark_db/internal/domain/entity"
+	"techpark_db/internal/infra/cache"
+	"time"
+)
+
+const CACHE_CHANNEL = "forum_cache"
+
+// Invalidation keys are "<kind>:<key>". "<kind>:*" drops the whole kind and
+// "*" drops everything.
+const (
+	cacheKindUser   = "user"
+	cacheKindForum  = "forum"
+	cacheKindThread = "thread"
+	cacheKeyAll     = "*"
+)
+
+// storageCache keeps users and forums by lowercased nickname and slug (both
+// are citext) and threads by id. Thread slugs map to ids separately, so
+// dropping a thread by id is enough.
+type storageCache struct {
+	users   *cache.Cache
+	forums  *cache.Cache
+	threads *cache.Cache
+	slugs   *cache.Cache
+}
+
+// EnableCache turns on the read-through cache of GetUser, GetForum, GetThread
+// and GetThreadById. Forums and threads are only served from the cache outside
+// of transactions: a transaction reads the counters it is about to change.
+func (store *Storage) EnableCache(size int, ttl time.Duration) {
+	store.cache = &storageCache{
+		users:   cache.New(size, ttl),
+		forums:  cache.New(size, ttl),
+		threads: cache.New(size, ttl),
+		slugs:   cache.New(size, ttl),
+	}
+}
+
+func (store *Storage) CacheStatus() *entity.CacheStatus {
+	if store.cache == nil {
+		return &entity.CacheStatus{}
+	}
+	return &entity.CacheStatus{
+		User:   cacheStats(store.cache.users.Stats()),
+		Forum:  cacheStats(store.cache.forums.Stats()),
+		Thread: cacheStats(store.cache.threads.Stats()),
+	}
+}
+
+func cacheStats(stats cache.Stats) entity.CacheStats {
+	return entity.CacheStats{
+		Hits:   stats.Hits,
+		Misses: stats.Misses,
+		Size:   stats.Size,
+	}
+}
+
+// ListenCache drops entries invalidated by other instances sharing the database.
+func (store *Storage) ListenCache(dsn string) error {
+	listener := pq.NewListener(dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Warning("cache listener: ", err)
+		}
+	})
+	if err := listener.Listen(CACHE_CHANNEL); err != nil {
+		listener.Close()
+		return err
+	}
+
+	go func() {
+		for notification := range listener.Notify {
+			// nil is sent after a reconnect, notifications may have been lost
+			if notification == nil {
+				store.dropCached(cacheKeyAll)
+				continue
+			}
+			store.dropCached(notification.Extra)
+		}
+	}()
+	return nil
+}
+
+const queryNotifyCache = "SELECT pg_notify('" + CACHE_CHANNEL + "', $1)"
+
+// invalidate drops the keys at once and notifies every instance, this one
+// included, when tx commits. The second drop catches entries cached from
+// a concurrent read between the write and the commit.
+func (store *Storage) invalidate(tx *sql.Tx, keys ...string) error {
+	if store.cache == nil {
+		return nil
+	}
+	for _, key := range keys {
+		store.dropCached(key)
+		if _, err := store.exec(tx, queryNotifyCache, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (store *Storage) dropCached(key string) {
+	if store.cache == nil {
+		return
+	}
+	if key == cacheKeyAll {
+		store.cache.users.Purge()
+		store.cache.forums.Purge()
+		store.cache.threads.Purge()
+		store.cache.slugs.Purge()
+		return
+	}
+
+	kind, name := key, ""
+	if i := strings.IndexByte(key, ':'); i >= 0 {
+		kind, name = key[:i], key[i+1:]
+	}
+
+	var c *cache.Cache
+	switch kind {
+	case cacheKindUser:
+		c = store.cache.users
+	case cacheKindForum:
+		c = store.cache.forums
+	case cacheKindThread:
+		c = store.cache.threads
+	default:
+		return
+	}
+	if name == cacheKeyAll {
+		c.Purge()
+	} else {
+		c.Delete(name)
+	}
+}
+
+func userCacheKey(nickname string) string {
+	return cacheKindUser + ":" + strings.ToLower(nickname)
+}
+
+func forumCacheKey(slug string) string {
+	return cacheKindForum + ":" + strings.ToLower(slug)
+}
+
+func threadCacheKey(id int) string {
+	return cacheKindThread + ":" + strconv.Itoa(id)
+}
+
+// Forums and threads are cached outside of transactions only. Like users,
+// requests reading their own writes skip the cache and only rows read from
+// the primary are put into it.
+func (store *Storage) readCached(tx *sql.Tx) bool {
+	return tx == nil && store.cache != nil && !store.readPrimary
+}
+
+func (store *Storage) fillCached(tx *sql.Tx) bool {
+	return tx == nil && store.cache != nil && store.fromPrimary(tx)
+}
@@ -0,0 +1,165 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"sync/atomic"
+	"techpark_db/internal/domain/entity"
+	"techpark_db/internal/infra/cache"
+	"testing"
+	"time"
+)
+
+func TestGetUserOldNicknameAfterUpdate(t *testing.T) {
+	store := testStorage(t)
+	store.EnableCache(cache.DEFAULT_SIZE, cache.DEFAULT_TTL)
+	seedThread(t, store)
+
+	err := store.RunInTx(context.Background(), sql.LevelReadCommitted, func(tx *sql.Tx) error {
+		return store.RenameUser(tx, "bench", "renamed")
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// fills the cache through the history
+	if _, err := store.GetUser(nil, "bench"); err != nil {
+		t.Fatal(err)
+	}
+
+	err = store.RunInTx(context.Background(), sql.LevelReadCommitted, func(tx *sql.Tx) error {
+		_, err := store.UpdateUser(tx, entity.UpdateUser{About: "updated"}, "renamed", 0)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, nickname := range []string{"bench", "renamed"} {
+		user, err := store.GetUser(nil, nickname)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if user.Nickname != "renamed" || user.About != "updated" {
+			t.Fatalf("GetUser(%q) = %s with about %q, want the updated renamed user", nickname, user.Nickname, user.About)
+		}
+	}
+}
+
+func TestCachedForumAndThreadAfterWrites(t *testing.T) {
+	store := testStorage(t)
+	store.EnableCache(cache.DEFAULT_SIZE, cache.DEFAULT_TTL)
+	forum, thread := seedThread(t, store)
+
+	// fill the cache before every write
+	read := func() (*entity.Forum, *entity.Thread) {
+		t.Helper()
+		f, err := store.GetForum(nil, forum)
+		if err != nil {
+			t.Fatal(err)
+		}
+		th, err := store.GetThread(nil, strconv.Itoa(thread))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return f, th
+	}
+	read()
+
+	savePosts(t, store, (*Storage).SavePosts, newPosts(2, 0), forum, thread)
+	if f, _ := read(); f.Posts != 2 {
+		t.Errorf("forum counts %d posts after the batch, want 2", f.Posts)
+	}
+
+	err := store.RunInTx(context.Background(), sql.LevelReadCommitted, func(tx *sql.Tx) error {
+		return store.SetVote(tx, entity.Vote{Nickname: "bench", Voice: 1, IdThread: thread})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, th := read(); th.Votes != 1 {
+		t.Errorf("thread counts %d votes after the vote, want 1", th.Votes)
+	}
+
+	err = store.RunInTx(context.Background(), sql.LevelReadCommitted, func(tx *sql.Tx) error {
+		_, err := store.UpdateThread(tx, thread, "", "updated", 0)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, th := read(); th.Message != "updated" {
+		t.Errorf("thread message %q after the update, want %q", th.Message, "updated")
+	}
+
+	status := store.CacheStatus()
+	if status.Forum.Hits == 0 || status.Thread.Hits == 0 {
+		t.Errorf("no forum or thread hits in %+v", status)
+	}
+}
+
+// BenchmarkWriteCommits runs post batches and votes in parallel, each worker
+// in a forum of its own, with and without the cache. The commits of these
+// transactions must not wait for each other on cache notifications.
+func BenchmarkWriteCommits(b *testing.B) {
+	for _, cached := range []bool{false, true} {
+		name := "nocache"
+		if cached {
+			name = "cache"
+		}
+		b.Run("posts/"+name, func(b *testing.B) {
+			store, threads := seedForums(b, cached)
+			runWrites(b, store, threads, func(tx *sql.Tx, thread entity.Thread) error {
+				_, err := store.SavePosts(tx, newPosts(10, 0), thread.Forum, thread.Id, time.Now().Format(time.RFC3339Nano))
+				return err
+			})
+		})
+		b.Run("votes/"+name, func(b *testing.B) {
+			store, threads := seedForums(b, cached)
+			runWrites(b, store, threads, func(tx *sql.Tx, thread entity.Thread) error {
+				return store.SetVote(tx, entity.Vote{Nickname: "bench", Voice: 1, IdThread: thread.Id})
+			})
+		})
+	}
+}
+
+// seedForums makes a forum with a thread for every benchmark worker.
+func seedForums(b *testing.B, cached bool) (*Storage, []entity.Thread) {
+	store := testStorage(b)
+	if cached {
+		store.EnableCache(cache.DEFAULT_SIZE, cache.DEFAULT_TTL)
+	}
+	seedThread(b, store)
+
+	threads := make([]entity.Thread, 64)
+	for i := range threads {
+		forum := entity.CreateForum{Title: "Bench", User: "bench", Slug: "bench" + strconv.Itoa(i)}
+		if err := store.SaveForum(nil, forum); err != nil {
+			b.Fatal(err)
+		}
+		thread := entity.CreateThread{Title: "Bench", Author: "bench", Message: "message", Created: time.Now().Format(time.RFC3339Nano)}
+		id, err := store.SaveThread(nil, thread, forum.Slug)
+		if err != nil {
+			b.Fatal(err)
+		}
+		threads[i] = entity.Thread{Id: id, Forum: forum.Slug}
+	}
+	return store, threads
+}
+
+func runWrites(b *testing.B, store *Storage, threads []entity.Thread, write func(tx *sql.Tx, thread entity.Thread) error) {
+	var worker int32
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		thread := threads[int(atomic.AddInt32(&worker, 1)-1)%len(threads)]
+		for pb.Next() {
+			err := store.RunInTx(context.Background(), sql.LevelReadCommitted, func(tx *sql.Tx) error {
+				return write(tx, thread)
+			})
+			if err != nil {
+				b.Error(err)
+				return
+			}
+		}
+	})
+}
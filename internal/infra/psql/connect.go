@@ -4,6 +4,8 @@ import (
 	"database/sql"
 	"fmt"
 	log "github.com/sirupsen/logrus"
+	"os"
+	"strings"
 	"time"
 )
 
@@ -14,19 +16,32 @@ const (
 	PG_DBNAME   = "forum_db"
 	PG_USER     = "root"
 	PG_PASSWORD = "love"
+
+	// PG_REPLICAS_ENV holds comma-separated DSNs of the read replicas.
+	PG_REPLICAS_ENV = "PG_REPLICAS"
 )
 
-func Connect() (*sql.DB, error) {
-	config := fmt.Sprintf("host=%s port=%s user=%s "+
+func DSN() string {
+	return fmt.Sprintf("host=%s port=%s user=%s "+
 		"password=%s dbname=%s sslmode=disable",
 		PG_HOST, PG_PORT, PG_USER, PG_PASSWORD, PG_DBNAME)
+}
 
-	db, err := sql.Open("postgres", config)
+func open(dsn string) (*sql.DB, error) {
+	db, err := sql.Open("postgres", dsn)
 	if err != nil {
 		return nil, err
 	}
 	db.SetMaxOpenConns(150)
 	db.SetMaxIdleConns(50)
+	return db, nil
+}
+
+func Connect() (*sql.DB, error) {
+	db, err := open(DSN())
+	if err != nil {
+		return nil, err
+	}
 
 	for i := 0; i < 15; i++ {
 		err = db.Ping()
@@ -38,3 +53,23 @@ func Connect() (*sql.DB, error) {
 	}
 	return nil, err
 }
+
+// ConnectReplicas opens the replicas listed in PG_REPLICAS_ENV. They are not
+// waited for: Storage.CheckReplicas adds them to rotation once they answer.
+func ConnectReplicas() ([]*sql.DB, error) {
+	replicas := make([]*sql.DB, 0)
+	for _, dsn := range strings.Split(os.Getenv(PG_REPLICAS_ENV), ",") {
+		if dsn = strings.TrimSpace(dsn); dsn == "" {
+			continue
+		}
+		db, err := open(dsn)
+		if err != nil {
+			for _, replica := range replicas {
+				replica.Close()
+			}
+			return nil, err
+		}
+		replicas = append(replicas, db)
+	}
+	return replicas, nil
+}
@@ -0,0 +1,312 @@
+package psql
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+	"techpark_db/internal/domain/entity"
+)
+
+var ErrImportUserConflict = errors.New("email of imported user is registered by another user")
+
+/*====================== EXPORT ======================*/
+
+// An empty forum slug in the export queries selects the whole database.
+
+const queryExportUsers = `
+SELECT Nickname, Fullname, About, Email FROM Users
+WHERE $1::citext = '' OR Nickname IN (
+    SELECT Nickname FROM Forum WHERE Slug = $1::citext
+    UNION
+    SELECT Nickname FROM UsersForum WHERE Forum = $1::citext
+    UNION
+    SELECT Vote.Nickname FROM Vote JOIN Thread ON Thread.Id = Vote.IdThread WHERE Thread.Forum = $1::citext
+)
+ORDER BY Nickname
+`
+
+func (store *Storage) ExportUsers(tx *sql.Tx, forum string, fn func(user *entity.User) error) error {
+	rows, err := store.query(tx, queryExportUsers, forum)
+	if err != nil {
+		log.Error(err, "[forum ", forum, "]")
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		user := entity.User{}
+		if err := rows.Scan(&user.Nickname, &user.Fullname, &user.About, &user.Email); err != nil {
+			return err
+		}
+		if err := fn(&user); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+const queryExportForums = `
+SELECT Slug, Title, Nickname, Posts, Threads FROM Forum
+WHERE $1::citext = '' OR Slug = $1::citext
+ORDER BY Slug
+`
+
+func (store *Storage) ExportForums(tx *sql.Tx, forum string, fn func(forum *entity.Forum) error) error {
+	rows, err := store.query(tx, queryExportForums, forum)
+	if err != nil {
+		log.Error(err, "[forum ", forum, "]")
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		f := entity.Forum{}
+		if err := rows.Scan(&f.Slug, &f.Title, &f.User, &f.Posts, &f.Threads); err != nil {
+			return err
+		}
+		if err := fn(&f); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+const queryExportThreads = `
+SELECT Id, Title, Author, Forum, Message, Votes, Slug, Created FROM Thread
+WHERE $1::citext = '' OR Forum = $1::citext
+ORDER BY Id
+`
+
+func (store *Storage) ExportThreads(tx *sql.Tx, forum string, fn func(thread *entity.Thread) error) error {
+	rows, err := store.query(tx, queryExportThreads, forum)
+	if err != nil {
+		log.Error(err, "[forum ", forum, "]")
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		thread := entity.Thread{}
+		if err := rows.Scan(&thread.Id, &thread.Title, &thread.Author, &thread.Forum, &thread.Message, &thread.Votes, &thread.Slug, &thread.Created); err != nil {
+			return err
+		}
+		if err := fn(&thread); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// Posts are ordered by TreePath inside a thread, so a parent is always
+// exported before its replies.
+const queryExportPosts = `
+SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created FROM Posts
+WHERE $1::citext = '' OR Forum = $1::citext
+ORDER BY Thread, TreePath
+`
+
+func (store *Storage) ExportPosts(tx *sql.Tx, forum string, fn func(post *entity.Post) error) error {
+	rows, err := store.query(tx, queryExportPosts, forum)
+	if err != nil {
+		log.Error(err, "[forum ", forum, "]")
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		post := entity.Post{}
+		if err := rows.Scan(&post.Id, &post.Parent, &post.Author, &post.Message, &post.IsEdited, &post.Forum, &post.Thread, &post.Created); err != nil {
+			return err
+		}
+		if err := fn(&post); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+const queryExportVotes = `
+SELECT IdThread, Thread.Slug, Nickname, Voice FROM Vote
+JOIN Thread ON Thread.Id = Vote.IdThread
+WHERE $1::citext = '' OR Thread.Forum = $1::citext
+ORDER BY IdThread, Nickname
+`
+
+func (store *Storage) ExportVotes(tx *sql.Tx, forum string, fn func(vote *entity.Vote) error) error {
+	rows, err := store.query(tx, queryExportVotes, forum)
+	if err != nil {
+		log.Error(err, "[forum ", forum, "]")
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		vote := entity.Vote{}
+		if err := rows.Scan(&vote.IdThread, &vote.SlugThread, &vote.Nickname, &vote.Voice); err != nil {
+			return err
+		}
+		if err := fn(&vote); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+/*====================== IMPORT ======================*/
+
+const queryImportUser = `
+INSERT INTO Users(Nickname, Fullname, About, Email) VALUES ($1, $2, $3, $4)
+ON CONFLICT DO NOTHING
+`
+const queryCheckImportedUser = "SELECT count(*) FROM Users WHERE Nickname = $1"
+
+// ImportUser keeps an existing user with the same nickname untouched.
+func (store *Storage) ImportUser(tx *sql.Tx, user entity.User) error {
+	if _, err := store.exec(tx, queryImportUser, user.Nickname, user.Fullname, user.About, user.Email); err != nil {
+		return err
+	}
+	row := store.queryRow(tx, queryCheckImportedUser, user.Nickname)
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrImportUserConflict
+	}
+	return nil
+}
+
+const queryImportForum = `
+INSERT INTO Forum(Slug, Title, Nickname) VALUES ($1, $2, $3)
+ON CONFLICT DO NOTHING
+`
+
+func (store *Storage) ImportForum(tx *sql.Tx, forum entity.Forum) error {
+	_, err := store.exec(tx, queryImportForum, forum.Slug, forum.Title, forum.User)
+	return err
+}
+
+// Candidates are ordered so that a row which kept the exported id comes first.
+const queryFindImportedThreads = `
+SELECT Id FROM Thread
+WHERE (Slug <> '' AND Slug = $5) OR (Forum = $1 AND Author = $2 AND Title = $3 AND Created = $4::TIMESTAMP WITH TIME ZONE)
+ORDER BY Id <> $6, Id
+`
+
+// FindImportedThreads returns ids of threads which may already hold the
+// imported thread: the one with the same slug or the same forum, author,
+// title and creation time.
+func (store *Storage) FindImportedThreads(tx *sql.Tx, thread entity.Thread) ([]int, error) {
+	rows, err := store.query(tx, queryFindImportedThreads, thread.Forum, thread.Author, thread.Title, thread.Created, thread.Slug, thread.Id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]int, 0)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+const queryImportThread = `
+INSERT INTO Thread(Id, Title, Author, Forum, Message, Slug, Created)
+VALUES (
+    CASE WHEN EXISTS (SELECT 1 FROM Thread WHERE Id = $1) THEN nextval(pg_get_serial_sequence('thread', 'id')) ELSE $1 END,
+    $2, $3, $4, $5, $6, $7::TIMESTAMP WITH TIME ZONE
+)
+RETURNING Id
+`
+
+// ImportThread saves the thread under its exported id when that id is free.
+func (store *Storage) ImportThread(tx *sql.Tx, thread entity.Thread) (int, error) {
+	row := store.queryRow(tx, queryImportThread, thread.Id, thread.Title, thread.Author, thread.Forum, thread.Message, thread.Slug, thread.Created)
+	var id int
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+const queryFindImportedPosts = `
+SELECT Id FROM Posts
+WHERE Thread = $1 AND Parent = $2 AND Author = $3 AND Message = $4 AND Created = $5::TIMESTAMP WITH TIME ZONE
+ORDER BY Id <> $6, Id
+`
+
+// FindImportedPosts returns ids of posts with the same thread, parent, author,
+// message and creation time as the imported post.
+func (store *Storage) FindImportedPosts(tx *sql.Tx, post entity.Post) ([]int, error) {
+	rows, err := store.query(tx, queryFindImportedPosts, post.Thread, post.Parent, post.Author, post.Message, post.Created, post.Id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]int, 0)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+const queryImportPost = `
+INSERT INTO Posts(Id, Parent, Author, Message, IsEdited, Forum, Thread, Created)
+VALUES (
+    CASE WHEN EXISTS (SELECT 1 FROM Posts WHERE Id = $1) THEN nextval(pg_get_serial_sequence('posts', 'id')) ELSE $1 END,
+    $2, $3, $4, $5, $6, $7, $8::TIMESTAMP WITH TIME ZONE
+)
+RETURNING Id
+`
+
+// ImportPost saves the post under its exported id when that id is free.
+// The parent must be imported already: TreePath is built by the insert trigger.
+func (store *Storage) ImportPost(tx *sql.Tx, post entity.Post) (int, error) {
+	row := store.queryRow(tx, queryImportPost, post.Id, post.Parent, post.Author, post.Message, post.IsEdited, post.Forum, post.Thread, post.Created)
+	var id int
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+const queryRestartThreadSeq = "SELECT setval(pg_get_serial_sequence('thread', 'id'), GREATEST((SELECT max(Id) FROM Thread), 1))"
+const queryRestartPostsSeq = "SELECT setval(pg_get_serial_sequence('posts', 'id'), GREATEST((SELECT max(Id) FROM Posts), 1))"
+const queryRecountForums = `
+UPDATE Forum SET
+    Posts = (SELECT count(*) FROM Posts WHERE Posts.Forum = Forum.Slug),
+    Threads = (SELECT count(*) FROM Thread WHERE Thread.Forum = Forum.Slug)
+WHERE Slug = ANY($1::citext[])
+`
+const queryRecountVotes = `
+UPDATE Thread SET Votes = COALESCE((SELECT sum(Voice) FROM Vote WHERE IdThread = Thread.Id), 0)
+WHERE Forum = ANY($1::citext[])
+`
+
+// FinishImport moves the id sequences past the imported ids and recounts
+// the counters of the imported forums and their threads.
+func (store *Storage) FinishImport(tx *sql.Tx, forums []string) error {
+	if _, err := store.exec(tx, queryRestartThreadSeq); err != nil {
+		return err
+	}
+	if _, err := store.exec(tx, queryRestartPostsSeq); err != nil {
+		return err
+	}
+	if _, err := store.exec(tx, queryRecountForums, pq.Array(forums)); err != nil {
+		return err
+	}
+	if _, err := store.exec(tx, queryRecountVotes, pq.Array(forums)); err != nil {
+		return err
+	}
+	return store.invalidate(tx, cacheKeyAll)
+}
@@ -0,0 +1,96 @@
+package psql
+
+import (
+	"database/sql"
+	"github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"strings"
+	"techpark_db/internal/infra/feed"
+	"time"
+)
+
+const POSTS_CHANNEL = "forum_posts"
+
+// FEED_IDS_PER_NOTIFY keeps the notification payload of comma separated post
+// ids below the 8000 bytes Postgres allows.
+const FEED_IDS_PER_NOTIFY = 500
+
+// ListenPosts publishes the posts created by every instance sharing the
+// database to PostFeed. Only listening instances notify about their posts,
+// NOTIFY serializes the commits, so every instance serving the feed must
+// listen.
+func (store *Storage) ListenPosts(dsn string) error {
+	listener := pq.NewListener(dsn, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Warning("posts listener: ", err)
+		}
+	})
+	if err := listener.Listen(POSTS_CHANNEL); err != nil {
+		listener.Close()
+		return err
+	}
+	store.feed = feed.New()
+
+	go func() {
+		primary := store.Primary()
+		for notification := range listener.Notify {
+			// nil is sent after a reconnect, notifications may have been lost
+			if notification == nil {
+				log.Warning("posts listener reconnected, the feed may have missed posts")
+				continue
+			}
+			if store.feed.Len() == 0 {
+				continue
+			}
+			posts, err := primary.GetPostsByIds(nil, postIds(notification.Extra))
+			if err != nil {
+				log.Error(err)
+				continue
+			}
+			store.feed.Publish(posts)
+		}
+	}()
+	return nil
+}
+
+// PostFeed is the feed of created posts, nil until ListenPosts.
+func (store *Storage) PostFeed() *feed.Feed {
+	return store.feed
+}
+
+const queryNotifyPosts = "SELECT pg_notify('" + POSTS_CHANNEL + "', $1)"
+
+// notifyPosts sends the ids of the created posts to the listeners when tx
+// commits.
+func (store *Storage) notifyPosts(tx *sql.Tx, ids []int) error {
+	if store.feed == nil {
+		return nil
+	}
+	for start := 0; start < len(ids); start += FEED_IDS_PER_NOTIFY {
+		end := start + FEED_IDS_PER_NOTIFY
+		if end > len(ids) {
+			end = len(ids)
+		}
+		payload := make([]string, 0, end-start)
+		for _, id := range ids[start:end] {
+			payload = append(payload, strconv.Itoa(id))
+		}
+		if _, err := store.exec(tx, queryNotifyPosts, strings.Join(payload, ",")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// postIds parses a notification payload.
+func postIds(payload string) []int {
+	parts := strings.Split(payload, ",")
+	ids := make([]int, 0, len(parts))
+	for _, part := range parts {
+		if id, err := strconv.Atoi(part); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
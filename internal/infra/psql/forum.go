@@ -3,44 +3,51 @@ package psql
 import (
 	"database/sql"
 	log "github.com/sirupsen/logrus"
+	"strings"
 	"techpark_db/internal/domain/entity"
 )
 
 const querySaveForum = "INSERT INTO Forum(Slug, Title, Nickname) VALUES ($1, $2, $3)"
 
 func (store *Storage) SaveForum(tx *sql.Tx, forum entity.CreateForum) error {
-	if _, err := tx.Exec(querySaveForum, forum.Slug, forum.Title, forum.User); err != nil {
+	if _, err := store.exec(tx, querySaveForum, forum.Slug, forum.Title, forum.User); err != nil {
 		return err
 	}
 	return nil
 }
 
-const queryGetForum = "SELECT Slug, Title, Nickname, Posts, Threads FROM Forum WHERE Slug = $1"
+const queryGetForum = "SELECT Slug, Title, Nickname, Posts, Threads, Version, Modified FROM Forum WHERE Slug = $1"
 
 func (store *Storage) GetForum(tx *sql.Tx, slug string) (*entity.Forum, error) {
-	var row *sql.Row
-	if row == nil {
-		row = store.DB.QueryRow(queryGetForum, slug)
-	} else {
-		row = tx.QueryRow(queryGetForum, slug)
+	if store.readCached(tx) {
+		if value, ok := store.cache.forums.Get(strings.ToLower(slug)); ok {
+			forum := value.(entity.Forum)
+			return &forum, nil
+		}
 	}
+
+	row := store.queryRow(tx, queryGetForum, slug)
 	forum := entity.Forum{}
-	if err := row.Scan(&forum.Slug, &forum.Title, &forum.User, &forum.Posts, &forum.Threads); err != nil {
+	if err := row.Scan(&forum.Slug, &forum.Title, &forum.User, &forum.Posts, &forum.Threads, &forum.Version, &forum.Modified); err != nil {
 		//log.Info(err, "[slug: ", slug, "]")
 		return nil, err
 	}
+
+	if store.fillCached(tx) {
+		store.cache.forums.Set(strings.ToLower(slug), forum)
+	}
 	return &forum, nil
 }
 
 const queryGetForumThreads = `
-SELECT Id, Title, Author, Forum, Message, Votes, Slug, Created FROM Thread
+SELECT Id, Title, Author, Forum, Message, Votes, Slug, Created, Version, Modified FROM Thread
 WHERE Forum = $1 AND Created >= $2::TIMESTAMP WITH TIME ZONE
 ORDER BY Created 
 LIMIT $3
 `
 
 const queryGetForumThreadsDesc = `
-SELECT Id, Title, Author, Forum, Message, Votes, Slug, Created FROM Thread
+SELECT Id, Title, Author, Forum, Message, Votes, Slug, Created, Version, Modified FROM Thread
 WHERE Forum = $1 AND Created <= $2::TIMESTAMP WITH TIME ZONE
 ORDER BY Created DESC
 LIMIT $3
@@ -50,9 +57,9 @@ func (store *Storage) GetForumThreads(tx *sql.Tx, slug string, order string, lim
 	var rows *sql.Rows
 	var err error
 	if order == "ASC" {
-		rows, err = store.DB.Query(queryGetForumThreads, slug, since, limit)
+		rows, err = store.query(tx, queryGetForumThreads, slug, since, limit)
 	} else {
-		rows, err = store.DB.Query(queryGetForumThreadsDesc, slug, since, limit)
+		rows, err = store.query(tx, queryGetForumThreadsDesc, slug, since, limit)
 	}
 
 	if err != nil {
@@ -64,7 +71,7 @@ func (store *Storage) GetForumThreads(tx *sql.Tx, slug string, order string, lim
 	threads := make([]entity.Thread, 0)
 	for rows.Next() {
 		thread := entity.Thread{}
-		if err := rows.Scan(&thread.Id, &thread.Title, &thread.Author, &thread.Forum, &thread.Message, &thread.Votes, &thread.Slug, &thread.Created); err != nil {
+		if err := rows.Scan(&thread.Id, &thread.Title, &thread.Author, &thread.Forum, &thread.Message, &thread.Votes, &thread.Slug, &thread.Created, &thread.Version, &thread.Modified); err != nil {
 			log.Error(err)
 			return nil, err
 		}
@@ -111,24 +118,8 @@ LIMIT $2
 `
 
 func (store *Storage) GetForumUsers(tx *sql.Tx, slug string, order string, limit int, since string) (*[]entity.User, error) {
-	var rows *sql.Rows
-	var err error
-	if since == "" {
-		if order == "ASC" {
-			rows, err = store.DB.Query(queryGetForumUsers, slug, limit)
-		} else {
-			rows, err = store.DB.Query(queryGetForumUsersDesc, slug, limit)
-		}
-	} else {
-		if order == "ASC" {
-			rows, err = store.DB.Query(queryGetForumUsersSince, slug, limit, since)
-		} else {
-			rows, err = store.DB.Query(queryGetForumUsersSinceDesc, slug, limit, since)
-		}
-	}
-
+	rows, err := store.queryForumUsers(tx, slug, order, limit, since)
 	if err != nil {
-		log.Error(err)
 		return nil, err
 	}
 	defer rows.Close()
@@ -148,3 +139,48 @@ func (store *Storage) GetForumUsers(tx *sql.Tx, slug string, order string, limit
 
 	return &users, nil
 }
+
+// StreamForumUsers calls fn for every user of the page as it is read from
+// the DB, the page is never held in memory. fn must not keep the user.
+func (store *Storage) StreamForumUsers(tx *sql.Tx, slug string, order string, limit int, since string, fn func(*entity.User) error) error {
+	rows, err := store.queryForumUsers(tx, slug, order, limit, since)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	user := entity.User{}
+	for rows.Next() {
+		if err := rows.Scan(&user.Nickname, &user.Fullname, &user.About, &user.Email); err != nil {
+			return err
+		}
+		if err := fn(&user); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (store *Storage) queryForumUsers(tx *sql.Tx, slug string, order string, limit int, since string) (*sql.Rows, error) {
+	var rows *sql.Rows
+	var err error
+	if since == "" {
+		if order == "ASC" {
+			rows, err = store.query(tx, queryGetForumUsers, slug, limit)
+		} else {
+			rows, err = store.query(tx, queryGetForumUsersDesc, slug, limit)
+		}
+	} else {
+		if order == "ASC" {
+			rows, err = store.query(tx, queryGetForumUsersSince, slug, limit, since)
+		} else {
+			rows, err = store.query(tx, queryGetForumUsersSinceDesc, slug, limit, since)
+		}
+	}
+
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	return rows, nil
+}
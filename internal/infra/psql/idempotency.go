@@ -0,0 +1,86 @@
+package psql
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+var ErrIdempotencyKeyReused = errors.New("idempotency key is used with another request")
+var ErrIdempotencyKeyInProgress = errors.New("request with the idempotency key is in progress")
+
+// IdempotentResponse is the stored response of the first request with a key.
+// ETag and Location are the headers a replay needs besides the body.
+type IdempotentResponse struct {
+	Status      int
+	ContentType string
+	ETag        string
+	Location    string
+	Body        []byte
+}
+
+// Status 0 marks a key claimed by a request still in progress. The claim
+// holds until LeaseUntil: a request that died with the process leaves its
+// key to a retry of the same request once the lease has expired.
+// An expired key is claimed again as if it were new.
+const queryClaimIdempotencyKey = `
+INSERT INTO Idempotency AS i (Key, RequestHash, LeaseUntil) VALUES ($1, $2, now() + $4 * interval '1 second')
+ON CONFLICT (Key) DO UPDATE SET RequestHash = $2, Status = 0, ContentType = '', ETag = '', Location = '', Body = '', Created = now(),
+    LeaseUntil = now() + $4 * interval '1 second'
+WHERE i.Created < now() - $3 * interval '1 second'
+   OR (i.Status = 0 AND i.RequestHash = $2 AND i.LeaseUntil < now())
+RETURNING LeaseUntil
+`
+const queryGetIdempotentResponse = "SELECT RequestHash, Status, ContentType, ETag, Location, Body FROM Idempotency WHERE Key = $1"
+
+// ClaimIdempotencyKey returns the lease when the key is claimed by the request,
+// or the stored response of the first request with the same hash.
+func (store *Storage) ClaimIdempotencyKey(key string, hash []byte, ttl, lease time.Duration) (*IdempotentResponse, time.Time, error) {
+	var leaseUntil time.Time
+	err := store.primary.scan(queryClaimIdempotencyKey, []interface{}{key, hash, ttl.Seconds(), lease.Seconds()}, []interface{}{&leaseUntil})
+	if err == nil {
+		return nil, leaseUntil, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, time.Time{}, err
+	}
+
+	var storedHash []byte
+	resp := IdempotentResponse{}
+	err = store.primary.scan(queryGetIdempotentResponse, []interface{}{key}, []interface{}{&storedHash, &resp.Status, &resp.ContentType, &resp.ETag, &resp.Location, &resp.Body})
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	if !bytes.Equal(storedHash, hash) {
+		return nil, time.Time{}, ErrIdempotencyKeyReused
+	}
+	if resp.Status == 0 {
+		return nil, time.Time{}, ErrIdempotencyKeyInProgress
+	}
+	return &resp, time.Time{}, nil
+}
+
+// The lease identifies the claim: a request outlived by its lease must not
+// touch the key taken over by a retry.
+const querySaveIdempotentResponse = "UPDATE Idempotency SET Status = $3, ContentType = $4, ETag = $5, Location = $6, Body = $7 WHERE Key = $1 AND Status = 0 AND LeaseUntil = $2"
+
+func (store *Storage) SaveIdempotentResponse(key string, leaseUntil time.Time, resp IdempotentResponse) error {
+	_, err := store.exec(nil, querySaveIdempotentResponse, key, leaseUntil, resp.Status, resp.ContentType, resp.ETag, resp.Location, resp.Body)
+	return err
+}
+
+const queryReleaseIdempotencyKey = "DELETE FROM Idempotency WHERE Key = $1 AND Status = 0 AND LeaseUntil = $2"
+
+// ReleaseIdempotencyKey lets the request with the key run again.
+func (store *Storage) ReleaseIdempotencyKey(key string, leaseUntil time.Time) error {
+	_, err := store.exec(nil, queryReleaseIdempotencyKey, key, leaseUntil)
+	return err
+}
+
+const querySweepIdempotencyKeys = "DELETE FROM Idempotency WHERE Created < now() - $1 * interval '1 second'"
+
+func (store *Storage) SweepIdempotencyKeys(ttl time.Duration) error {
+	_, err := store.exec(nil, querySweepIdempotencyKeys, ttl.Seconds())
+	return err
+}
@@ -0,0 +1,47 @@
+package psql
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClaimIdempotencyKeyAfterLease(t *testing.T) {
+	store := testStorage(t)
+	hash := []byte("request")
+	lease := time.Second
+
+	_, dead, err := store.ClaimIdempotencyKey("key", hash, time.Hour, lease)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = store.ClaimIdempotencyKey("key", hash, time.Hour, lease); err != ErrIdempotencyKeyInProgress {
+		t.Fatalf("claim within the lease: %v, want %v", err, ErrIdempotencyKeyInProgress)
+	}
+
+	time.Sleep(lease + 100*time.Millisecond)
+	if _, _, err = store.ClaimIdempotencyKey("key", []byte("another"), time.Hour, lease); err != ErrIdempotencyKeyReused {
+		t.Fatalf("another request after the lease: %v, want %v", err, ErrIdempotencyKeyReused)
+	}
+	resp, retry, err := store.ClaimIdempotencyKey("key", hash, time.Hour, lease)
+	if err != nil || resp != nil {
+		t.Fatalf("retry after the lease: %v, %v", resp, err)
+	}
+
+	// the request outlived by its lease leaves the key to the retry
+	if err = store.ReleaseIdempotencyKey("key", dead); err != nil {
+		t.Fatal(err)
+	}
+	if err = store.SaveIdempotentResponse("key", dead, IdempotentResponse{Status: 500}); err != nil {
+		t.Fatal(err)
+	}
+	if err = store.SaveIdempotentResponse("key", retry, IdempotentResponse{Status: 201, Body: []byte("{}")}); err != nil {
+		t.Fatal(err)
+	}
+	resp, _, err = store.ClaimIdempotencyKey("key", hash, time.Hour, lease)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp == nil || resp.Status != 201 {
+		t.Fatalf("stored response %+v, want status 201", resp)
+	}
+}
@@ -4,27 +4,53 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"github.com/lib/pq"
 	log "github.com/sirupsen/logrus"
 	"techpark_db/internal/domain/entity"
 )
 
-const queryCheckParentPost = "SELECT count(Id) FROM Posts WHERE Id = $1 AND Thread = $2"
+const queryCheckParentPosts = "SELECT count(Id) FROM Posts WHERE Id = ANY($1) AND Thread = $2"
 
-func (store *Storage) CheckParentPost(tx *sql.Tx, parent int, threadId int) (bool, error) {
-	row := tx.QueryRow(queryCheckParentPost, parent, threadId)
+// CheckParentPosts reports whether all the distinct parents are posts of the thread.
+func (store *Storage) CheckParentPosts(tx *sql.Tx, parents []int, threadId int) (bool, error) {
+	row := store.queryRow(tx, queryCheckParentPosts, pq.Array(parents), threadId)
 	var count int
 	if err := row.Scan(&count); err != nil {
 		return false, err
 	}
-	if count == 0 {
+	if count != len(parents) {
 		return false, nil
 	}
 	return true, nil
 }
 
+var ErrParentNotFound = errors.New("parent post was created in another thread")
+
 const querySavePost = "INSERT INTO Posts(Parent, Author, Message, Forum, Thread, Created) VALUES "
 
+// SavePosts inserts a batch of posts and returns their ids in the batch order.
+// Batches of BULK_POSTS_THRESHOLD posts and more go through COPY.
 func (store *Storage) SavePosts(tx *sql.Tx, posts []entity.CreatePost, forum string, thread int, created string) (*[]int, error) {
+	if err := store.invalidate(tx, forumCacheKey(forum)); err != nil {
+		return nil, err
+	}
+	var ids *[]int
+	var err error
+	if len(posts) >= BULK_POSTS_THRESHOLD {
+		ids, err = store.savePostsCopy(tx, posts, forum, thread, created)
+	} else {
+		ids, err = store.savePostsInsert(tx, posts, forum, thread, created)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := store.notifyPosts(tx, *ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (store *Storage) savePostsInsert(tx *sql.Tx, posts []entity.CreatePost, forum string, thread int, created string) (*[]int, error) {
 	query := querySavePost
 	args := make([]interface{}, 0, len(posts))
 	for i, post := range posts {
@@ -56,6 +82,121 @@ func (store *Storage) SavePosts(tx *sql.Tx, posts []entity.CreatePost, forum str
 	return &ids, nil
 }
 
+// Ids are taken from the Posts sequence while copying, so they grow in the batch order.
+const queryCreatePostsStaging = `
+CREATE TEMP TABLE IF NOT EXISTS PostsStaging
+(
+    Id           int               NOT NULL DEFAULT nextval('posts_id_seq'),
+    Parent       int               NOT NULL,
+    Author       citext            NOT NULL,
+    Message      text              NOT NULL,
+    Forum        citext            NOT NULL,
+    Thread       int               NOT NULL,
+    Created      timestamp WITH TIME ZONE NOT NULL
+) ON COMMIT DELETE ROWS
+`
+const queryStartBulkInsert = "SET LOCAL forum.bulk_insert = on"
+const queryStopBulkInsert = "SET LOCAL forum.bulk_insert = off"
+const queryCheckStagingParents = `
+SELECT count(*) FROM PostsStaging
+WHERE Parent <> 0 AND NOT EXISTS (
+    SELECT 1 FROM Posts WHERE Posts.Id = PostsStaging.Parent AND Posts.Thread = PostsStaging.Thread
+)
+`
+const queryMoveStagingPosts = `
+INSERT INTO Posts(Id, Parent, Author, Message, Forum, Thread, Created, TreePath)
+SELECT PostsStaging.Id, PostsStaging.Parent, PostsStaging.Author, PostsStaging.Message,
+       PostsStaging.Forum, PostsStaging.Thread, PostsStaging.Created,
+       COALESCE(Posts.TreePath, ARRAY[] :: INT[]) || PostsStaging.Id
+FROM PostsStaging
+LEFT JOIN Posts ON Posts.Id = PostsStaging.Parent
+`
+const queryStagingUsersForum = `
+INSERT INTO UsersForum(Forum, Nickname)
+SELECT DISTINCT Forum, Author FROM PostsStaging
+ON CONFLICT ON CONSTRAINT usersforum_pkey
+DO NOTHING
+`
+const queryGetStagingIds = "SELECT Id FROM PostsStaging ORDER BY Id"
+const queryClearStaging = "TRUNCATE PostsStaging"
+
+// savePostsCopy streams the batch into a staging table with COPY and moves it
+// into Posts with one INSERT ... SELECT. The row triggers of Posts are switched
+// off for the transaction by forum.bulk_insert, so TreePath, the forum counter
+// and UsersForum are maintained here once per batch.
+func (store *Storage) savePostsCopy(tx *sql.Tx, posts []entity.CreatePost, forum string, thread int, created string) (*[]int, error) {
+	if _, err := tx.Exec(queryCreatePostsStaging); err != nil {
+		return nil, err
+	}
+
+	stmt, err := tx.Prepare(pq.CopyIn("postsstaging", "parent", "author", "message", "forum", "thread", "created"))
+	if err != nil {
+		return nil, err
+	}
+	for _, post := range posts {
+		if _, err := stmt.Exec(post.Parent, post.Author, post.Message, forum, thread, created); err != nil {
+			stmt.Close()
+			return nil, err
+		}
+	}
+	if _, err := stmt.Exec(); err != nil {
+		stmt.Close()
+		return nil, err
+	}
+	if err := stmt.Close(); err != nil {
+		return nil, err
+	}
+
+	var orphans int
+	if err := tx.QueryRow(queryCheckStagingParents).Scan(&orphans); err != nil {
+		return nil, err
+	}
+	if orphans > 0 {
+		return nil, ErrParentNotFound
+	}
+
+	if _, err := tx.Exec(queryStartBulkInsert); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(queryMoveStagingPosts); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(queryStopBulkInsert); err != nil {
+		return nil, err
+	}
+	if _, err := store.exec(tx, queryAddForumCounters, forum, len(posts), 0); err != nil {
+		return nil, err
+	}
+	if _, err := tx.Exec(queryStagingUsersForum); err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(queryGetStagingIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]int, 0, len(posts))
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	if _, err := tx.Exec(queryClearStaging); err != nil {
+		return nil, err
+	}
+	return &ids, nil
+}
+
 //const queryGetPosts = "SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created FROM Posts WHERE Created = $1::TIMESTAMP WITH TIME ZONE ORDER BY Id"
 //
 //func (store *Storage) GetPostsByCreated(tx *sql.Tx, created string) (*[]entity.Post, error) {
@@ -83,74 +224,92 @@ func (store *Storage) SavePosts(tx *sql.Tx, posts []entity.CreatePost, forum str
 //	return &posts, nil
 //}
 
-const queryGetPostById = "SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created FROM Posts WHERE Id = $1"
+const queryGetPostById = "SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created, Version, Modified FROM Posts WHERE Id = $1"
 
 func (store *Storage) GetPostById(tx *sql.Tx, id int) (*entity.Post, error) {
-	var row *sql.Row
-	if tx != nil {
-		row = tx.QueryRow(queryGetPostById, id)
-	} else {
-		row = store.DB.QueryRow(queryGetPostById, id)
+	row := store.queryRow(tx, queryGetPostById, id)
+	var post entity.Post
+	if err := row.Scan(&post.Id, &post.Parent, &post.Author, &post.Message, &post.IsEdited, &post.Forum, &post.Thread, &post.Created, &post.Version, &post.Modified); err != nil {
+		return nil, err
 	}
+	return &post, nil
+}
+
+const queryUpdatePost = `
+UPDATE Posts SET Message = $2, IsEdited = true
+WHERE Id = $1 AND ($3 = 0 OR Version = $3)
+RETURNING Id, Parent, Author, Message, IsEdited, Forum, Thread, Created, Version, Modified
+`
+
+// UpdatePost changes the message of the post.
+// A non-zero version makes the update conditional, see ErrVersionMismatch.
+func (store *Storage) UpdatePost(tx *sql.Tx, id int, message string, version int) (*entity.Post, error) {
+	row := store.queryRow(tx, queryUpdatePost, id, message, version)
 	var post entity.Post
-	if err := row.Scan(&post.Id, &post.Parent, &post.Author, &post.Message, &post.IsEdited, &post.Forum, &post.Thread, &post.Created); err != nil {
+	if err := row.Scan(&post.Id, &post.Parent, &post.Author, &post.Message, &post.IsEdited, &post.Forum, &post.Thread, &post.Created, &post.Version, &post.Modified); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrVersionMismatch
+		}
 		return nil, err
 	}
 	return &post, nil
 }
 
-const queryUpdatePost = "UPDATE Posts SET Message = $2, IsEdited = true WHERE Id = $1"
+const querySplitPosts = `
+WITH Root AS (SELECT TreePath FROM Posts WHERE Id = $1)
+UPDATE Posts SET
+    Thread = $2,
+    Parent = CASE WHEN Posts.Id = $1 THEN 0 ELSE Posts.Parent END,
+    TreePath = Posts.TreePath[array_length(Root.TreePath, 1):array_length(Posts.TreePath, 1)]
+FROM Root
+WHERE Posts.Thread = $3 AND Posts.TreePath[1:array_length(Root.TreePath, 1)] = Root.TreePath
+`
 
-func (store *Storage) UpdatePost(tx *sql.Tx, id int, message string) error {
-	_, err := tx.Exec(queryUpdatePost, id, message)
-	return err
+// SplitPosts moves the subtree rooted at the post into another thread of the
+// same forum. The root becomes a top-level post and the TreePath of every moved
+// post is cut down to start at the new root. Returns the number of moved posts.
+func (store *Storage) SplitPosts(tx *sql.Tx, post entity.Post, thread int) (int, error) {
+	if err := store.lockThreads(tx, post.Thread, thread); err != nil {
+		return 0, err
+	}
+	res, err := store.exec(tx, querySplitPosts, post.Id, thread, post.Thread)
+	if err != nil {
+		return 0, err
+	}
+	posts, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(posts), nil
 }
 
-const queryGetPostsFlat = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created FROM Posts
+const queryGetPostsFlat = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created, Version FROM Posts
 WHERE Thread = $1
 ORDER BY Id
 LIMIT $2
 `
 
-const queryGetPostsFlatDesc = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created FROM Posts
+const queryGetPostsFlatDesc = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created, Version FROM Posts
 WHERE Thread = $1
 ORDER BY Id DESC
 LIMIT $2
 `
 
-const queryGetPostsFlatSince = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created FROM Posts
+const queryGetPostsFlatSince = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created, Version FROM Posts
 WHERE Thread = $1 AND Id > $3
 ORDER BY Id
 LIMIT $2
 `
 
-const queryGetPostsFlatSinceDesc = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created FROM Posts
+const queryGetPostsFlatSinceDesc = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created, Version FROM Posts
 WHERE Thread = $1 AND Id < $3
 ORDER BY Id DESC
 LIMIT $2
 `
 
 func (store *Storage) GetPostsByThreadFlat(tx *sql.Tx, thread int, limit int, since int, sort string, order string) (*[]entity.Post, error) {
-	var rows *sql.Rows
-	err := errors.New("undefined")
-	if since == 0 {
-		if order == "ASC" {
-			rows, err = store.DB.Query(queryGetPostsFlat, thread, limit)
-		}
-		if order == "DESC" {
-			rows, err = store.DB.Query(queryGetPostsFlatDesc, thread, limit)
-		}
-	} else {
-		if order == "ASC" {
-			rows, err = store.DB.Query(queryGetPostsFlatSince, thread, limit, since)
-		}
-		if order == "DESC" {
-			rows, err = store.DB.Query(queryGetPostsFlatSinceDesc, thread, limit, since)
-		}
-	}
-
+	rows, err := store.queryPostsFlat(tx, thread, limit, since, sort, order)
 	if err != nil {
-		log.Error(err, "[thread ", thread, "]", "[limit ", limit, "] [since ", since, "] [sort ", sort, "] [order ", order, "]")
 		return nil, err
 	}
 	defer rows.Close()
@@ -158,7 +317,7 @@ func (store *Storage) GetPostsByThreadFlat(tx *sql.Tx, thread int, limit int, si
 	posts := make([]entity.Post, 0, 100)
 	for rows.Next() {
 		post := entity.Post{}
-		if err := rows.Scan(&post.Id, &post.Parent, &post.Author, &post.Message, &post.IsEdited, &post.Forum, &post.Thread, &post.Created); err != nil {
+		if err := rows.Scan(&post.Id, &post.Parent, &post.Author, &post.Message, &post.IsEdited, &post.Forum, &post.Thread, &post.Created, &post.Version); err != nil {
 			log.Error(err)
 			return nil, err
 		}
@@ -172,49 +331,59 @@ func (store *Storage) GetPostsByThreadFlat(tx *sql.Tx, thread int, limit int, si
 	return &posts, nil
 }
 
-const queryGetPostsTree = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created FROM Posts
+func (store *Storage) queryPostsFlat(tx *sql.Tx, thread int, limit int, since int, sort string, order string) (*sql.Rows, error) {
+	var rows *sql.Rows
+	err := errors.New("undefined")
+	if since == 0 {
+		if order == "ASC" {
+			rows, err = store.query(tx, queryGetPostsFlat, thread, limit)
+		}
+		if order == "DESC" {
+			rows, err = store.query(tx, queryGetPostsFlatDesc, thread, limit)
+		}
+	} else {
+		if order == "ASC" {
+			rows, err = store.query(tx, queryGetPostsFlatSince, thread, limit, since)
+		}
+		if order == "DESC" {
+			rows, err = store.query(tx, queryGetPostsFlatSinceDesc, thread, limit, since)
+		}
+	}
+
+	if err != nil {
+		log.Error(err, "[thread ", thread, "]", "[limit ", limit, "] [since ", since, "] [sort ", sort, "] [order ", order, "]")
+		return nil, err
+	}
+	return rows, nil
+}
+
+const queryGetPostsTree = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created, Version FROM Posts
 WHERE Thread = $1
 ORDER BY TreePath 
 LIMIT $2
 `
 
-const queryGetPostsTreeDesc = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created FROM Posts
+const queryGetPostsTreeDesc = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created, Version FROM Posts
 WHERE Thread = $1
 ORDER BY TreePath DESC
 LIMIT $2
 `
 
-const queryGetPostsTreeSince = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created FROM Posts
+const queryGetPostsTreeSince = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created, Version FROM Posts
 WHERE Thread = $1 AND Treepath > (SELECT Treepath FROM Posts WHERE Id = $3)
 ORDER BY TreePath 
 LIMIT $2
 `
 
-const queryGetPostsTreeSinceDesc = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created FROM Posts
+const queryGetPostsTreeSinceDesc = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created, Version FROM Posts
 WHERE Thread = $1 AND Treepath < (SELECT Treepath FROM Posts WHERE Id = $3)
 ORDER BY TreePath DESC
 LIMIT $2
 `
 
 func (store *Storage) GetPostsTree(tx *sql.Tx, thread int, limit int, since int, sort string, order string) (*[]entity.Post, error) {
-	var rows *sql.Rows
-	err := errors.New("undefined")
-	if since == 0 {
-		if order == "ASC" {
-			rows, err = store.DB.Query(queryGetPostsTree, thread, limit)
-		} else {
-			rows, err = store.DB.Query(queryGetPostsTreeDesc, thread, limit)
-		}
-	} else {
-		if order == "ASC" {
-			rows, err = store.DB.Query(queryGetPostsTreeSince, thread, limit, since)
-		} else {
-			rows, err = store.DB.Query(queryGetPostsTreeSinceDesc, thread, limit, since)
-		}
-	}
-
+	rows, err := store.queryPostsTree(tx, thread, limit, since, sort, order)
 	if err != nil {
-		log.Error(err, "[sort ", sort, "] [order ", order, "]")
 		return nil, err
 	}
 	defer rows.Close()
@@ -222,7 +391,7 @@ func (store *Storage) GetPostsTree(tx *sql.Tx, thread int, limit int, since int,
 	posts := make([]entity.Post, 0)
 	for rows.Next() {
 		post := entity.Post{}
-		if err := rows.Scan(&post.Id, &post.Parent, &post.Author, &post.Message, &post.IsEdited, &post.Forum, &post.Thread, &post.Created); err != nil {
+		if err := rows.Scan(&post.Id, &post.Parent, &post.Author, &post.Message, &post.IsEdited, &post.Forum, &post.Thread, &post.Created, &post.Version); err != nil {
 			log.Error(err)
 			return nil, err
 		}
@@ -235,21 +404,45 @@ func (store *Storage) GetPostsTree(tx *sql.Tx, thread int, limit int, since int,
 	return &posts, nil
 }
 
-const queryGetPostsParentTree = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created FROM Posts
+func (store *Storage) queryPostsTree(tx *sql.Tx, thread int, limit int, since int, sort string, order string) (*sql.Rows, error) {
+	var rows *sql.Rows
+	err := errors.New("undefined")
+	if since == 0 {
+		if order == "ASC" {
+			rows, err = store.query(tx, queryGetPostsTree, thread, limit)
+		} else {
+			rows, err = store.query(tx, queryGetPostsTreeDesc, thread, limit)
+		}
+	} else {
+		if order == "ASC" {
+			rows, err = store.query(tx, queryGetPostsTreeSince, thread, limit, since)
+		} else {
+			rows, err = store.query(tx, queryGetPostsTreeSinceDesc, thread, limit, since)
+		}
+	}
+
+	if err != nil {
+		log.Error(err, "[sort ", sort, "] [order ", order, "]")
+		return nil, err
+	}
+	return rows, nil
+}
+
+const queryGetPostsParentTree = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created, Version FROM Posts
 WHERE TreePath[1] IN (SELECT Id FROM Posts WHERE Thread = $1 AND Parent = 0 ORDER BY Id LIMIT $2)
 ORDER BY TreePath
 `
-const queryGetPostsParentTreeDesc = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created FROM Posts
+const queryGetPostsParentTreeDesc = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created, Version FROM Posts
 WHERE TreePath[1] IN (SELECT Id FROM Posts WHERE Thread = $1 AND Parent = 0 ORDER BY Id DESC LIMIT $2)
 ORDER BY TreePath[1] DESC, TreePath
 `
-const queryGetPostsParentTreeSince = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created FROM Posts
+const queryGetPostsParentTreeSince = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created, Version FROM Posts
 WHERE TreePath[1] IN 
 (SELECT Id FROM Posts WHERE Thread = $1 AND Parent = 0 AND Id > (SELECT TreePath[1] FROM Posts WHERE Id = $3) 
 ORDER BY Id LIMIT $2)
 ORDER BY TreePath
 `
-const queryGetPostsParentTreeSinceDesc = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created FROM Posts
+const queryGetPostsParentTreeSinceDesc = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created, Version FROM Posts
 WHERE TreePath[1] IN 
 (SELECT Id FROM Posts WHERE Thread = $1 AND Parent = 0 AND Id < (SELECT TreePath[1] FROM Posts WHERE Id = $3) 
 ORDER BY Id DESC LIMIT $2)
@@ -257,20 +450,46 @@ ORDER BY TreePath[1] DESC, TreePath
 `
 
 func (store *Storage) GetPostsParentTree(tx *sql.Tx, thread int, limit int, since int, sort string, order string) (*[]entity.Post, error) {
+	rows, err := store.queryPostsParentTree(tx, thread, limit, since, sort, order)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	posts := make([]entity.Post, 0)
+	if sort == "parent_tree" {
+		limit = INF
+	}
+	for rows.Next() {
+		post := entity.Post{}
+		if err := rows.Scan(&post.Id, &post.Parent, &post.Author, &post.Message, &post.IsEdited, &post.Forum, &post.Thread, &post.Created, &post.Version); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		posts = append(posts, post)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	return &posts, nil
+}
+
+func (store *Storage) queryPostsParentTree(tx *sql.Tx, thread int, limit int, since int, sort string, order string) (*sql.Rows, error) {
 	var rows *sql.Rows
 	err := errors.New("undefined")
 
 	if since == 0 {
 		if order == "ASC" {
-			rows, err = store.DB.Query(queryGetPostsParentTree, thread, limit)
+			rows, err = store.query(tx, queryGetPostsParentTree, thread, limit)
 		} else {
-			rows, err = store.DB.Query(queryGetPostsParentTreeDesc, thread, limit)
+			rows, err = store.query(tx, queryGetPostsParentTreeDesc, thread, limit)
 		}
 	} else {
 		if order == "ASC" {
-			rows, err = store.DB.Query(queryGetPostsParentTreeSince, thread, limit, since)
+			rows, err = store.query(tx, queryGetPostsParentTreeSince, thread, limit, since)
 		} else {
-			rows, err = store.DB.Query(queryGetPostsParentTreeSinceDesc, thread, limit, since)
+			rows, err = store.query(tx, queryGetPostsParentTreeSinceDesc, thread, limit, since)
 		}
 	}
 
@@ -278,12 +497,107 @@ func (store *Storage) GetPostsParentTree(tx *sql.Tx, thread int, limit int, sinc
 		log.Error(err, "[sort ", sort, "] [order ", order, "]")
 		return nil, err
 	}
+	return rows, nil
+}
+
+// StreamThreadPosts calls fn for every post of the page as it is read from
+// the DB, the page is never held in memory. fn must not keep the post.
+func (store *Storage) StreamThreadPosts(tx *sql.Tx, thread int, limit int, since int, sort string, order string, fn func(*entity.Post) error) error {
+	var rows *sql.Rows
+	var err error
+	switch sort {
+	case "tree":
+		rows, err = store.queryPostsTree(tx, thread, limit, since, sort, order)
+	case "parent_tree":
+		rows, err = store.queryPostsParentTree(tx, thread, limit, since, sort, order)
+	default:
+		rows, err = store.queryPostsFlat(tx, thread, limit, since, sort, order)
+	}
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	post := entity.Post{}
+	for rows.Next() {
+		if err := rows.Scan(&post.Id, &post.Parent, &post.Author, &post.Message, &post.IsEdited, &post.Forum, &post.Thread, &post.Created, &post.Version); err != nil {
+			return err
+		}
+		if err := fn(&post); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+const queryGetPostReplies = `SELECT Posts.Id, Posts.Parent, Author, Message, IsEdited, Forum, Posts.Thread, Created FROM Posts,
+(SELECT Thread, TreePath, TreePath[1:array_length(TreePath, 1) - 1] || TreePath[array_length(TreePath, 1)] + 1 AS NextPath
+FROM Posts WHERE Id = $1) AS Root
+WHERE Posts.Thread = Root.Thread AND Posts.TreePath >= Root.TreePath AND Posts.TreePath < Root.NextPath
+AND array_length(Posts.TreePath, 1) <= array_length(Root.TreePath, 1) + $2
+`
+
+const (
+	orderRepliesTree     = "ORDER BY Posts.TreePath LIMIT $3"
+	orderRepliesTreeDesc = "ORDER BY Posts.TreePath DESC LIMIT $3"
+	orderRepliesFlat     = "ORDER BY Posts.Id LIMIT $3"
+	orderRepliesFlatDesc = "ORDER BY Posts.Id DESC LIMIT $3"
+)
+
+// GetPostReplies returns the post itself and its replies down to depth levels
+// below it. The subtree is a contiguous TreePath range: it starts at the root
+// path and ends before the path of the next sibling of the root.
+func (store *Storage) GetPostReplies(tx *sql.Tx, id int, depth int, limit int, sort string, order string) (*[]entity.Post, error) {
+	query := queryGetPostReplies
+	switch {
+	case sort == "flat" && order == "ASC":
+		query += orderRepliesFlat
+	case sort == "flat":
+		query += orderRepliesFlatDesc
+	case order == "ASC":
+		query += orderRepliesTree
+	default:
+		query += orderRepliesTreeDesc
+	}
+
+	rows, err := store.query(tx, query, id, depth, limit)
+	if err != nil {
+		log.Error(err, "[id ", id, "] [depth ", depth, "] [limit ", limit, "] [sort ", sort, "] [order ", order, "]")
+		return nil, err
+	}
 	defer rows.Close()
 
 	posts := make([]entity.Post, 0)
-	if sort == "parent_tree" {
-		limit = INF
+	for rows.Next() {
+		post := entity.Post{}
+		if err := rows.Scan(&post.Id, &post.Parent, &post.Author, &post.Message, &post.IsEdited, &post.Forum, &post.Thread, &post.Created); err != nil {
+			log.Error(err)
+			return nil, err
+		}
+		posts = append(posts, post)
+	}
+	if err := rows.Err(); err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	return &posts, nil
+}
+
+const queryGetPostAncestors = `SELECT Id, Parent, Author, Message, IsEdited, Forum, Thread, Created FROM Posts
+WHERE Id = ANY((SELECT TreePath[1:array_length(TreePath, 1) - 1] FROM Posts WHERE Id = $1)::INT[])
+ORDER BY TreePath
+`
+
+// GetPostAncestors returns the chain of parents of the post starting from the root.
+func (store *Storage) GetPostAncestors(tx *sql.Tx, id int) (*[]entity.Post, error) {
+	rows, err := store.query(tx, queryGetPostAncestors, id)
+	if err != nil {
+		log.Error(err, "[id ", id, "]")
+		return nil, err
 	}
+	defer rows.Close()
+
+	posts := make([]entity.Post, 0)
 	for rows.Next() {
 		post := entity.Post{}
 		if err := rows.Scan(&post.Id, &post.Parent, &post.Author, &post.Message, &post.IsEdited, &post.Forum, &post.Thread, &post.Created); err != nil {
@@ -0,0 +1,152 @@
+package psql
+
+import (
+	"database/sql"
+	"strconv"
+	"techpark_db/internal/domain/entity"
+	"techpark_db/internal/infra/psql/psqltest"
+	"testing"
+	"time"
+)
+
+// testStorage returns a storage over the cleared test database.
+func testStorage(tb testing.TB) *Storage {
+	tb.Helper()
+	store := NewStorage(psqltest.Open(tb))
+	if err := store.ClearData(); err != nil {
+		tb.Fatal(err)
+	}
+	return store
+}
+
+// seedThread creates a user, a forum and a thread to post into.
+func seedThread(tb testing.TB, store *Storage) (string, int) {
+	tb.Helper()
+	user := entity.CreateUser{Fullname: "Bench User", About: "about", Email: "bench@example.com"}
+	if err := store.SaveUser(nil, user, "bench"); err != nil {
+		tb.Fatal(err)
+	}
+	forum := entity.CreateForum{Title: "Bench", User: "bench", Slug: "bench"}
+	if err := store.SaveForum(nil, forum); err != nil {
+		tb.Fatal(err)
+	}
+	thread := entity.CreateThread{Title: "Bench", Author: "bench", Message: "message", Created: time.Now().Format(time.RFC3339Nano)}
+	id, err := store.SaveThread(nil, thread, forum.Slug)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return forum.Slug, id
+}
+
+// newPosts makes a batch of n replies to parent, 0 for top-level posts.
+func newPosts(n int, parent int) []entity.CreatePost {
+	posts := make([]entity.CreatePost, n)
+	for i := range posts {
+		posts[i] = entity.CreatePost{Parent: parent, Author: "bench", Message: "message " + strconv.Itoa(i)}
+	}
+	return posts
+}
+
+type savePostsFunc func(store *Storage, tx *sql.Tx, posts []entity.CreatePost, forum string, thread int, created string) (*[]int, error)
+
+func savePosts(tb testing.TB, store *Storage, save savePostsFunc, posts []entity.CreatePost, forum string, thread int) []int {
+	tx, err := store.DB.Begin()
+	if err != nil {
+		tb.Fatal(err)
+	}
+	ids, err := save(store, tx, posts, forum, thread, time.Now().Format(time.RFC3339Nano))
+	if err != nil {
+		tx.Rollback()
+		tb.Fatal(err)
+	}
+	if err := tx.Commit(); err != nil {
+		tb.Fatal(err)
+	}
+	return *ids
+}
+
+func TestSavePostsOverParameterLimit(t *testing.T) {
+	store := testStorage(t)
+	forum, thread := seedThread(t, store)
+
+	// six bind parameters a post, 10923 posts are over the 65535 of one INSERT
+	const size = 10923
+	ids := savePosts(t, store, (*Storage).SavePosts, newPosts(size, 0), forum, thread)
+	if len(ids) != size {
+		t.Fatalf("saved %d posts, want %d", len(ids), size)
+	}
+	for i := 1; i < len(ids); i++ {
+		if ids[i] <= ids[i-1] {
+			t.Fatalf("ids are not in the batch order: %d after %d", ids[i], ids[i-1])
+		}
+	}
+
+	replies := savePosts(t, store, (*Storage).SavePosts, newPosts(size, ids[0]), forum, thread)
+	post, err := store.GetPostById(nil, replies[size-1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if post.Parent != ids[0] {
+		t.Fatalf("parent of the reply is %d, want %d", post.Parent, ids[0])
+	}
+
+	got, err := store.GetForum(nil, forum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Posts != 2*size {
+		t.Fatalf("forum counts %d posts, want %d", got.Posts, 2*size)
+	}
+}
+
+func TestCheckParentPosts(t *testing.T) {
+	store := testStorage(t)
+	forum, thread := seedThread(t, store)
+	ids := savePosts(t, store, (*Storage).SavePosts, newPosts(2, 0), forum, thread)
+
+	ok, err := store.CheckParentPosts(nil, ids, thread)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Errorf("parents %v are not found in the thread", ids)
+	}
+
+	// the first parent is there, the second one is not
+	ok, err = store.CheckParentPosts(nil, []int{ids[0], ids[1] + 1000}, thread)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Error("a missing second parent passes the check")
+	}
+}
+
+// BenchmarkSavePosts compares the multi-row INSERT with COPY through the
+// staging table. Every iteration adds its batch to the same thread, so a
+// fixed count like -benchtime=20x keeps the runs comparable.
+func BenchmarkSavePosts(b *testing.B) {
+	paths := []struct {
+		name string
+		save savePostsFunc
+	}{
+		{"insert", (*Storage).savePostsInsert},
+		{"copy", (*Storage).savePostsCopy},
+	}
+	for _, size := range []int{10, 100, 1000, 5000, 10000} {
+		for _, path := range paths {
+			b.Run(path.name+"/"+strconv.Itoa(size), func(b *testing.B) {
+				store := testStorage(b)
+				forum, thread := seedThread(b, store)
+				posts := newPosts(size, 0)
+
+				b.ResetTimer()
+				start := time.Now()
+				for i := 0; i < b.N; i++ {
+					savePosts(b, store, path.save, posts, forum, thread)
+				}
+				b.ReportMetric(float64(size*b.N)/time.Since(start).Seconds(), "posts/s")
+			})
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Package psqltest connects tests to the database named by PG_TEST_DSN, e.g.
+//
+//	PG_TEST_DSN="host=localhost user=root password=love dbname=forum_test sslmode=disable" go test -p 1 ./...
+//
+// The DSN is in the key=value form, tests append their own settings to it.
+// The database must have db/db.sql loaded. The tests clear its tables, so the
+// packages are run one at a time with -p 1. Without PG_TEST_DSN the tests
+// that need the database are skipped.
+package psqltest
+
+import (
+	"database/sql"
+	_ "github.com/lib/pq"
+	"os"
+	"testing"
+)
+
+const DSN_ENV = "PG_TEST_DSN"
+
+// DSN returns the test database, or skips the test when there is none.
+func DSN(tb testing.TB) string {
+	tb.Helper()
+	dsn := os.Getenv(DSN_ENV)
+	if dsn == "" {
+		tb.Skip(DSN_ENV + " is not set")
+	}
+	return dsn
+}
+
+// Open connects to the test database, or skips the test when there is none.
+// The connection is closed with the test.
+func Open(tb testing.TB) *sql.DB {
+	tb.Helper()
+	return OpenDSN(tb, DSN(tb))
+}
+
+// OpenDSN connects to dsn and closes the connection with the test.
+func OpenDSN(tb testing.TB, dsn string) *sql.DB {
+	tb.Helper()
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() { db.Close() })
+	return db
+}
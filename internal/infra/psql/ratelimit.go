@@ -0,0 +1,74 @@
+package psql
+
+import (
+	"database/sql"
+	log "github.com/sirupsen/logrus"
+	"sync/atomic"
+	"techpark_db/internal/infra/ratelimit"
+	"time"
+)
+
+// RATE_LIMIT_TTL is the age of untouched buckets dropped by RateLimiter.
+// Every bucket is full again long before that with any sane policy.
+const RATE_LIMIT_TTL = time.Hour
+
+// RateLimiter keeps the buckets in the RateLimit table, so that every
+// instance sharing the database sees the same limits.
+type RateLimiter struct {
+	store     *Storage
+	lastSweep int64
+}
+
+func NewRateLimiter(store *Storage) *RateLimiter {
+	return &RateLimiter{
+		store:     store,
+		lastSweep: time.Now().UnixNano(),
+	}
+}
+
+// The refilled amount is computed in the database: one clock for all instances.
+const queryTakeToken = `
+INSERT INTO RateLimit AS r (Key, Tokens, Updated) VALUES ($1, $3::float8 - 1, now())
+ON CONFLICT (Key) DO UPDATE SET
+    Tokens = LEAST($3::float8, r.Tokens + EXTRACT(EPOCH FROM now() - r.Updated)::float8 * $2::float8) - 1,
+    Updated = now()
+WHERE LEAST($3::float8, r.Tokens + EXTRACT(EPOCH FROM now() - r.Updated)::float8 * $2::float8) >= 1
+RETURNING Tokens
+`
+const queryGetTokens = `
+SELECT LEAST($3::float8, Tokens + EXTRACT(EPOCH FROM now() - Updated)::float8 * $2::float8) FROM RateLimit WHERE Key = $1
+`
+const querySweepRateLimit = "DELETE FROM RateLimit WHERE Updated < now() - $1 * interval '1 second'"
+
+func (limiter *RateLimiter) Allow(key string, policy ratelimit.Policy) (ratelimit.Result, error) {
+	limiter.sweep()
+
+	var tokens float64
+	err := limiter.store.primary.scan(queryTakeToken, []interface{}{key, policy.Rate, policy.Burst}, []interface{}{&tokens})
+	if err == nil {
+		return ratelimit.NewResult(policy, tokens, true), nil
+	}
+	if err != sql.ErrNoRows {
+		return ratelimit.Result{}, err
+	}
+
+	// the bucket is empty, the update was skipped
+	err = limiter.store.primary.scan(queryGetTokens, []interface{}{key, policy.Rate, policy.Burst}, []interface{}{&tokens})
+	if err != nil {
+		return ratelimit.Result{}, err
+	}
+	return ratelimit.NewResult(policy, tokens, false), nil
+}
+
+func (limiter *RateLimiter) sweep() {
+	last := atomic.LoadInt64(&limiter.lastSweep)
+	now := time.Now().UnixNano()
+	if time.Duration(now-last) < ratelimit.SWEEP_INTERVAL || !atomic.CompareAndSwapInt64(&limiter.lastSweep, last, now) {
+		return
+	}
+	go func() {
+		if _, err := limiter.store.exec(nil, querySweepRateLimit, RATE_LIMIT_TTL.Seconds()); err != nil {
+			log.Warning("rate limit sweep: ", err)
+		}
+	}()
+}
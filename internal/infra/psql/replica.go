@@ -0,0 +1,118 @@
+package psql
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+const REPLICA_CHECK_INTERVAL = time.Second
+
+type replica struct {
+	*pool
+	healthy int32
+}
+
+type replicaSet struct {
+	replicas []*replica
+	next     uint32
+}
+
+func newReplicaSet(dbs []*sql.DB) *replicaSet {
+	set := &replicaSet{}
+	for _, db := range dbs {
+		set.replicas = append(set.replicas, &replica{
+			pool:    newPool(db),
+			healthy: 1,
+		})
+	}
+	return set
+}
+
+// Primary returns the storage reading from the primary only. Requests made
+// right after a write use it to see their own changes.
+func (store *Storage) Primary() *Storage {
+	primary := *store
+	primary.readPrimary = true
+	return &primary
+}
+
+// fromPrimary reports whether a read with tx is sure to go to the primary.
+func (store *Storage) fromPrimary(tx *sql.Tx) bool {
+	return tx != nil || store.readPrimary || len(store.replicas.replicas) == 0
+}
+
+// readReplica picks healthy replicas in turn. It returns nil when reads
+// must go to the primary.
+func (store *Storage) readReplica() *replica {
+	if store.readPrimary {
+		return nil
+	}
+	set := store.replicas
+	n := uint32(len(set.replicas))
+	if n == 0 {
+		return nil
+	}
+	start := atomic.AddUint32(&set.next, 1)
+	for i := uint32(0); i < n; i++ {
+		r := set.replicas[(start+i)%n]
+		if atomic.LoadInt32(&r.healthy) == 1 {
+			return r
+		}
+	}
+	return nil
+}
+
+// CheckReplicas pings the replicas every interval. A replica is back in
+// rotation after the first successful ping.
+func (store *Storage) CheckReplicas(interval time.Duration) {
+	if len(store.replicas.replicas) == 0 {
+		return
+	}
+	store.replicas.check()
+	go func() {
+		for range time.Tick(interval) {
+			store.replicas.check()
+		}
+	}()
+}
+
+func (set *replicaSet) check() {
+	for i, r := range set.replicas {
+		err := r.db.Ping()
+		if err == nil && atomic.SwapInt32(&r.healthy, 1) == 0 {
+			log.Info("replica ", i, " is back")
+		}
+		if err != nil && atomic.SwapInt32(&r.healthy, 0) == 1 {
+			log.Warning("replica ", i, " is down: ", err)
+		}
+	}
+}
+
+// failed reports errors after which the query is repeated on the primary:
+// a lost connection, a server which is shutting down or in recovery and
+// a write sent to a read-only server. The replica is taken out of rotation
+// until the next successful ping.
+func (r *replica) failed(err error) bool {
+	if err == nil || err == sql.ErrNoRows {
+		return false
+	}
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch {
+		case pqErr.Code == "25006":
+			return true
+		case pqErr.Code.Class() == "08", strings.HasPrefix(string(pqErr.Code), "57P"):
+		default:
+			return false
+		}
+	}
+	if atomic.SwapInt32(&r.healthy, 0) == 1 {
+		log.Warning("replica is down: ", err)
+	}
+	return true
+}
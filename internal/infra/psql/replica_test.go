@@ -0,0 +1,93 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"techpark_db/internal/domain/entity"
+	"techpark_db/internal/infra/cache"
+	"techpark_db/internal/infra/psql/psqltest"
+	"testing"
+)
+
+// The lagging replica is a connection whose search_path starts with a copy
+// of Users taken before the update.
+const queryCreateStaleUsers = `
+DROP SCHEMA IF EXISTS stale CASCADE;
+CREATE SCHEMA stale;
+CREATE TABLE stale.Users AS SELECT * FROM public.Users;
+`
+const queryDropStaleUsers = "DROP SCHEMA IF EXISTS stale CASCADE"
+
+func TestGetUserReplicaDoesNotFillCache(t *testing.T) {
+	db := psqltest.Open(t)
+	seed := NewStorage(db)
+	if err := seed.ClearData(); err != nil {
+		t.Fatal(err)
+	}
+	seedThread(t, seed)
+
+	if _, err := db.Exec(queryCreateStaleUsers); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Exec(queryDropStaleUsers) })
+	replica := psqltest.OpenDSN(t, psqltest.DSN(t)+" search_path=stale,public")
+
+	store := NewStorage(db, replica)
+	store.EnableCache(cache.DEFAULT_SIZE, cache.DEFAULT_TTL)
+	err := store.RunInTx(context.Background(), sql.LevelReadCommitted, func(tx *sql.Tx) error {
+		_, err := store.UpdateUser(tx, entity.UpdateUser{About: "updated"}, "bench", 0)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stale, err := store.GetUser(nil, "bench")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stale.About == "updated" {
+		t.Fatal("the read did not go to the stale replica")
+	}
+
+	user, err := store.Primary().GetUser(nil, "bench")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.About != "updated" {
+		t.Fatalf("primary read returned about %q, want the update", user.About)
+	}
+}
+
+// queryCreateStaleThreads gives the replica a Thread table of its own, a
+// write sent there does not reach the primary.
+const queryCreateStaleThreads = `
+DROP SCHEMA IF EXISTS stale CASCADE;
+CREATE SCHEMA stale;
+CREATE TABLE stale.Thread (LIKE public.Thread INCLUDING DEFAULTS);
+`
+
+func TestWriteWithoutTxGoesToPrimary(t *testing.T) {
+	db := psqltest.Open(t)
+	seed := NewStorage(db)
+	if err := seed.ClearData(); err != nil {
+		t.Fatal(err)
+	}
+	forum, _ := seedThread(t, seed)
+
+	if _, err := db.Exec(queryCreateStaleThreads); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Exec(queryDropStaleUsers) })
+	replica := psqltest.OpenDSN(t, psqltest.DSN(t)+" search_path=stale,public")
+
+	store := NewStorage(db, replica)
+	thread := entity.CreateThread{Title: "Second", Author: "bench", Message: "message", Created: "2021-01-01T00:00:00Z"}
+	id, err := store.SaveThread(nil, thread, forum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := seed.GetThreadById(nil, id); err != nil {
+		t.Fatalf("thread %d is not on the primary: %v", id, err)
+	}
+}
@@ -13,22 +13,22 @@ const queryGetPostCount = "SELECT COUNT(*) FROM Posts"
 
 func (store *Storage) GetServiceStatus(tx *sql.Tx) (*entity.ServStatus, error) {
 	var servStatus entity.ServStatus
-	row := tx.QueryRow(queryGetUserCount)
+	row := store.queryRow(tx, queryGetUserCount)
 	if err := row.Scan(&servStatus.User); err != nil {
 		return nil, err
 	}
 
-	row = tx.QueryRow(queryGetForumCount)
+	row = store.queryRow(tx, queryGetForumCount)
 	if err := row.Scan(&servStatus.Forum); err != nil {
 		return nil, err
 	}
 
-	row = tx.QueryRow(queryGetThreadCount)
+	row = store.queryRow(tx, queryGetThreadCount)
 	if err := row.Scan(&servStatus.Thread); err != nil {
 		return nil, err
 	}
 
-	row = tx.QueryRow(queryGetPostCount)
+	row = store.queryRow(tx, queryGetPostCount)
 	if err := row.Scan(&servStatus.Post); err != nil {
 		return nil, err
 	}
@@ -36,7 +36,7 @@ func (store *Storage) GetServiceStatus(tx *sql.Tx) (*entity.ServStatus, error) {
 	return &servStatus, nil
 }
 
-const queryClear = "TRUNCATE Vote, Posts, Thread, Forum, Users CASCADE"
+const queryClear = "TRUNCATE Vote, Posts, Thread, Forum, Users, AuditLog, Idempotency CASCADE"
 const queryClearPosts = "TRUNCATE TABLE Posts"
 const queryClearThread = "TRUNCATE TABLE Thread"
 const queryClearForum = "TRUNCATE TABLE Forum"
@@ -46,6 +46,9 @@ func (store *Storage) ClearData() error {
 	if _, err := store.DB.Exec(queryClear); err != nil {
 		return err
 	}
+	if err := store.invalidate(nil, cacheKeyAll); err != nil {
+		return err
+	}
 	log.Info("clear data")
 	//if _, err := tx.Exec(queryClearPosts); err != nil {
 	//	return err
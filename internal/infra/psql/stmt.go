@@ -0,0 +1,236 @@
+package psql
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/lib/pq"
+	"strings"
+)
+
+// preparedQueries are prepared on the primary by PrepareStatements at startup.
+// Any other constant query sent through query, queryRow or exec (and every
+// query on a replica) is prepared on first use. Queries built at runtime,
+// COPY and the statements on the temporary staging table of SavePosts bypass
+// the cache.
+var preparedQueries = []string{
+	querySaveForum,
+	queryGetForum,
+	queryGetForumThreads,
+	queryGetForumThreadsDesc,
+	queryGetForumUsers,
+	queryGetForumUsersDesc,
+	queryGetForumUsersSince,
+	queryGetForumUsersSinceDesc,
+
+	queryCheckParentPosts,
+	queryGetPostById,
+	queryUpdatePost,
+	queryGetPostsFlat,
+	queryGetPostsFlatDesc,
+	queryGetPostsFlatSince,
+	queryGetPostsFlatSinceDesc,
+	queryGetPostsTree,
+	queryGetPostsTreeDesc,
+	queryGetPostsTreeSince,
+	queryGetPostsTreeSinceDesc,
+	queryGetPostsParentTree,
+	queryGetPostsParentTreeDesc,
+	queryGetPostsParentTreeSince,
+	queryGetPostsParentTreeSinceDesc,
+	queryGetPostReplies + orderRepliesTree,
+	queryGetPostReplies + orderRepliesTreeDesc,
+	queryGetPostReplies + orderRepliesFlat,
+	queryGetPostReplies + orderRepliesFlatDesc,
+	queryGetPostAncestors,
+
+	querySaveThread,
+	queryUpdateThread,
+	queryGetThreadId,
+	queryGetThreadSlug,
+	queryGetThreadByID,
+	queryCountVote,
+
+	queryGetUser,
+	queryGetUserByOldNickname,
+	queryFindUser,
+	querySaveUser,
+	queryUpdateUser,
+
+	querySetVote,
+
+	queryGetUserCount,
+	queryGetForumCount,
+	queryGetThreadCount,
+	queryGetPostCount,
+}
+
+// PrepareStatements fills the statement cache of the primary with preparedQueries.
+func (store *Storage) PrepareStatements() error {
+	for _, query := range preparedQueries {
+		if _, err := store.primary.prepare(query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *pool) prepare(query string) (*sql.Stmt, error) {
+	p.stmtsMu.RLock()
+	stmt, ok := p.stmts[query]
+	p.stmtsMu.RUnlock()
+	if ok {
+		return stmt, nil
+	}
+
+	p.stmtsMu.Lock()
+	defer p.stmtsMu.Unlock()
+	if stmt, ok := p.stmts[query]; ok {
+		return stmt, nil
+	}
+	stmt, err := p.db.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	p.stmts[query] = stmt
+	return stmt, nil
+}
+
+// reprepare replaces a statement the server no longer knows. database/sql
+// prepares statements again by itself on connections opened after a reset,
+// this covers statements dropped on a live connection (DISCARD ALL, poolers).
+func (p *pool) reprepare(stale *sql.Stmt, query string) (*sql.Stmt, error) {
+	p.stmtsMu.Lock()
+	if p.stmts[query] == stale {
+		delete(p.stmts, query)
+		stale.Close()
+	}
+	p.stmtsMu.Unlock()
+	return p.prepare(query)
+}
+
+// isStmtLost reports the invalid_sql_statement_name error. Inside a transaction
+// the error aborts it, so only statements outside of transactions are retried.
+func isStmtLost(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "26000"
+}
+
+func (p *pool) query(query string, args ...interface{}) (*sql.Rows, error) {
+	stmt, err := p.prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := stmt.Query(args...)
+	if isStmtLost(err) {
+		if stmt, err = p.reprepare(stmt, query); err != nil {
+			return nil, err
+		}
+		return stmt.Query(args...)
+	}
+	return rows, err
+}
+
+func (p *pool) exec(query string, args ...interface{}) (sql.Result, error) {
+	stmt, err := p.prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	res, err := stmt.Exec(args...)
+	if isStmtLost(err) {
+		if stmt, err = p.reprepare(stmt, query); err != nil {
+			return nil, err
+		}
+		return stmt.Exec(args...)
+	}
+	return res, err
+}
+
+func (p *pool) scan(query string, args []interface{}, dest []interface{}) error {
+	stmt, err := p.prepare(query)
+	if err != nil {
+		return err
+	}
+	err = stmt.QueryRow(args...).Scan(dest...)
+	if isStmtLost(err) {
+		if stmt, err = p.reprepare(stmt, query); err != nil {
+			return err
+		}
+		return stmt.QueryRow(args...).Scan(dest...)
+	}
+	return err
+}
+
+// isRead tells the plain SELECTs, which a replica may serve, from the
+// INSERT ... RETURNING and UPDATE ... RETURNING sent through query and
+// queryRow without a transaction.
+func isRead(query string) bool {
+	query = strings.TrimSpace(query)
+	return len(query) >= 6 && strings.EqualFold(query[:6], "SELECT")
+}
+
+// query reads from a replica without a transaction, writes go to the primary.
+func (store *Storage) query(tx *sql.Tx, query string, args ...interface{}) (*sql.Rows, error) {
+	if tx != nil {
+		stmt, err := store.primary.prepare(query)
+		if err != nil {
+			return nil, err
+		}
+		return tx.Stmt(stmt).Query(args...)
+	}
+
+	if replica := store.readReplica(); replica != nil && isRead(query) {
+		rows, err := replica.query(query, args...)
+		if !replica.failed(err) {
+			return rows, err
+		}
+	}
+	return store.primary.query(query, args...)
+}
+
+// exec always writes to the primary.
+func (store *Storage) exec(tx *sql.Tx, query string, args ...interface{}) (sql.Result, error) {
+	if tx != nil {
+		stmt, err := store.primary.prepare(query)
+		if err != nil {
+			return nil, err
+		}
+		return tx.Stmt(stmt).Exec(args...)
+	}
+	return store.primary.exec(query, args...)
+}
+
+// stmtRow defers the query to Scan like sql.Row does, so a lost statement
+// can still be prepared again and retried.
+type stmtRow struct {
+	store *Storage
+	tx    *sql.Tx
+	query string
+	args  []interface{}
+}
+
+func (store *Storage) queryRow(tx *sql.Tx, query string, args ...interface{}) *stmtRow {
+	return &stmtRow{
+		store: store,
+		tx:    tx,
+		query: query,
+		args:  args,
+	}
+}
+
+func (row *stmtRow) Scan(dest ...interface{}) error {
+	if row.tx != nil {
+		stmt, err := row.store.primary.prepare(row.query)
+		if err != nil {
+			return err
+		}
+		return row.tx.Stmt(stmt).QueryRow(row.args...).Scan(dest...)
+	}
+
+	if replica := row.store.readReplica(); replica != nil && isRead(row.query) {
+		err := replica.scan(row.query, row.args, dest)
+		if !replica.failed(err) {
+			return err
+		}
+	}
+	return row.store.primary.scan(row.query, row.args, dest)
+}
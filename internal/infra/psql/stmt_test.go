@@ -0,0 +1,64 @@
+package psql
+
+import (
+	"database/sql"
+	"testing"
+)
+
+// BenchmarkStatements runs the hot queries of the perf workload through the
+// statement cache and as plain queries, which lib/pq parses on every call.
+func BenchmarkStatements(b *testing.B) {
+	store := testStorage(b)
+	forum, thread := seedThread(b, store)
+	savePosts(b, store, (*Storage).SavePosts, newPosts(1000, 0), forum, thread)
+	if err := store.PrepareStatements(); err != nil {
+		b.Fatal(err)
+	}
+
+	workloads := []struct {
+		name  string
+		query string
+		args  []interface{}
+	}{
+		{"user", queryGetUser, []interface{}{"bench"}},
+		{"forum", queryGetForum, []interface{}{forum}},
+		{"thread", queryGetThreadId, []interface{}{thread}},
+		{"posts_tree", queryGetPostsTree, []interface{}{thread, 100}},
+		{"vote", querySetVote, []interface{}{thread, "bench", 1}},
+	}
+	for _, w := range workloads {
+		w := w
+		b.Run("prepared/"+w.name, func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if err := drain(store.query(nil, w.query, w.args...)); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+		b.Run("plain/"+w.name, func(b *testing.B) {
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					if err := drain(store.DB.Query(w.query, w.args...)); err != nil {
+						b.Error(err)
+						return
+					}
+				}
+			})
+		})
+	}
+}
+
+// drain reads the rows like the handlers do. The benchmark bodies run on
+// their own goroutines, where b.Fatal is not allowed.
+func drain(rows *sql.Rows, err error) error {
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+	}
+	return rows.Err()
+}
@@ -1,15 +1,54 @@
 package psql
 
-import "database/sql"
+import (
+	"database/sql"
+	"errors"
+	"sync"
+	"techpark_db/internal/infra/feed"
+)
 
 const INF = 10e7
 
+// BULK_POSTS_THRESHOLD keeps the multi-row INSERT of SavePosts far below
+// the limit of 65535 bind parameters (six per post).
+const BULK_POSTS_THRESHOLD = 1000
+
+// ErrVersionMismatch is returned by the conditional updates when the row
+// was changed since the expected version was read.
+var ErrVersionMismatch = errors.New("row was changed by another update")
+
+// Storage sends transactions and writes to the primary DB. Queries outside
+// of transactions are read from a healthy replica, if there are any.
 type Storage struct {
 	DB *sql.DB
+
+	primary     *pool
+	replicas    *replicaSet
+	readPrimary bool
+
+	cache *storageCache
+	feed  *feed.Feed
+}
+
+// pool keeps the prepared statements of one database.
+type pool struct {
+	db *sql.DB
+
+	stmtsMu sync.RWMutex
+	stmts   map[string]*sql.Stmt
+}
+
+func newPool(db *sql.DB) *pool {
+	return &pool{
+		db:    db,
+		stmts: make(map[string]*sql.Stmt),
+	}
 }
 
-func NewStorage(db *sql.DB) *Storage {
+func NewStorage(db *sql.DB, replicas ...*sql.DB) *Storage {
 	return &Storage{
-		DB: db,
+		DB:       db,
+		primary:  newPool(db),
+		replicas: newReplicaSet(replicas),
 	}
 }
@@ -3,61 +3,80 @@ package psql
 import (
 	"database/sql"
 	"errors"
+	"github.com/lib/pq"
 	"strconv"
+	"strings"
 	"techpark_db/internal/domain/entity"
 )
 
 const querySaveThread = "INSERT INTO Thread(Title, Author, Message, Forum, Slug, Created) VALUES ($1, $2, $3, $4, $5, $6::TIMESTAMP WITH TIME ZONE) RETURNING id"
 
 func (store *Storage) SaveThread(tx *sql.Tx, thread entity.CreateThread, slugForum string) (int, error) {
-	row := tx.QueryRow(querySaveThread, thread.Title, thread.Author, thread.Message, slugForum, thread.Slug, thread.Created)
+	row := store.queryRow(tx, querySaveThread, thread.Title, thread.Author, thread.Message, slugForum, thread.Slug, thread.Created)
 	var id int
 	if err := row.Scan(&id); err != nil {
 		return 0, err
 	}
+	if err := store.invalidate(tx, forumCacheKey(slugForum)); err != nil {
+		return 0, err
+	}
 	return id, nil
 }
 
 const queryUpdateThreadVote = "UPDATE Thread SET Votes = $2 WHERE Id = $1"
 
 func (store *Storage) UpdateThreadVote(tx *sql.Tx, thread entity.Thread) error {
-	_, err := tx.Exec(queryUpdateThreadVote, thread.Id, thread.Votes)
-	return err
+	if _, err := store.exec(tx, queryUpdateThreadVote, thread.Id, thread.Votes); err != nil {
+		return err
+	}
+	return store.invalidate(tx, threadCacheKey(thread.Id))
 }
 
-const queryUpdateThread = "UPDATE Thread SET Title = $2, Author = $3, Forum = $4, Message = $5, Slug = $6 WHERE Id = $1"
+const queryUpdateThread = `
+UPDATE Thread SET Title = COALESCE(NULLIF($2, ''), Title), Message = COALESCE(NULLIF($3, ''), Message)
+WHERE Id = $1 AND ($4 = 0 OR Version = $4)
+RETURNING Id, Title, Author, Forum, Message, Votes, Slug, Created, Version, Modified
+`
 
-func (store *Storage) UpdateThread(tx *sql.Tx, thread entity.Thread) error {
-	_, err := tx.Exec(queryUpdateThread, thread.Id, thread.Title, thread.Author, thread.Forum, thread.Message, thread.Slug)
-	return err
+// UpdateThread changes the non-empty title and message of the thread.
+// A non-zero version makes the update conditional, see ErrVersionMismatch.
+func (store *Storage) UpdateThread(tx *sql.Tx, id int, title string, message string, version int) (*entity.Thread, error) {
+	row := store.queryRow(tx, queryUpdateThread, id, title, message, version)
+	thread := entity.Thread{}
+	if err := row.Scan(&thread.Id, &thread.Title, &thread.Author, &thread.Forum, &thread.Message, &thread.Votes, &thread.Slug, &thread.Created, &thread.Version, &thread.Modified); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrVersionMismatch
+		}
+		return nil, err
+	}
+	if err := store.invalidate(tx, threadCacheKey(thread.Id)); err != nil {
+		return nil, err
+	}
+	return &thread, nil
 }
 
-const queryGetThreadId = "SELECT Id, Title, Author, Forum, Message, Votes, Slug, Created FROM Thread WHERE Id = $1"
-const queryGetThreadSlug = "SELECT Id, Title, Author, Forum, Message, Votes, Slug, Created FROM Thread WHERE Slug = $1"
+const queryGetThreadId = "SELECT Id, Title, Author, Forum, Message, Votes, Slug, Created, Version, Modified FROM Thread WHERE Id = $1"
+const queryGetThreadSlug = "SELECT Id, Title, Author, Forum, Message, Votes, Slug, Created, Version, Modified FROM Thread WHERE Slug = $1"
 
 func (store *Storage) GetThread(tx *sql.Tx, slugOrId string) (*entity.Thread, error) {
 	if slugOrId == "" {
 		return nil, errors.New("Empty slug")
 	}
-	var row *sql.Row
+	var row *stmtRow
 	id, err := strconv.Atoi(slugOrId)
 
-	if tx != nil {
-		if err != nil {
-			row = tx.QueryRow(queryGetThreadSlug, slugOrId)
-		} else {
-			row = tx.QueryRow(queryGetThreadId, id)
-		}
+	if store.readCached(tx) {
+		return store.getCachedThread(slugOrId, id, err == nil)
+	}
+
+	if err != nil {
+		row = store.queryRow(tx, queryGetThreadSlug, slugOrId)
 	} else {
-		if err != nil {
-			row = store.DB.QueryRow(queryGetThreadSlug, slugOrId)
-		} else {
-			row = store.DB.QueryRow(queryGetThreadId, id)
-		}
+		row = store.queryRow(tx, queryGetThreadId, id)
 	}
 
 	thread := entity.Thread{}
-	if err := row.Scan(&thread.Id, &thread.Title, &thread.Author, &thread.Forum, &thread.Message, &thread.Votes, &thread.Slug, &thread.Created); err != nil {
+	if err := row.Scan(&thread.Id, &thread.Title, &thread.Author, &thread.Forum, &thread.Message, &thread.Votes, &thread.Slug, &thread.Created, &thread.Version, &thread.Modified); err != nil {
 		return nil, err
 	}
 	return &thread, nil
@@ -66,7 +85,7 @@ func (store *Storage) GetThread(tx *sql.Tx, slugOrId string) (*entity.Thread, er
 const queryCountVote = "SELECT Votes FROM Thread WHERE Id = $1"
 
 func (store *Storage) CountVote(tx *sql.Tx, id int) (*int, error) {
-	row := tx.QueryRow(queryCountVote, id)
+	row := store.queryRow(tx, queryCountVote, id)
 	var count int
 	if err := row.Scan(&count); err != nil {
 		return nil, err
@@ -74,29 +93,203 @@ func (store *Storage) CountVote(tx *sql.Tx, id int) (*int, error) {
 	return &count, nil
 }
 
-const queryGetThreadByTitle = "SELECT Id, Title, Author, Forum, Message, Votes, Slug, Created FROM Thread WHERE Title = $1"
+const queryGetThreadByTitle = "SELECT Id, Title, Author, Forum, Message, Votes, Slug, Created, Version, Modified FROM Thread WHERE Title = $1"
 
 func (store *Storage) GetThreadByTitle(tx *sql.Tx, title string) (*entity.Thread, error) {
-	row := tx.QueryRow(queryGetThreadByTitle, title)
+	row := store.queryRow(tx, queryGetThreadByTitle, title)
 	thread := entity.Thread{}
-	if err := row.Scan(&thread.Id, &thread.Title, &thread.Author, &thread.Forum, &thread.Message, &thread.Votes, &thread.Slug, &thread.Created); err != nil {
+	if err := row.Scan(&thread.Id, &thread.Title, &thread.Author, &thread.Forum, &thread.Message, &thread.Votes, &thread.Slug, &thread.Created, &thread.Version, &thread.Modified); err != nil {
 		return nil, err
 	}
 	return &thread, nil
 }
 
-const queryGetThreadByID = "SELECT Id, Title, Author, Forum, Message, Votes, Slug, Created FROM Thread WHERE Id = $1"
+const queryGetThreadByID = "SELECT Id, Title, Author, Forum, Message, Votes, Slug, Created, Version, Modified FROM Thread WHERE Id = $1"
 
 func (store *Storage) GetThreadById(tx *sql.Tx, id int) (*entity.Thread, error) {
-	var row *sql.Row
-	if tx != nil {
-		row = tx.QueryRow(queryGetThreadByID, id)
-	} else {
-		row = store.DB.QueryRow(queryGetThreadByID, id)
+	if store.readCached(tx) {
+		if value, ok := store.cache.threads.Get(strconv.Itoa(id)); ok {
+			thread := value.(entity.Thread)
+			return &thread, nil
+		}
 	}
+
+	row := store.queryRow(tx, queryGetThreadByID, id)
 	thread := entity.Thread{}
-	if err := row.Scan(&thread.Id, &thread.Title, &thread.Author, &thread.Forum, &thread.Message, &thread.Votes, &thread.Slug, &thread.Created); err != nil {
+	if err := row.Scan(&thread.Id, &thread.Title, &thread.Author, &thread.Forum, &thread.Message, &thread.Votes, &thread.Slug, &thread.Created, &thread.Version, &thread.Modified); err != nil {
 		return nil, err
 	}
+
+	if store.fillCached(tx) {
+		store.cache.threads.Set(strconv.Itoa(id), thread)
+	}
 	return &thread, nil
 }
+
+// getCachedThread resolves a slug to the id through the slug cache. A stale
+// slug (e.g. of a merged thread or after a clear) falls back to the lookup
+// by slug.
+func (store *Storage) getCachedThread(slugOrId string, id int, isId bool) (*entity.Thread, error) {
+	if isId {
+		return store.GetThreadById(nil, id)
+	}
+
+	slug := strings.ToLower(slugOrId)
+	if value, ok := store.cache.slugs.Get(slug); ok {
+		thread, err := store.GetThreadById(nil, value.(int))
+		if err == nil && strings.EqualFold(thread.Slug, slugOrId) {
+			return thread, nil
+		}
+		if err != nil && err != sql.ErrNoRows {
+			return nil, err
+		}
+		store.cache.slugs.Delete(slug)
+	}
+
+	row := store.queryRow(nil, queryGetThreadSlug, slugOrId)
+	thread := entity.Thread{}
+	if err := row.Scan(&thread.Id, &thread.Title, &thread.Author, &thread.Forum, &thread.Message, &thread.Votes, &thread.Slug, &thread.Created, &thread.Version, &thread.Modified); err != nil {
+		return nil, err
+	}
+	if store.fillCached(nil) {
+		store.cache.slugs.Set(slug, thread.Id)
+		store.cache.threads.Set(strconv.Itoa(thread.Id), thread)
+	}
+	return &thread, nil
+}
+
+const queryMoveThread = "UPDATE Thread SET Forum = $2 WHERE Id = $1"
+const queryMoveThreadPosts = "UPDATE Posts SET Forum = $2 WHERE Thread = $1"
+const queryMoveThreadUsersForum = `
+INSERT INTO UsersForum(Forum, Nickname)
+SELECT $2::citext, Author FROM Thread WHERE Id = $1
+UNION
+SELECT $2::citext, Author FROM Posts WHERE Thread = $1
+ON CONFLICT ON CONSTRAINT usersforum_pkey
+DO NOTHING
+`
+const queryAddForumPostsUsers = `
+INSERT INTO UsersForum(Forum, Nickname)
+SELECT DISTINCT $2::citext, Author FROM Posts WHERE Thread = $1
+ON CONFLICT ON CONSTRAINT usersforum_pkey
+DO NOTHING
+`
+const queryAddForumCounters = "UPDATE Forum SET Posts = Posts + $2, Threads = Threads + $3 WHERE Slug = $1"
+
+// MoveThread moves the thread with all its posts to the forum with the given slug
+// and moves the Posts/Threads counters along with them. The thread is locked and
+// read again first, so a concurrent move or a post insert does not get between.
+// Returns the thread as it was before the move, a thread already in the forum
+// is left in place.
+func (store *Storage) MoveThread(tx *sql.Tx, id int, forum string) (*entity.Thread, error) {
+	if err := store.lockThreads(tx, id); err != nil {
+		return nil, err
+	}
+	thread, err := store.GetThreadById(tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(thread.Forum, forum) {
+		return thread, nil
+	}
+
+	if _, err := store.exec(tx, queryMoveThread, thread.Id, forum); err != nil {
+		return nil, err
+	}
+	res, err := store.exec(tx, queryMoveThreadPosts, thread.Id, forum)
+	if err != nil {
+		return nil, err
+	}
+	posts, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := store.exec(tx, queryMoveThreadUsersForum, thread.Id, forum); err != nil {
+		return nil, err
+	}
+	if _, err := store.exec(tx, queryAddForumCounters, thread.Forum, -posts, -1); err != nil {
+		return nil, err
+	}
+	if _, err := store.exec(tx, queryAddForumCounters, forum, posts, 1); err != nil {
+		return nil, err
+	}
+	return thread, store.invalidate(tx, threadCacheKey(thread.Id), forumCacheKey(thread.Forum), forumCacheKey(forum))
+}
+
+const queryLockThreads = "SELECT Id FROM Thread WHERE Id = ANY($1) ORDER BY Id FOR UPDATE"
+
+// lockThreads locks the thread rows until the end of the transaction. A post
+// insert takes a key share lock on its thread, so no post is created in the
+// threads meanwhile. The rows are locked in the id order to avoid deadlocks.
+func (store *Storage) lockThreads(tx *sql.Tx, ids ...int) error {
+	rows, err := store.query(tx, queryLockThreads, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+	}
+	return rows.Err()
+}
+
+const queryMergeThreadPosts = "UPDATE Posts SET Thread = $2, Forum = $3 WHERE Thread = $1"
+const queryMergeThreadVotes = `
+INSERT INTO Vote(IdThread, Nickname, Voice)
+SELECT $2, Nickname, Voice FROM Vote WHERE IdThread = $1
+ON CONFLICT ON CONSTRAINT vote_pkey
+DO NOTHING
+`
+const queryDeleteThreadVotes = "DELETE FROM Vote WHERE IdThread = $1"
+const queryDeleteThread = "DELETE FROM Thread WHERE Id = $1"
+
+// MergeThreads moves all posts and votes of the source thread into the target
+// thread and deletes the source. TreePath arrays consist of globally unique post
+// ids, so the merged posts keep their paths and become extra root branches of
+// the target. A user who voted in both threads keeps the target vote.
+// Returns the number of moved posts.
+func (store *Storage) MergeThreads(tx *sql.Tx, source entity.Thread, target entity.Thread) (int, error) {
+	if err := store.lockThreads(tx, source.Id, target.Id); err != nil {
+		return 0, err
+	}
+	err := store.invalidate(tx, threadCacheKey(source.Id), threadCacheKey(target.Id),
+		forumCacheKey(source.Forum), forumCacheKey(target.Forum))
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := store.exec(tx, queryMergeThreadPosts, source.Id, target.Id, target.Forum)
+	if err != nil {
+		return 0, err
+	}
+	posts, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if _, err := store.exec(tx, queryMergeThreadVotes, source.Id, target.Id); err != nil {
+		return 0, err
+	}
+	if _, err := store.exec(tx, queryDeleteThreadVotes, source.Id); err != nil {
+		return 0, err
+	}
+	if _, err := store.exec(tx, queryDeleteThread, source.Id); err != nil {
+		return 0, err
+	}
+
+	if source.Forum == target.Forum {
+		if _, err := store.exec(tx, queryAddForumCounters, source.Forum, 0, -1); err != nil {
+			return 0, err
+		}
+		return int(posts), nil
+	}
+
+	if _, err := store.exec(tx, queryAddForumPostsUsers, target.Id, target.Forum); err != nil {
+		return 0, err
+	}
+	if _, err := store.exec(tx, queryAddForumCounters, source.Forum, -posts, -1); err != nil {
+		return 0, err
+	}
+	if _, err := store.exec(tx, queryAddForumCounters, target.Forum, posts, 0); err != nil {
+		return 0, err
+	}
+	return int(posts), nil
+}
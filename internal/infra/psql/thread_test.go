@@ -0,0 +1,48 @@
+package psql
+
+import (
+	"techpark_db/internal/domain/entity"
+	"testing"
+)
+
+func TestMoveThread(t *testing.T) {
+	store := testStorage(t)
+	from, thread := seedThread(t, store)
+	savePosts(t, store, (*Storage).SavePosts, newPosts(3, 0), from, thread)
+	if err := store.SaveForum(nil, entity.CreateForum{Title: "Other", User: "bench", Slug: "other"}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the second move reads the thread again and finds it in place
+	for i := 0; i < 2; i++ {
+		tx, err := store.DB.Begin()
+		if err != nil {
+			t.Fatal(err)
+		}
+		moved, err := store.MoveThread(tx, thread, "other")
+		if err != nil {
+			tx.Rollback()
+			t.Fatal(err)
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatal(err)
+		}
+		want := from
+		if i > 0 {
+			want = "other"
+		}
+		if moved.Forum != want {
+			t.Errorf("move %d found the thread in %q, want %q", i, moved.Forum, want)
+		}
+	}
+
+	for slug, want := range map[string]entity.Forum{from: {Posts: 0, Threads: 0}, "other": {Posts: 3, Threads: 1}} {
+		forum, err := store.GetForum(nil, slug)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if forum.Posts != want.Posts || forum.Threads != want.Threads {
+			t.Errorf("forum %s has %d posts and %d threads, want %d and %d", slug, forum.Posts, forum.Threads, want.Posts, want.Threads)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package psql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/lib/pq"
+	log "github.com/sirupsen/logrus"
+	"math/rand"
+	"time"
+)
+
+const (
+	TX_MAX_ATTEMPTS  = 5
+	TX_RETRY_BACKOFF = 10 * time.Millisecond
+	TX_RETRY_MAX     = 500 * time.Millisecond
+)
+
+// RunInTx runs fn in a transaction and commits it, or rolls it back when fn
+// fails. Serialization failures and deadlocks, from fn or from the commit,
+// start the transaction again after a jittered exponential backoff, so fn
+// must not have effects outside of tx.
+func (store *Storage) RunInTx(ctx context.Context, isolation sql.IsolationLevel, fn func(tx *sql.Tx) error) error {
+	var err error
+	for attempt := 0; attempt < TX_MAX_ATTEMPTS; attempt++ {
+		if attempt > 0 {
+			log.Warning("retry transaction, attempt ", attempt+1, ": ", err)
+			select {
+			case <-time.After(retryBackoff(attempt)):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		err = store.runTx(ctx, isolation, fn)
+		if !IsRetryable(err) {
+			return err
+		}
+	}
+	return err
+}
+
+func (store *Storage) runTx(ctx context.Context, isolation sql.IsolationLevel, fn func(tx *sql.Tx) error) error {
+	tx, err := store.DB.BeginTx(ctx, &sql.TxOptions{Isolation: isolation})
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// retryBackoff doubles the bound with every attempt and picks a random pause
+// between its half and itself, so contending transactions do not meet again.
+func retryBackoff(attempt int) time.Duration {
+	backoff := TX_RETRY_BACKOFF << uint(attempt-1)
+	if backoff > TX_RETRY_MAX {
+		backoff = TX_RETRY_MAX
+	}
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// IsRetryable reports serialization_failure and deadlock_detected.
+func IsRetryable(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+	return pqErr.Code == "40001" || pqErr.Code == "40P01"
+}
+
+// IsUniqueViolation reports unique_violation, e.g. a key taken by a
+// concurrent transaction after it was checked.
+func IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
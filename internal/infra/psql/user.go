@@ -3,20 +3,49 @@ package psql
 import (
 	"database/sql"
 	log "github.com/sirupsen/logrus"
+	"strings"
 	"techpark_db/internal/domain/entity"
 )
 
-const queryGetUser = "SELECT nickname, fullname, about, email FROM users WHERE nickname = $1"
+const queryGetUser = "SELECT nickname, fullname, about, email, version, modified FROM users WHERE nickname = $1"
+const queryGetUserByOldNickname = `
+SELECT Users.Nickname, Fullname, About, Email, Version, Modified FROM NicknameHistory
+JOIN Users ON Users.Nickname = NicknameHistory.Nickname
+WHERE OldNickname = $1
+`
 
+// GetUser looks the user up by the current nickname and falls back
+// to the nickname history, so old names keep resolving after a rename.
+// The cache keeps users under their current nickname only, an old one
+// always goes to the history. Requests reading their own writes skip the
+// cache, and only rows read from the primary are cached: a lagging replica
+// would put back a row invalidated by a write.
 func (store *Storage) GetUser(tx *sql.Tx, nickname string) (*entity.User, error) {
-	var row *sql.Row
-	if tx == nil {
-		row = store.DB.QueryRow(queryGetUser, nickname)
-	} else {
-		row = tx.QueryRow(queryGetUser, nickname)
+	if store.cache != nil && !store.readPrimary {
+		if cached, ok := store.cache.users.Get(strings.ToLower(nickname)); ok {
+			user := cached.(entity.User)
+			return &user, nil
+		}
+	}
+
+	user, err := store.getUser(tx, queryGetUser, nickname)
+	if err == sql.ErrNoRows {
+		user, err = store.getUser(tx, queryGetUserByOldNickname, nickname)
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	if store.cache != nil && store.fromPrimary(tx) {
+		store.cache.users.Set(strings.ToLower(user.Nickname), *user)
+	}
+	return user, nil
+}
+
+func (store *Storage) getUser(tx *sql.Tx, query string, nickname string) (*entity.User, error) {
+	row := store.queryRow(tx, query, nickname)
 	user := entity.User{}
-	if err := row.Scan(&user.Nickname, &user.Fullname, &user.About, &user.Email); err != nil {
+	if err := row.Scan(&user.Nickname, &user.Fullname, &user.About, &user.Email, &user.Version, &user.Modified); err != nil {
 		return nil, err
 	}
 	return &user, nil
@@ -25,7 +54,7 @@ func (store *Storage) GetUser(tx *sql.Tx, nickname string) (*entity.User, error)
 const queryFindUser = "SELECT nickname, fullname, about, email FROM users WHERE nickname = $1 OR email = $2"
 
 func (store *Storage) FindUser(tx *sql.Tx, nickname string, email string) (*[]entity.User, error) {
-	rows, err := tx.Query(queryFindUser, nickname, email)
+	rows, err := store.query(tx, queryFindUser, nickname, email)
 	if err != nil {
 		log.Error(err)
 		return nil, err
@@ -51,17 +80,70 @@ func (store *Storage) FindUser(tx *sql.Tx, nickname string, email string) (*[]en
 const querySaveUser = "INSERT INTO Users(Nickname, Fullname, About, Email) VALUES ($1, $2, $3, $4)"
 
 func (store *Storage) SaveUser(tx *sql.Tx, user entity.CreateUser, nickname string) error {
-	if _, err := tx.Exec(querySaveUser, nickname, user.Fullname, user.About, user.Email); err != nil {
-		return err
+	_, err := store.exec(tx, querySaveUser, nickname, user.Fullname, user.About, user.Email)
+	return err
+}
+
+const queryUpdateUser = `
+UPDATE Users SET
+    Fullname = COALESCE(NULLIF($2, ''), Fullname),
+    About = COALESCE(NULLIF($3, ''), About),
+    Email = COALESCE(NULLIF($4::citext, ''), Email)
+WHERE Nickname = $1 AND ($5 = 0 OR Version = $5)
+RETURNING Nickname, Fullname, About, Email, Version, Modified
+`
+
+// UpdateUser changes the non-empty fields of the profile.
+// A non-zero version makes the update conditional, see ErrVersionMismatch.
+func (store *Storage) UpdateUser(tx *sql.Tx, user entity.UpdateUser, nickname string, version int) (*entity.User, error) {
+	row := store.queryRow(tx, queryUpdateUser, nickname, user.Fullname, user.About, user.Email, version)
+	updated := entity.User{}
+	if err := row.Scan(&updated.Nickname, &updated.Fullname, &updated.About, &updated.Email, &updated.Version, &updated.Modified); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrVersionMismatch
+		}
+		return nil, err
 	}
-	return nil
+	if err := store.invalidate(tx, userCacheKey(updated.Nickname)); err != nil {
+		return nil, err
+	}
+	return &updated, nil
 }
 
-const queryUpdateUser = "UPDATE Users SET Fullname = $1, About = $2, Email = $3 WHERE Nickname = $4"
+const queryCheckNickname = "SELECT count(*) FROM Users WHERE Nickname = $1 AND Nickname <> $2"
 
-func (store *Storage) UpdateUser(tx *sql.Tx, user entity.UpdateUser, nickname string) error {
-	if _, err := tx.Exec(queryUpdateUser, user.Fullname, user.About, user.Email, nickname); err != nil {
+// CheckNicknameFree reports whether nickname can be taken by the user
+// currently named owner. A case-only change of the own nickname is allowed.
+func (store *Storage) CheckNicknameFree(tx *sql.Tx, nickname string, owner string) (bool, error) {
+	row := store.queryRow(tx, queryCheckNickname, nickname, owner)
+	var count int
+	if err := row.Scan(&count); err != nil {
+		return false, err
+	}
+	return count == 0, nil
+}
+
+const queryRenameUser = "UPDATE Users SET Nickname = $2 WHERE Nickname = $1"
+const queryReleaseOldNickname = "DELETE FROM NicknameHistory WHERE OldNickname = $1"
+const querySaveOldNickname = `
+INSERT INTO NicknameHistory(OldNickname, Nickname)
+SELECT $1::citext, $2::citext WHERE $1::citext <> $2::citext
+ON CONFLICT (OldNickname) DO UPDATE SET Nickname = $2, Changed = now()
+`
+
+// RenameUser changes the primary key of the user. References in Forum, Thread,
+// Posts, Vote, UsersForum and NicknameHistory follow through ON UPDATE CASCADE.
+func (store *Storage) RenameUser(tx *sql.Tx, oldNickname string, newNickname string) error {
+	if _, err := store.exec(tx, queryReleaseOldNickname, newNickname); err != nil {
+		return err
+	}
+	if _, err := store.exec(tx, queryRenameUser, oldNickname, newNickname); err != nil {
+		return err
+	}
+	if _, err := store.exec(tx, querySaveOldNickname, oldNickname, newNickname); err != nil {
 		return err
 	}
-	return nil
+	// the nickname is copied into the cached forums and threads
+	return store.invalidate(tx, userCacheKey(oldNickname), userCacheKey(newNickname),
+		cacheKindForum+":"+cacheKeyAll, cacheKindThread+":"+cacheKeyAll)
 }
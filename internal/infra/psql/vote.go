@@ -46,11 +46,11 @@ DO UPDATE SET Voice = $3;
 `
 
 func (store *Storage) SetVote(tx *sql.Tx, voteReq entity.Vote) error {
-	_, err := tx.Exec(querySetVote, voteReq.IdThread, voteReq.Nickname, voteReq.Voice)
+	_, err := store.exec(tx, querySetVote, voteReq.IdThread, voteReq.Nickname, voteReq.Voice)
 	if err != nil {
 		log.Info(err)
 		log.Info(voteReq.IdThread, " ", voteReq.Nickname, " ", voteReq.Voice)
 		return err
 	}
-	return nil
+	return store.invalidate(tx, threadCacheKey(voteReq.IdThread))
 }
@@ -0,0 +1,28 @@
+package ratelimit
+
+import (
+	"errors"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+)
+
+// LoadPolicies reads the policies by method and route template from a YAML
+// file, see configs/ratelimit.yaml:
+//
+//	POST /api/thread/{slug_or_id}/vote: {rate: 200, burst: 2000}
+func LoadPolicies(path string) (map[string]Policy, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	policies := make(map[string]Policy)
+	if err := yaml.UnmarshalStrict(data, &policies); err != nil {
+		return nil, err
+	}
+	for route, policy := range policies {
+		if policy.Rate <= 0 || policy.Burst < 1 {
+			return nil, errors.New("rate limit of " + route + " needs a positive rate and burst")
+		}
+	}
+	return policies, nil
+}
@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const SWEEP_INTERVAL = time.Minute
+
+// Memory keeps the buckets of one instance.
+type Memory struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+}
+
+type bucket struct {
+	policy  Policy
+	tokens  float64
+	updated time.Time
+}
+
+func NewMemory() *Memory {
+	return &Memory{
+		buckets:   make(map[string]*bucket),
+		lastSweep: time.Now(),
+	}
+}
+
+func (m *Memory) Allow(key string, policy Policy) (Result, error) {
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.sweep(now)
+
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{
+			policy:  policy,
+			tokens:  float64(policy.Burst),
+			updated: now,
+		}
+		m.buckets[key] = b
+	}
+	b.tokens = b.refill(now)
+	b.updated = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+	return NewResult(policy, b.tokens, allowed), nil
+}
+
+func (b *bucket) refill(now time.Time) float64 {
+	tokens := b.tokens + now.Sub(b.updated).Seconds()*b.policy.Rate
+	return math.Min(float64(b.policy.Burst), tokens)
+}
+
+// sweep drops full buckets, they are the same as missing ones.
+func (m *Memory) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < SWEEP_INTERVAL {
+		return
+	}
+	m.lastSweep = now
+	for key, b := range m.buckets {
+		if b.refill(now) >= float64(b.policy.Burst) {
+			delete(m.buckets, key)
+		}
+	}
+}
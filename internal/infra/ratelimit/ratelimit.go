@@ -0,0 +1,50 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+// Policy is a token bucket holding at most Burst tokens and refilled at Rate
+// tokens per second. Every request takes one token.
+type Policy struct {
+	Rate  float64 `yaml:"rate"`
+	Burst int     `yaml:"burst"`
+}
+
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is the wait for the next token of a rejected request.
+	RetryAfter time.Duration
+	// Reset is the wait until the bucket is full again.
+	Reset time.Duration
+}
+
+// Limiter takes a token from the bucket of key. Buckets of the same key
+// must be used with the same policy.
+type Limiter interface {
+	Allow(key string, policy Policy) (Result, error)
+}
+
+// NewResult describes the bucket left with tokens after the request.
+func NewResult(policy Policy, tokens float64, allowed bool) Result {
+	res := Result{
+		Allowed:   allowed,
+		Limit:     policy.Burst,
+		Remaining: int(math.Max(0, math.Floor(tokens))),
+		Reset:     seconds((float64(policy.Burst) - tokens) / policy.Rate),
+	}
+	if !allowed {
+		res.RetryAfter = seconds((1 - tokens) / policy.Rate)
+	}
+	return res
+}
+
+func seconds(s float64) time.Duration {
+	if s < 0 {
+		return 0
+	}
+	return time.Duration(s * float64(time.Second))
+}
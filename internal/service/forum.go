@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"techpark_db/internal/apperr"
+	"techpark_db/internal/domain/entity"
+	"time"
+)
+
+// CreateForum returns ErrExists with the forum already holding the slug.
+func (s *Service) CreateForum(ctx context.Context, forumRequest entity.CreateForum) (*entity.Forum, error) {
+	var forum *entity.Forum
+	err := s.storage.RunInTx(ctx, sql.LevelReadCommitted, func(tx *sql.Tx) error {
+		user, err := s.storage.GetUser(tx, forumRequest.User)
+		if err != nil {
+			return apperr.New(apperr.CodeUserNotFound, ErrNoUser+forumRequest.User)
+		}
+		forumRequest.User = user.Nickname
+
+		forum, err = s.storage.GetForum(tx, forumRequest.Slug)
+		if err == nil {
+			return ErrExists
+		}
+
+		if err := s.storage.SaveForum(tx, forumRequest); err != nil {
+			return err
+		}
+
+		forum = &entity.Forum{
+			Slug:    forumRequest.Slug,
+			Title:   forumRequest.Title,
+			User:    forumRequest.User,
+			Posts:   0,
+			Threads: 0,
+		}
+		return nil
+	})
+	return forum, err
+}
+
+// CreateThread returns ErrExists with the thread already holding the slug.
+func (s *Service) CreateThread(ctx context.Context, slugForum string, threadRequest entity.CreateThread) (*entity.Thread, error) {
+	if threadRequest.Created == "" {
+		threadRequest.Created = time.Now().Format(time.RFC3339Nano)
+	}
+
+	var thread *entity.Thread
+	err := s.storage.RunInTx(ctx, sql.LevelReadCommitted, func(tx *sql.Tx) error {
+		user, err := s.storage.GetUser(tx, threadRequest.Author)
+		if err != nil {
+			return apperr.New(apperr.CodeUserNotFound, ErrNoThreadAuthor+threadRequest.Author)
+		}
+		threadRequest.Author = user.Nickname
+
+		thread, err = s.storage.GetThread(tx, threadRequest.Slug)
+		if err == nil {
+			return ErrExists
+		}
+
+		forum, err := s.storage.GetForum(tx, slugForum)
+		if err != nil {
+			return apperr.New(apperr.CodeForumNotFound, ErrNoThreadForum+slugForum)
+		}
+
+		insertId, err := s.storage.SaveThread(tx, threadRequest, forum.Slug)
+		if err != nil {
+			return err
+		}
+
+		thread, err = s.storage.GetThreadById(tx, insertId)
+		return err
+	})
+	return thread, err
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"techpark_db/internal/apperr"
+	"techpark_db/internal/domain/entity"
+	"techpark_db/internal/infra/psql"
+)
+
+// UpdatePost changes the message of the post if it is still at the version
+// ifMatch names. It reports whether the message was changed.
+func (s *Service) UpdatePost(ctx context.Context, id int, postRequest entity.UpdatePost, ifMatch string) (*entity.Post, bool, error) {
+	var post *entity.Post
+	edited := false
+	err := s.storage.RunInTx(ctx, sql.LevelReadCommitted, func(tx *sql.Tx) error {
+		var err error
+		post, err = s.storage.GetPostById(tx, id)
+		if err != nil {
+			return apperr.New(apperr.CodePostNotFound, ErrNoPost+strconv.Itoa(id))
+		}
+
+		version, err := MatchVersion(ifMatch, post.Version)
+		if err != nil {
+			return err
+		}
+		edited = postRequest.Message != "" && postRequest.Message != post.Message
+		if !edited {
+			return nil
+		}
+
+		post, err = s.storage.UpdatePost(tx, id, postRequest.Message, version)
+		if err == psql.ErrVersionMismatch {
+			return apperr.New(apperr.CodeVersionChanged, ErrPreconditionFailed)
+		}
+		return err
+	})
+	return post, edited, err
+}
+
+// SplitPost moves the post with its replies into a new thread started with
+// its message. It returns ErrExists with the thread already holding the slug.
+func (s *Service) SplitPost(ctx context.Context, id int, splitReq entity.SplitPost) (*entity.Thread, error) {
+	var thread *entity.Thread
+	err := s.storage.RunInTx(ctx, sql.LevelReadCommitted, func(tx *sql.Tx) error {
+		post, err := s.storage.GetPostById(tx, id)
+		if err != nil {
+			return apperr.New(apperr.CodePostNotFound, ErrNoPost+strconv.Itoa(id))
+		}
+
+		if splitReq.Author == "" {
+			splitReq.Author = post.Author
+		}
+		author, err := s.storage.GetUser(tx, splitReq.Author)
+		if err != nil {
+			return apperr.New(apperr.CodeUserNotFound, ErrNoThreadAuthor+splitReq.Author)
+		}
+
+		if splitReq.Slug != "" {
+			thread, err = s.storage.GetThread(tx, splitReq.Slug)
+			if err == nil {
+				return ErrExists
+			}
+		}
+
+		threadReq := entity.CreateThread{
+			Title:   splitReq.Title,
+			Author:  author.Nickname,
+			Message: post.Message,
+			Slug:    splitReq.Slug,
+			Created: post.Created,
+		}
+		threadId, err := s.storage.SaveThread(tx, threadReq, post.Forum)
+		if err != nil {
+			return err
+		}
+
+		posts, err := s.storage.SplitPosts(tx, *post, threadId)
+		if err != nil {
+			return err
+		}
+
+		audit := entity.PostSplitAudit{
+			Post:   post.Id,
+			Source: post.Thread,
+			Target: threadId,
+			Posts:  posts,
+		}
+		if err := s.storage.SaveAudit(tx, entity.AuditPostSplit, audit); err != nil {
+			return err
+		}
+
+		thread, err = s.storage.GetThreadById(tx, threadId)
+		return err
+	})
+	return thread, err
+}
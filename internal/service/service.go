@@ -0,0 +1,71 @@
+package service
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"techpark_db/internal/apperr"
+	"techpark_db/internal/infra/psql"
+)
+
+const (
+	MOVED_STUB_PREFIX = "Moved: "
+	TITLE_MAX_LENGTH  = 100
+)
+
+var ErrNoUser = "Can't find user by nickname: "
+var ErrEmailAlreadyRegistered = "This email is already registered by user: "
+var ErrNicknameTaken = "This nickname is already taken: "
+var ErrNoForum = "Can't find forum with slug: "
+var ErrNoThread = "Can't find thread with slug: "
+var ErrNoThreadAuthor = "Can't find thread author by nickname: "
+var ErrNoThreadForum = "Can't find thread forum by slug: "
+var ErrNoTargetForum = "Can't find target forum by slug: "
+var ErrNoTargetThread = "Can't find target thread by slug or id: "
+var ErrMergeSameThread = "Can't merge thread into itself: "
+var ErrNoPost = "Can't find post by id: "
+var ErrNoPostAuthor = "Can't find post author by nickname: "
+var ErrNoParent = "Can't find parent post in thread: "
+var ErrVoteRejected = "Can't save vote for thread: "
+var ErrPreconditionFailed = "Resource was changed since it was read, fetch it again"
+
+// ErrExists is returned with the existing entity when the created one is
+// already there. The transports answer it with the entity, not an error.
+var ErrExists = errors.New("already exists")
+
+// Service runs the writes of the API, each in one transaction. The REST
+// handlers and the gRPC server only decode the request and encode the result.
+type Service struct {
+	storage *psql.Storage
+}
+
+func NewService(store *psql.Storage) *Service {
+	return &Service{
+		storage: store,
+	}
+}
+
+// ETag is the strong entity tag of a row version. The REST API adds the
+// format to the tags of msgpack and protobuf, like "7-msgpack".
+func ETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// MatchVersion checks an If-Match value against the current version of the
+// resource. It returns the version the update must be conditional on, which
+// is 0 without If-Match or with "*".
+func MatchVersion(header string, version int) (int, error) {
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if i := strings.IndexByte(tag, '-'); i >= 0 && strings.HasSuffix(tag, `"`) {
+			tag = tag[:i] + `"`
+		}
+		if tag == ETag(version) {
+			return version, nil
+		}
+	}
+	return 0, apperr.New(apperr.CodeVersionChanged, ErrPreconditionFailed)
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"techpark_db/internal/apperr"
+	"techpark_db/internal/domain/entity"
+	"techpark_db/internal/infra/psql"
+	"time"
+)
+
+// CreatePosts saves the posts in the thread with one creation time.
+func (s *Service) CreatePosts(ctx context.Context, slug_or_id string, postReq entity.CreatePosts) (entity.Posts, error) {
+	created := time.Now().Format(time.RFC3339Nano)
+	created = created[:len(created)-4]
+
+	var thread *entity.Thread
+	var ids *[]int
+	err := s.storage.RunInTx(ctx, sql.LevelReadCommitted, func(tx *sql.Tx) error {
+		var err error
+		thread, err = s.storage.GetThread(tx, slug_or_id)
+		if err != nil {
+			return apperr.New(apperr.CodeThreadNotFound, ErrNoThread+slug_or_id)
+		}
+
+		if len(postReq) == 0 {
+			return nil
+		}
+
+		// an old nickname resolves through the history, the posts are saved
+		// under the current one
+		authors := make(map[string]string)
+		for i := range postReq {
+			key := strings.ToLower(postReq[i].Author)
+			nickname, ok := authors[key]
+			if !ok {
+				user, err := s.storage.GetUser(tx, postReq[i].Author)
+				if err != nil {
+					return apperr.New(apperr.CodeUserNotFound, ErrNoPostAuthor+postReq[i].Author)
+				}
+				nickname = user.Nickname
+				authors[key] = nickname
+			}
+			postReq[i].Author = nickname
+		}
+
+		parents := make([]int, 0)
+		seen := make(map[int]bool)
+		for _, post := range postReq {
+			if post.Parent != 0 && !seen[post.Parent] {
+				seen[post.Parent] = true
+				parents = append(parents, post.Parent)
+			}
+		}
+		if len(parents) > 0 {
+			ok, err := s.storage.CheckParentPosts(tx, parents, thread.Id)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return apperr.New(apperr.CodeParentNotFound, ErrNoParent+slug_or_id)
+			}
+		}
+
+		ids, err = s.storage.SavePosts(tx, postReq, thread.Forum, thread.Id, created)
+		if err == psql.ErrParentNotFound {
+			return apperr.New(apperr.CodeParentNotFound, ErrNoParent+slug_or_id)
+		}
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(postReq) == 0 {
+		return entity.Posts{}, nil
+	}
+
+	posts := make(entity.Posts, len(*ids))
+	for i := 0; i < len(*ids); i++ {
+		posts[i] = entity.Post{
+			Id:       (*ids)[i],
+			Parent:   postReq[i].Parent,
+			Author:   postReq[i].Author,
+			Message:  postReq[i].Message,
+			Created:  created,
+			IsEdited: false,
+			Forum:    thread.Forum,
+			Thread:   thread.Id,
+		}
+	}
+	return posts, nil
+}
+
+// Vote sets the voice of the user in the thread and returns the thread
+// with the new vote count.
+func (s *Service) Vote(ctx context.Context, slug_or_id string, voteReq entity.Vote) (*entity.Thread, error) {
+	var thread *entity.Thread
+	err := s.storage.RunInTx(ctx, sql.LevelReadCommitted, func(tx *sql.Tx) error {
+		var err error
+		thread, err = s.storage.GetThread(tx, slug_or_id)
+		if err != nil {
+			return apperr.New(apperr.CodeThreadNotFound, ErrNoThread+slug_or_id)
+		}
+		voteReq.IdThread = thread.Id
+
+		user, err := s.storage.GetUser(tx, voteReq.Nickname)
+		if err != nil {
+			return apperr.New(apperr.CodeUserNotFound, ErrNoUser+voteReq.Nickname)
+		}
+		voteReq.Nickname = user.Nickname
+
+		if err := s.storage.SetVote(tx, voteReq); err != nil {
+			if psql.IsRetryable(err) {
+				return err
+			}
+			return apperr.New(apperr.CodeVoteRejected, ErrVoteRejected+slug_or_id)
+		}
+
+		voteCount, err := s.storage.CountVote(tx, thread.Id)
+		if err != nil {
+			return err
+		}
+		thread.Votes = *voteCount
+		return nil
+	})
+	return thread, err
+}
+
+// UpdateThread changes the non-empty fields of the thread if it is still at
+// the version ifMatch names.
+func (s *Service) UpdateThread(ctx context.Context, slug_or_id string, threadReq entity.Thread, ifMatch string) (*entity.Thread, error) {
+	var thread *entity.Thread
+	err := s.storage.RunInTx(ctx, sql.LevelReadCommitted, func(tx *sql.Tx) error {
+		var err error
+		thread, err = s.storage.GetThread(tx, slug_or_id)
+		if err != nil {
+			return apperr.New(apperr.CodeThreadNotFound, ErrNoThread+slug_or_id)
+		}
+
+		version, err := MatchVersion(ifMatch, thread.Version)
+		if err != nil {
+			return err
+		}
+		if threadReq.Title == "" && threadReq.Message == "" {
+			return nil
+		}
+
+		thread, err = s.storage.UpdateThread(tx, thread.Id, threadReq.Title, threadReq.Message, version)
+		if err == psql.ErrVersionMismatch {
+			return apperr.New(apperr.CodeVersionChanged, ErrPreconditionFailed)
+		}
+		return err
+	})
+	return thread, err
+}
+
+// MoveThread moves the thread with its posts to another forum, leaving
+// a stub thread behind on request.
+func (s *Service) MoveThread(ctx context.Context, slug_or_id string, moveReq entity.MoveThread) (*entity.Thread, error) {
+	var thread *entity.Thread
+	err := s.storage.RunInTx(ctx, sql.LevelReadCommitted, func(tx *sql.Tx) error {
+		var err error
+		thread, err = s.storage.GetThread(tx, slug_or_id)
+		if err != nil {
+			return apperr.New(apperr.CodeThreadNotFound, ErrNoThread+slug_or_id)
+		}
+
+		forum, err := s.storage.GetForum(tx, moveReq.Forum)
+		if err != nil {
+			return apperr.New(apperr.CodeForumNotFound, ErrNoTargetForum+moveReq.Forum)
+		}
+
+		// the thread is read again under the lock, it may have moved meanwhile
+		thread, err = s.storage.MoveThread(tx, thread.Id, forum.Slug)
+		if err == sql.ErrNoRows {
+			return apperr.New(apperr.CodeThreadNotFound, ErrNoThread+slug_or_id)
+		}
+		if err != nil {
+			return err
+		}
+		if strings.EqualFold(forum.Slug, thread.Forum) {
+			return nil
+		}
+
+		audit := entity.ThreadMoveAudit{
+			Thread: thread.Id,
+			From:   thread.Forum,
+			To:     forum.Slug,
+		}
+
+		if moveReq.Stub {
+			stub := entity.CreateThread{
+				Title:   MovedStubTitle(thread.Title),
+				Author:  thread.Author,
+				Message: fmt.Sprintf("Thread %d has been moved to forum %s", thread.Id, forum.Slug),
+				Created: time.Now().Format(time.RFC3339Nano),
+			}
+			stubId, err := s.storage.SaveThread(tx, stub, thread.Forum)
+			if err != nil {
+				return err
+			}
+			audit.Stub = stubId
+		}
+
+		if err := s.storage.SaveAudit(tx, entity.AuditThreadMove, audit); err != nil {
+			return err
+		}
+		thread.Forum = forum.Slug
+		return nil
+	})
+	return thread, err
+}
+
+// MergeThreads moves the posts of the thread into the target thread and
+// returns the target.
+func (s *Service) MergeThreads(ctx context.Context, slug_or_id string, mergeReq entity.MergeThread) (*entity.Thread, error) {
+	var target *entity.Thread
+	err := s.storage.RunInTx(ctx, sql.LevelReadCommitted, func(tx *sql.Tx) error {
+		source, err := s.storage.GetThread(tx, slug_or_id)
+		if err != nil {
+			return apperr.New(apperr.CodeThreadNotFound, ErrNoThread+slug_or_id)
+		}
+
+		target, err = s.storage.GetThread(tx, mergeReq.Target)
+		if err != nil {
+			return apperr.New(apperr.CodeThreadNotFound, ErrNoTargetThread+mergeReq.Target)
+		}
+
+		if source.Id == target.Id {
+			return apperr.New(apperr.CodeSameThread, ErrMergeSameThread+slug_or_id)
+		}
+
+		posts, err := s.storage.MergeThreads(tx, *source, *target)
+		if err != nil {
+			return err
+		}
+
+		audit := entity.ThreadMergeAudit{
+			Source: source.Id,
+			Target: target.Id,
+			Posts:  posts,
+		}
+		if err := s.storage.SaveAudit(tx, entity.AuditThreadMerge, audit); err != nil {
+			return err
+		}
+
+		target, err = s.storage.GetThreadById(tx, target.Id)
+		return err
+	})
+	return target, err
+}
+
+// MovedStubTitle keeps the stub title within the Thread.Title column limit.
+func MovedStubTitle(title string) string {
+	stubTitle := []rune(MOVED_STUB_PREFIX + title)
+	if len(stubTitle) > TITLE_MAX_LENGTH {
+		stubTitle = stubTitle[:TITLE_MAX_LENGTH]
+	}
+	return string(stubTitle)
+}
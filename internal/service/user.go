@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"techpark_db/internal/apperr"
+	"techpark_db/internal/domain/entity"
+	"techpark_db/internal/infra/psql"
+)
+
+// CreateUser returns ErrExists with the users already holding the nickname
+// or the email.
+func (s *Service) CreateUser(ctx context.Context, nickname string, userReq entity.CreateUser) (*entity.User, entity.Users, error) {
+	var users *[]entity.User
+	err := s.storage.RunInTx(ctx, sql.LevelReadCommitted, func(tx *sql.Tx) error {
+		var err error
+		users, err = s.storage.FindUser(tx, nickname, userReq.Email)
+		if err == nil && len(*users) > 0 {
+			return ErrExists
+		}
+
+		return s.storage.SaveUser(tx, userReq, nickname)
+	})
+
+	if err == ErrExists {
+		return nil, entity.Users(*users), err
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	user := &entity.User{
+		Nickname: nickname,
+		Fullname: userReq.Fullname,
+		About:    userReq.About,
+		Email:    userReq.Email,
+	}
+	return user, nil, nil
+}
+
+// UpdateUser changes the non-empty fields of the user if it is still at the
+// version ifMatch names.
+func (s *Service) UpdateUser(ctx context.Context, nickname string, userReq entity.UpdateUser, ifMatch string) (*entity.User, error) {
+	var user *entity.User
+	err := s.storage.RunInTx(ctx, sql.LevelReadCommitted, func(tx *sql.Tx) error {
+		var err error
+		user, err = s.storage.GetUser(tx, nickname)
+		if err != nil {
+			return apperr.New(apperr.CodeUserNotFound, ErrNoUser+nickname)
+		}
+
+		version, err := MatchVersion(ifMatch, user.Version)
+		if err != nil {
+			return err
+		}
+		if userReq.Fullname == "" && userReq.About == "" && userReq.Email == "" {
+			return nil
+		}
+
+		if userReq.Email != "" {
+			users, err := s.storage.FindUser(tx, user.Nickname, userReq.Email)
+			if err == nil && len(*users) > 1 {
+				return apperr.New(apperr.CodeEmailTaken, ErrEmailAlreadyRegistered+user.Nickname)
+			}
+		}
+
+		updated, err := s.storage.UpdateUser(tx, userReq, user.Nickname, version)
+		if err != nil {
+			if psql.IsRetryable(err) {
+				return err
+			}
+			if err == psql.ErrVersionMismatch {
+				return apperr.New(apperr.CodeVersionChanged, ErrPreconditionFailed)
+			}
+			return apperr.New(apperr.CodeEmailTaken, ErrEmailAlreadyRegistered+user.Nickname)
+		}
+		user = updated
+		return nil
+	})
+	return user, err
+}
+
+// RenameUser gives the user a free nickname, the old one stays in the history.
+func (s *Service) RenameUser(ctx context.Context, nickname string, renameReq entity.RenameUser) (*entity.User, error) {
+	var user *entity.User
+	err := s.storage.RunInTx(ctx, sql.LevelReadCommitted, func(tx *sql.Tx) error {
+		var err error
+		user, err = s.storage.GetUser(tx, nickname)
+		if err != nil {
+			return apperr.New(apperr.CodeUserNotFound, ErrNoUser+nickname)
+		}
+
+		free, err := s.storage.CheckNicknameFree(tx, renameReq.Nickname, user.Nickname)
+		if err != nil {
+			return err
+		}
+		if !free {
+			return apperr.New(apperr.CodeNicknameTaken, ErrNicknameTaken+renameReq.Nickname)
+		}
+
+		// a concurrent rename may take the nickname after the check
+		err = s.storage.RenameUser(tx, user.Nickname, renameReq.Nickname)
+		if psql.IsUniqueViolation(err) {
+			return apperr.New(apperr.CodeNicknameTaken, ErrNicknameTaken+renameReq.Nickname)
+		}
+		if err != nil {
+			return err
+		}
+		user.Nickname = renameReq.Nickname
+		return nil
+	})
+	return user, err
+}
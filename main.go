@@ -4,12 +4,56 @@ import (
 	"github.com/gorilla/mux"
 	_ "github.com/lib/pq"
 	log "github.com/sirupsen/logrus"
+	"net"
 	"net/http"
+	"os"
+	"strconv"
+	"techpark_db/internal/dump"
 	"techpark_db/internal/handler"
+	"techpark_db/internal/handler/gql"
 	mw "techpark_db/internal/handler/middleware"
+	"techpark_db/internal/handler/router"
+	"techpark_db/internal/handler/rpc"
+	"techpark_db/internal/infra/cache"
 	"techpark_db/internal/infra/psql"
+	"techpark_db/internal/infra/ratelimit"
+	"time"
 )
 
+// RATE_LIMIT_POLICIES_ENV names the YAML file of the rate limit policies,
+// e.g. configs/ratelimit.yaml. Requests are not limited without it.
+const RATE_LIMIT_POLICIES_ENV = "RATE_LIMIT_POLICIES"
+
+// RATE_LIMITER_ENV selects the rate limiter of the policies: "memory"
+// (default), "postgres" to share the limits between instances or "off".
+const RATE_LIMITER_ENV = "RATE_LIMITER"
+
+// CACHE_CONTROL_POLICIES_ENV names the YAML file of the Cache-Control
+// policies, e.g. configs/cache-control.yaml. No Cache-Control is sent without it.
+const CACHE_CONTROL_POLICIES_ENV = "CACHE_CONTROL_POLICIES"
+
+// MAX_LIMIT_ENV overrides the largest "limit" of list requests.
+const MAX_LIMIT_ENV = "MAX_LIMIT"
+
+// IDEMPOTENCY_TTL_ENV overrides how long idempotency keys are kept, e.g. "1h".
+const IDEMPOTENCY_TTL_ENV = "IDEMPOTENCY_TTL"
+
+// IDEMPOTENCY_LEASE_ENV overrides how long a request in progress holds its
+// idempotency key, e.g. "30s". It should outlast the slowest request.
+const IDEMPOTENCY_LEASE_ENV = "IDEMPOTENCY_LEASE"
+
+// CACHE_ENV set to "off" turns off the cache of users, forums and threads.
+const CACHE_ENV = "CACHE"
+
+// GRPC_PORT_ENV sets the port of the gRPC API, e.g. "5001". The gRPC API
+// is off without it.
+const GRPC_PORT_ENV = "GRPC_PORT"
+
+// POST_FEED_ENV set to "on" serves the PostFeed call of the gRPC API. Every
+// instance then notifies about the posts it creates, so the feed must be on
+// for all instances sharing the database.
+const POST_FEED_ENV = "POST_FEED"
+
 func main() {
 	db, err := psql.Connect()
 	if err != nil {
@@ -18,41 +62,117 @@ func main() {
 	defer db.Close()
 	log.Info("Successful connect to database.")
 
-	psqlStorage := psql.NewStorage(db)
+	replicas, err := psql.ConnectReplicas()
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, replica := range replicas {
+		defer replica.Close()
+	}
+
+	psqlStorage := psql.NewStorage(db, replicas...)
+
+	if len(os.Args) > 1 {
+		if err := dump.RunCommand(psqlStorage, os.Args[1:]); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if err := psqlStorage.PrepareStatements(); err != nil {
+		log.Warning("Statements will be prepared on first use: ", err)
+	}
+
+	psqlStorage.CheckReplicas(psql.REPLICA_CHECK_INTERVAL)
+
+	if os.Getenv(CACHE_ENV) != "off" {
+		psqlStorage.EnableCache(cache.DEFAULT_SIZE, cache.DEFAULT_TTL)
+		if err := psqlStorage.ListenCache(psql.DSN()); err != nil {
+			log.Warning("Cache invalidation of other instances is not received: ", err)
+		}
+	}
 
 	handler := handler.NewHandler(psqlStorage)
+	rpcServer := rpc.NewServer(psqlStorage)
+	gqlHandler, err := gql.NewHandler(psqlStorage)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if maxLimit := os.Getenv(MAX_LIMIT_ENV); maxLimit != "" {
+		limit, err := strconv.Atoi(maxLimit)
+		if err != nil || limit < 1 {
+			log.Fatal("invalid ", MAX_LIMIT_ENV, ": ", maxLimit)
+		}
+		handler.SetMaxLimit(limit)
+		rpcServer.SetMaxLimit(limit)
+		gqlHandler.SetMaxLimit(limit)
+	}
+
+	middlewares := []mux.MiddlewareFunc{mw.CompressMiddleware}
+	if path := os.Getenv(RATE_LIMIT_POLICIES_ENV); path != "" {
+		rateLimitPolicies, err := ratelimit.LoadPolicies(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		var rateLimit *mw.RateLimit
+		switch os.Getenv(RATE_LIMITER_ENV) {
+		case "off":
+		case "postgres":
+			rateLimit = mw.NewRateLimit(psql.NewRateLimiter(psqlStorage), rateLimitPolicies)
+		default:
+			rateLimit = mw.NewRateLimit(ratelimit.NewMemory(), rateLimitPolicies)
+		}
+		if rateLimit != nil {
+			middlewares = append(middlewares, rateLimit.Middleware)
+			rpcServer.SetRateLimit(rateLimit)
+		}
+	}
+	if path := os.Getenv(CACHE_CONTROL_POLICIES_ENV); path != "" {
+		cacheControlPolicies, err := mw.LoadCacheControlPolicies(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		middlewares = append(middlewares, mw.NewCacheControl(cacheControlPolicies).Middleware)
+	}
+	middlewares = append(middlewares, mw.ReadYourWritesMiddleware)
+
+	idempotencyTTL := mw.IDEMPOTENCY_TTL
+	if ttl := os.Getenv(IDEMPOTENCY_TTL_ENV); ttl != "" {
+		if idempotencyTTL, err = time.ParseDuration(ttl); err != nil {
+			log.Fatal(err)
+		}
+	}
+	idempotencyLease := mw.IDEMPOTENCY_LEASE
+	if lease := os.Getenv(IDEMPOTENCY_LEASE_ENV); lease != "" {
+		if idempotencyLease, err = time.ParseDuration(lease); err != nil {
+			log.Fatal(err)
+		}
+	}
+	idempotency := mw.NewIdempotency(psqlStorage, idempotencyTTL, idempotencyLease)
+	middlewares = append(middlewares, idempotency.Middleware)
+	rpcServer.SetIdempotency(idempotency)
+
+	if os.Getenv(POST_FEED_ENV) == "on" {
+		if err := psqlStorage.ListenPosts(psql.DSN()); err != nil {
+			log.Warning("Post feed is not available: ", err)
+		}
+	}
+
+	if grpcPort := os.Getenv(GRPC_PORT_ENV); grpcPort != "" {
+
+		listener, err := net.Listen("tcp", ":"+grpcPort)
+		if err != nil {
+			log.Fatal(err)
+		}
+		go func() {
+			log.Info("Start gRPC server at port " + grpcPort + "...")
+			if err := rpc.NewGRPCServer(rpcServer).Serve(listener); err != nil {
+				log.Fatal(err)
+			}
+		}()
+	}
 
-	router := mux.NewRouter()
-	routerAPI := router.PathPrefix("/api").Subrouter()
-
-	/*====================== FORUM ======================*/
-	routerAPI.HandleFunc("/forum/create", handler.ForumCreate).Methods("POST")
-	routerAPI.HandleFunc("/forum/{slug:[A-Za-z0-9._-]+}/details", handler.ForumDetails).Methods("GET")
-	routerAPI.HandleFunc("/forum/{slug:[A-Za-z0-9._-]+}/create", handler.ForumCreateThread).Methods("POST")
-	routerAPI.HandleFunc("/forum/{slug:[A-Za-z0-9._-]+}/users", handler.ForumUsers).Methods("GET")
-	routerAPI.HandleFunc("/forum/{slug:[A-Za-z0-9._-]+}/threads", handler.ForumThreads).Methods("GET")
-
-	/*====================== THREAD ======================*/
-	routerAPI.HandleFunc("/thread/{slug_or_id:[A-Za-z0-9._-]+}/create", handler.ThreadCreatePosts).Methods("POST")
-	routerAPI.HandleFunc("/thread/{slug_or_id:[A-Za-z0-9._-]+}/vote", handler.ThreadVote).Methods("POST")
-	routerAPI.HandleFunc("/thread/{slug_or_id:[A-Za-z0-9._-]+}/details", handler.ThreadDetails).Methods("GET")
-	routerAPI.HandleFunc("/thread/{slug_or_id:[A-Za-z0-9._-]+}/details", handler.ThreadUpdate).Methods("POST")
-	routerAPI.HandleFunc("/thread/{slug_or_id:[A-Za-z0-9._-]+}/posts", handler.ThreadPosts).Methods("GET")
-
-	/*====================== POST ======================*/
-	routerAPI.HandleFunc("/post/{id:[0-9]+}/details", handler.PostGet).Methods("GET")
-	routerAPI.HandleFunc("/post/{id:[0-9]+}/details", handler.PostUpdate).Methods("POST")
-
-	/*====================== USER ======================*/
-	routerAPI.HandleFunc("/user/{nickname:[A-Za-z0-9._-]+}/create", handler.UserCreate).Methods("POST")
-	routerAPI.HandleFunc("/user/{nickname:[A-Za-z0-9._-]+}/profile", handler.UserDetails).Methods("GET")
-	routerAPI.HandleFunc("/user/{nickname:[A-Za-z0-9._-]+}/profile", handler.UserUpdate).Methods("POST")
-
-	/*====================== SERVICE ======================*/
-	routerAPI.HandleFunc("/service/status", handler.ServiceStatus).Methods("GET")
-	routerAPI.HandleFunc("/service/clear", handler.ServiceClear).Methods("POST")
-
-	routerAPI.Use(mw.TimeLogMiddleware)
+	router := router.NewRouter(handler, gqlHandler, middlewares...)
 
 	log.Info("Start server at port 5000...")
 	if err := http.ListenAndServe(":5000", router); err != nil {
@@ -0,0 +1,248 @@
+// Package client is the Go client of the forum API. The methods take and
+// return the bodies of entity.go and encode them with their easyjson codecs.
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/mailru/easyjson"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DEFAULT_RETRIES     = 3
+	DEFAULT_BACKOFF     = 100 * time.Millisecond
+	MAX_BACKOFF         = 5 * time.Second
+	CONTENT_TYPE_JSON   = "application/json"
+	CONTENT_TYPE_ERROR  = "application/problem+json"
+	IDEMPOTENCY_HEADER  = "Idempotency-Key"
+	READ_PRIMARY_HEADER = "X-Read-Primary"
+)
+
+// Client calls the API under baseURL, e.g. "http://localhost:5000/api". It
+// is safe for concurrent use.
+type Client struct {
+	baseURL string
+	http    *http.Client
+	retries int
+	backoff time.Duration
+}
+
+type Option func(*Client)
+
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.http = httpClient
+	}
+}
+
+// WithRetries sets how many times a failed request is repeated, 0 turns
+// the retries off.
+func WithRetries(retries int) Option {
+	return func(c *Client) {
+		c.retries = retries
+	}
+}
+
+// WithBackoff sets the wait before the first retry, it doubles with every
+// next one.
+func WithBackoff(backoff time.Duration) Option {
+	return func(c *Client) {
+		c.backoff = backoff
+	}
+}
+
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		http:    http.DefaultClient,
+		retries: DEFAULT_RETRIES,
+		backoff: DEFAULT_BACKOFF,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// call is one API call, set up by the CallOptions.
+type call struct {
+	method   string
+	path     string
+	query    url.Values
+	in       easyjson.Marshaler
+	header   http.Header
+	etag     *string
+	existing easyjson.Unmarshaler
+}
+
+type CallOption func(*call)
+
+// IfMatch makes an update fail with ErrPreconditionFailed when the entity
+// changed since the read which returned etag.
+func IfMatch(etag string) CallOption {
+	return func(cl *call) {
+		cl.header.Set("If-Match", etag)
+	}
+}
+
+// IdempotencyKey replaces the random key of a POST, to repeat a request
+// across restarts of the caller.
+func IdempotencyKey(key string) CallOption {
+	return func(cl *call) {
+		cl.header.Set(IDEMPOTENCY_HEADER, key)
+	}
+}
+
+// ReadETag stores the ETag of the response in etag, for IfMatch.
+func ReadETag(etag *string) CallOption {
+	return func(cl *call) {
+		cl.etag = etag
+	}
+}
+
+// ReadPrimary reads from the primary database while the replicas may lag
+// behind a write, with the value of the X-Read-Primary header the write
+// answered with.
+func ReadPrimary(deadline string) CallOption {
+	return func(cl *call) {
+		cl.header.Set(READ_PRIMARY_HEADER, deadline)
+	}
+}
+
+// do runs the call and decodes the response body into out. POST requests
+// get an idempotency key, so every request can be retried: the server
+// answers a repeated POST with the response of the first one.
+func (c *Client) do(ctx context.Context, cl *call, out easyjson.Unmarshaler, opts []CallOption) error {
+	if cl.header == nil {
+		cl.header = make(http.Header)
+	}
+	for _, opt := range opts {
+		opt(cl)
+	}
+
+	var body []byte
+	if cl.in != nil {
+		var err error
+		if body, err = easyjson.Marshal(cl.in); err != nil {
+			return err
+		}
+	}
+	if cl.method == http.MethodPost && cl.header.Get(IDEMPOTENCY_HEADER) == "" {
+		cl.header.Set(IDEMPOTENCY_HEADER, newKey())
+	}
+
+	backoff := c.backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, cl, body)
+		if err == nil {
+			// only the error statuses are retried, not a broken body
+			if err = c.decode(cl, resp, out); err != nil && !isStatus(err) {
+				return err
+			}
+		}
+		if err == nil || attempt >= c.retries || !retryable(err) {
+			return err
+		}
+
+		wait := backoff
+		if e, ok := err.(*Error); ok && e.RetryAfter > wait {
+			wait = e.RetryAfter
+		}
+		if wait > MAX_BACKOFF {
+			wait = MAX_BACKOFF
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		backoff *= 2
+	}
+}
+
+func (c *Client) send(ctx context.Context, cl *call, body []byte) (*http.Response, error) {
+	target := c.baseURL + cl.path
+	if len(cl.query) > 0 {
+		target += "?" + cl.query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, cl.method, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range cl.header {
+		req.Header[name] = values
+	}
+	req.Header.Set("Accept", CONTENT_TYPE_JSON)
+	if body != nil {
+		req.Header.Set("Content-Type", CONTENT_TYPE_JSON)
+	}
+	return c.http.Do(req)
+}
+
+func (c *Client) decode(cl *call, resp *http.Response, out easyjson.Unmarshaler) error {
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return responseError(resp, data, cl.existing)
+	}
+	if cl.etag != nil {
+		*cl.etag = resp.Header.Get("ETag")
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return easyjson.Unmarshal(data, out)
+}
+
+// responseError makes the error of a response. A create conflicting with an
+// existing entity answers with the entity, which is decoded into existing.
+func responseError(resp *http.Response, data []byte, existing easyjson.Unmarshaler) error {
+	e := &Error{Status: resp.StatusCode}
+	if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+		e.RetryAfter = time.Duration(seconds) * time.Second
+	}
+
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), CONTENT_TYPE_ERROR) {
+		if err := easyjson.Unmarshal(data, &e.Problem); err == nil {
+			return e
+		}
+	}
+	if resp.StatusCode == http.StatusConflict && existing != nil {
+		if err := easyjson.Unmarshal(data, existing); err == nil {
+			e.Problem = Problem{
+				Status: resp.StatusCode,
+				Code:   CodeExists,
+				Detail: ErrExistsDetail,
+			}
+			e.Existing = existing
+			return e
+		}
+	}
+	e.Problem = Problem{
+		Status: resp.StatusCode,
+		Detail: http.StatusText(resp.StatusCode),
+	}
+	return e
+}
+
+func newKey() string {
+	key := make([]byte, 16)
+	rand.Read(key)
+	return hex.EncodeToString(key)
+}
+
+func escape(segment string) string {
+	return url.PathEscape(segment)
+}
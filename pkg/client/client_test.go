@@ -0,0 +1,212 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"github.com/gorilla/mux"
+	"net/http/httptest"
+	"techpark_db/internal/handler"
+	"techpark_db/internal/handler/gql"
+	mw "techpark_db/internal/handler/middleware"
+	"techpark_db/internal/handler/router"
+	"techpark_db/internal/infra/psql"
+	"techpark_db/internal/infra/psql/psqltest"
+	"techpark_db/internal/infra/ratelimit"
+	"techpark_db/pkg/client"
+	"testing"
+	"time"
+)
+
+// newClient serves the real router over store, requests are not retried.
+func newClient(t *testing.T, store *psql.Storage, middlewares ...mux.MiddlewareFunc) *client.Client {
+	t.Helper()
+	gqlHandler, err := gql.NewHandler(store)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(router.NewRouter(handler.NewHandler(store), gqlHandler, middlewares...))
+	t.Cleanup(server.Close)
+	return client.New(server.URL+"/api", client.WithRetries(0))
+}
+
+// testClient serves a cleared test database with the idempotency keys of
+// the POST requests stored.
+func testClient(t *testing.T) *client.Client {
+	t.Helper()
+	store := psql.NewStorage(psqltest.Open(t))
+	idempotency := mw.NewIdempotency(store, time.Hour, time.Minute)
+	c := newClient(t, store, mw.ReadYourWritesMiddleware, idempotency.Middleware)
+	if err := c.Clear(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestInvalidBody(t *testing.T) {
+	c := newClient(t, psql.NewStorage(nil))
+
+	_, err := c.CreateUser(context.Background(), "author", client.CreateUser{Fullname: "Author", Email: "not an email"})
+	if !errors.Is(err, client.ErrBadRequest) {
+		t.Fatalf("create: %v, want %v", err, client.ErrBadRequest)
+	}
+	var e *client.Error
+	if !errors.As(err, &e) || len(e.Problem.Errors) == 0 || e.Problem.Errors[0].Field == "" {
+		t.Errorf("problem %+v has no invalid field", e)
+	}
+}
+
+func TestRateLimited(t *testing.T) {
+	rateLimit := mw.NewRateLimit(ratelimit.NewMemory(), map[string]ratelimit.Policy{
+		"POST /api/user/{nickname}/create": {Rate: 0.001, Burst: 1},
+	})
+	c := newClient(t, psql.NewStorage(nil), rateLimit.Middleware)
+	ctx := context.Background()
+
+	// the invalid request takes the only token before the storage
+	user := client.CreateUser{Email: "not an email"}
+	if _, err := c.CreateUser(ctx, "author", user); !errors.Is(err, client.ErrBadRequest) {
+		t.Fatalf("first: %v, want %v", err, client.ErrBadRequest)
+	}
+	_, err := c.CreateUser(ctx, "author", user)
+	var e *client.Error
+	if !errors.As(err, &e) || !errors.Is(err, client.ErrRateLimited) {
+		t.Fatalf("second: %v, want %v", err, client.ErrRateLimited)
+	}
+	if e.RetryAfter <= 0 {
+		t.Errorf("retry after %v", e.RetryAfter)
+	}
+}
+
+func TestClient(t *testing.T) {
+	c := testClient(t)
+	ctx := context.Background()
+
+	for _, nickname := range []string{"author", "reader", "voter"} {
+		if _, err := c.CreateUser(ctx, nickname, client.CreateUser{Fullname: "User", Email: nickname + "@example.com"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err := c.CreateUser(ctx, "author", client.CreateUser{Fullname: "Other", Email: "reader@example.com"})
+	var e *client.Error
+	if !errors.As(err, &e) || !errors.Is(err, client.ErrExists) {
+		t.Fatalf("existing user: %v, want %v", err, client.ErrExists)
+	}
+	if existing, ok := e.Existing.(*client.Users); !ok || len(*existing) != 2 {
+		t.Errorf("existing %#v, want the two users", e.Existing)
+	}
+
+	if _, err := c.CreateForum(ctx, client.CreateForum{Title: "Forum", User: "author", Slug: "forum"}); err != nil {
+		t.Fatal(err)
+	}
+	thread, err := c.CreateThread(ctx, "forum", client.CreateThread{Title: "Thread", Author: "author", Message: "message", Slug: "thread"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	posts, err := c.CreatePosts(ctx, "thread", client.CreatePosts{{Author: "reader", Message: "first"}, {Author: "voter", Message: "second"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CreatePosts(ctx, "thread", client.CreatePosts{{Author: "reader", Message: "reply", Parent: posts[0].Id}}); err != nil {
+		t.Fatal(err)
+	}
+	voted, err := c.Vote(ctx, "thread", client.Vote{Nickname: "voter", Voice: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if voted.Votes != 1 {
+		t.Errorf("%d votes, want 1", voted.Votes)
+	}
+
+	var etag string
+	if _, err := c.Thread(ctx, "thread", client.ReadETag(&etag)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.UpdateThread(ctx, "thread", client.Thread{Message: "edited"}, client.IfMatch(etag)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.UpdateThread(ctx, "thread", client.Thread{Message: "stale"}, client.IfMatch(etag)); !errors.Is(err, client.ErrPreconditionFailed) {
+		t.Fatalf("stale update: %v, want %v", err, client.ErrPreconditionFailed)
+	}
+
+	var nicknames []string
+	users := c.ForumUsersIter("forum", client.ListOptions{Limit: 1})
+	for users.Next(ctx) {
+		nicknames = append(nicknames, users.User().Nickname)
+	}
+	if err := users.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if len(nicknames) != 3 {
+		t.Errorf("forum users %v, want all three", nicknames)
+	}
+
+	count := 0
+	tree := c.ThreadPostsIter("thread", client.ListOptions{Limit: 2, Sort: "tree"})
+	for tree.Next(ctx) {
+		count++
+	}
+	if err := tree.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 {
+		t.Errorf("%d posts, want 3", count)
+	}
+
+	details, err := c.Post(ctx, posts[0].Id, []string{"user", "thread"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if details.DAuthor == nil || details.DAuthor.Nickname != "reader" || details.DThread == nil || details.DThread.Id != thread.Id {
+		t.Errorf("details %+v", details)
+	}
+	if _, err := c.Post(ctx, 1000000, nil); !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("missing post: %v, want %v", err, client.ErrNotFound)
+	}
+
+	renamed, err := c.RenameUser(ctx, "reader", "writer")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if renamed.Nickname != "writer" {
+		t.Errorf("renamed to %q", renamed.Nickname)
+	}
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	c := testClient(t)
+	ctx := context.Background()
+
+	if _, err := c.CreateUser(ctx, "author", client.CreateUser{Fullname: "Author", Email: "author@example.com"}); err != nil {
+		t.Fatal(err)
+	}
+	forum := client.CreateForum{Title: "Forum", User: "author", Slug: "forum"}
+	first, err := c.CreateForum(ctx, forum, client.IdempotencyKey("forum"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the repeat gets the response of the first request, not ErrExists
+	repeat, err := c.CreateForum(ctx, forum, client.IdempotencyKey("forum"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *repeat != *first {
+		t.Errorf("repeat %+v, want %+v", repeat, first)
+	}
+	if _, err := c.CreateForum(ctx, forum); !errors.Is(err, client.ErrExists) {
+		t.Fatalf("new key: %v, want %v", err, client.ErrExists)
+	}
+
+	// the replay carries the ETag of the first response
+	var etag, replayed string
+	update := client.UpdateUser{About: "updated"}
+	if _, err := c.UpdateUser(ctx, "author", update, client.IdempotencyKey("update"), client.ReadETag(&etag)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.UpdateUser(ctx, "author", update, client.IdempotencyKey("update"), client.ReadETag(&replayed)); err != nil {
+		t.Fatal(err)
+	}
+	if etag == "" || replayed != etag {
+		t.Errorf("replayed ETag %q, want %q", replayed, etag)
+	}
+}
@@ -0,0 +1,41 @@
+package client
+
+import "techpark_db/internal/domain/entity"
+
+// The bodies of the API. They are the structs of the server, which other
+// modules can not import from its internal packages, under public names.
+type (
+	Forum       = entity.Forum
+	CreateForum = entity.CreateForum
+
+	Thread       = entity.Thread
+	Threads      = entity.Threads
+	CreateThread = entity.CreateThread
+	MoveThread   = entity.MoveThread
+	MergeThread  = entity.MergeThread
+	Vote         = entity.Vote
+
+	Post        = entity.Post
+	Posts       = entity.Posts
+	PostNode    = entity.PostNode
+	PostNodes   = entity.PostNodes
+	MoreReplies = entity.MoreReplies
+	PostDetails = entity.PostDetails
+	CreatePost  = entity.CreatePost
+	CreatePosts = entity.CreatePosts
+	UpdatePost  = entity.UpdatePost
+	SplitPost   = entity.SplitPost
+
+	User       = entity.User
+	Users      = entity.Users
+	CreateUser = entity.CreateUser
+	UpdateUser = entity.UpdateUser
+	RenameUser = entity.RenameUser
+
+	ServStatus  = entity.ServStatus
+	CacheStatus = entity.CacheStatus
+	CacheStats  = entity.CacheStats
+
+	Problem    = entity.Problem
+	FieldError = entity.FieldError
+)
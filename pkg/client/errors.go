@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// The codes of Problem the client acts on.
+const (
+	CodeExists        = "already_exists"
+	CodeKeyInProgress = "idempotency_key_in_progress"
+)
+
+var ErrExistsDetail = "Entity already exists"
+
+// Error is a response with an error status. Problem is the body of the
+// error, or made up from the status when the body is not a problem.
+type Error struct {
+	Status     int
+	Problem    Problem
+	RetryAfter time.Duration
+	// Existing is the entity a create conflicted with: *Forum, *Thread or
+	// *Users.
+	Existing interface{}
+}
+
+func (e *Error) Error() string {
+	msg := strconv.Itoa(e.Status) + " " + http.StatusText(e.Status)
+	if e.Problem.Code != "" {
+		msg += " (" + e.Problem.Code + ")"
+	}
+	if e.Problem.Detail != "" {
+		msg += ": " + e.Problem.Detail
+	}
+	return msg
+}
+
+// Is matches the sentinel errors below by status, and by the problem code
+// when the sentinel has one.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return t.Status == e.Status && (t.Problem.Code == "" || t.Problem.Code == e.Problem.Code)
+}
+
+var (
+	ErrBadRequest         = &Error{Status: http.StatusBadRequest}
+	ErrNotFound           = &Error{Status: http.StatusNotFound}
+	ErrConflict           = &Error{Status: http.StatusConflict}
+	ErrExists             = &Error{Status: http.StatusConflict, Problem: Problem{Code: CodeExists}}
+	ErrPreconditionFailed = &Error{Status: http.StatusPreconditionFailed}
+	ErrRateLimited        = &Error{Status: http.StatusTooManyRequests}
+	ErrInternal           = &Error{Status: http.StatusInternalServerError}
+)
+
+func isStatus(err error) bool {
+	var e *Error
+	return errors.As(err, &e)
+}
+
+// retryable tells the errors a repeated request may not get. Network errors
+// are retried too, the idempotency key keeps a repeated POST from running
+// twice.
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var e *Error
+	if !errors.As(err, &e) {
+		return true
+	}
+	switch {
+	case e.Status == http.StatusTooManyRequests:
+		return true
+	case e.Status == http.StatusConflict && e.Problem.Code == CodeKeyInProgress:
+		return true
+	case e.Status >= http.StatusInternalServerError:
+		return true
+	}
+	return false
+}
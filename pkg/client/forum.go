@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// CreateForum fails with ErrExists when the slug is taken, the existing
+// forum is in the Existing field of the error.
+func (c *Client) CreateForum(ctx context.Context, forum CreateForum, opts ...CallOption) (*Forum, error) {
+	var created Forum
+	cl := &call{method: http.MethodPost, path: "/forum/create", in: forum, existing: &Forum{}}
+	if err := c.do(ctx, cl, &created, opts); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (c *Client) Forum(ctx context.Context, slug string, opts ...CallOption) (*Forum, error) {
+	var forum Forum
+	cl := &call{method: http.MethodGet, path: "/forum/" + escape(slug) + "/details"}
+	if err := c.do(ctx, cl, &forum, opts); err != nil {
+		return nil, err
+	}
+	return &forum, nil
+}
+
+// CreateThread fails with ErrExists when the slug is taken, the existing
+// thread is in the Existing field of the error.
+func (c *Client) CreateThread(ctx context.Context, forum string, thread CreateThread, opts ...CallOption) (*Thread, error) {
+	var created Thread
+	cl := &call{method: http.MethodPost, path: "/forum/" + escape(forum) + "/create", in: thread, existing: &Thread{}}
+	if err := c.do(ctx, cl, &created, opts); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// ForumUsers returns a page of the users who posted in the forum, Since is
+// a nickname.
+func (c *Client) ForumUsers(ctx context.Context, forum string, list ListOptions, opts ...CallOption) (Users, error) {
+	var users Users
+	cl := &call{method: http.MethodGet, path: "/forum/" + escape(forum) + "/users", query: list.query()}
+	if err := c.do(ctx, cl, &users, opts); err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+// ForumThreads returns a page of the threads of the forum, Since is a
+// creation time in RFC 3339.
+func (c *Client) ForumThreads(ctx context.Context, forum string, list ListOptions, opts ...CallOption) (Threads, error) {
+	var threads Threads
+	cl := &call{method: http.MethodGet, path: "/forum/" + escape(forum) + "/threads", query: list.query()}
+	if err := c.do(ctx, cl, &threads, opts); err != nil {
+		return nil, err
+	}
+	return threads, nil
+}
@@ -0,0 +1,228 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+)
+
+const DEFAULT_PAGE_LIMIT = 100
+
+var ErrPageStuck = errors.New("client: a page of threads shares one creation time, raise the limit")
+
+// ListOptions are the query of the list routes. The zero value asks for the
+// defaults of the server.
+type ListOptions struct {
+	Limit int
+	// Since continues a list after a nickname, a creation time in RFC 3339
+	// or a post id, by the route.
+	Since string
+	Desc  bool
+	// Sort is "flat", "tree" or "parent_tree" for the posts of a thread,
+	// "tree" or "flat" for the replies.
+	Sort string
+	// Depth limits the levels of the replies.
+	Depth int
+}
+
+func (list ListOptions) query() url.Values {
+	query := url.Values{}
+	if list.Limit > 0 {
+		query.Set("limit", strconv.Itoa(list.Limit))
+	}
+	if list.Since != "" {
+		query.Set("since", list.Since)
+	}
+	if list.Desc {
+		query.Set("desc", "true")
+	}
+	if list.Sort != "" {
+		query.Set("sort", list.Sort)
+	}
+	if list.Depth > 0 {
+		query.Set("depth", strconv.Itoa(list.Depth))
+	}
+	return query
+}
+
+func (list ListOptions) pageLimit() int {
+	if list.Limit > 0 {
+		return list.Limit
+	}
+	return DEFAULT_PAGE_LIMIT
+}
+
+// The iterators walk a list page by page, continuing every page with Since
+// after the last element of the previous one:
+//
+//	it := c.ForumUsersIter("pirates", client.ListOptions{})
+//	for it.Next(ctx) {
+//		user := it.User()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+
+type UserIterator struct {
+	fetch func(ctx context.Context, list ListOptions) (Users, error)
+	list  ListOptions
+	page  Users
+	user  User
+	done  bool
+	err   error
+}
+
+// ForumUsersIter walks the users of the forum.
+func (c *Client) ForumUsersIter(forum string, list ListOptions, opts ...CallOption) *UserIterator {
+	list.Limit = list.pageLimit()
+	return &UserIterator{
+		list: list,
+		fetch: func(ctx context.Context, list ListOptions) (Users, error) {
+			return c.ForumUsers(ctx, forum, list, opts...)
+		},
+	}
+}
+
+func (it *UserIterator) Next(ctx context.Context) bool {
+	if len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.page, it.err = it.fetch(ctx, it.list)
+		if it.err != nil || len(it.page) == 0 {
+			return false
+		}
+		it.done = len(it.page) < it.list.Limit
+		it.list.Since = it.page[len(it.page)-1].Nickname
+	}
+	it.user, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+func (it *UserIterator) User() User {
+	return it.user
+}
+
+func (it *UserIterator) Err() error {
+	return it.err
+}
+
+// ThreadIterator skips the threads seen on the previous page, the since of
+// the threads is a creation time and includes the threads created then.
+type ThreadIterator struct {
+	fetch  func(ctx context.Context, list ListOptions) (Threads, error)
+	list   ListOptions
+	page   Threads
+	thread Thread
+	seen   map[int]bool
+	done   bool
+	err    error
+}
+
+// ForumThreadsIter walks the threads of the forum.
+func (c *Client) ForumThreadsIter(forum string, list ListOptions, opts ...CallOption) *ThreadIterator {
+	list.Limit = list.pageLimit()
+	return &ThreadIterator{
+		list: list,
+		seen: make(map[int]bool),
+		fetch: func(ctx context.Context, list ListOptions) (Threads, error) {
+			return c.ForumThreads(ctx, forum, list, opts...)
+		},
+	}
+}
+
+func (it *ThreadIterator) Next(ctx context.Context) bool {
+	for len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		page, err := it.fetch(ctx, it.list)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.done = len(page) < it.list.Limit
+
+		// the threads at the since time were on the previous page
+		it.page = page[:0]
+		for _, thread := range page {
+			if !it.seen[thread.Id] {
+				it.page = append(it.page, thread)
+			}
+		}
+		if len(it.page) == 0 {
+			if !it.done {
+				it.err = ErrPageStuck
+			}
+			return false
+		}
+
+		last := it.page[len(it.page)-1].Created
+		if last != it.list.Since {
+			it.seen = make(map[int]bool)
+		}
+		for _, thread := range it.page {
+			if thread.Created == last {
+				it.seen[thread.Id] = true
+			}
+		}
+		it.list.Since = last
+	}
+	it.thread, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+func (it *ThreadIterator) Thread() Thread {
+	return it.thread
+}
+
+func (it *ThreadIterator) Err() error {
+	return it.err
+}
+
+// PostIterator walks the posts of a thread. With the parent_tree sort a page
+// holds the replies of Limit root posts, so the walk ends on an empty page.
+type PostIterator struct {
+	fetch func(ctx context.Context, list ListOptions) (Posts, error)
+	list  ListOptions
+	page  Posts
+	post  Post
+	done  bool
+	err   error
+}
+
+// ThreadPostsIter walks the posts of the thread.
+func (c *Client) ThreadPostsIter(thread string, list ListOptions, opts ...CallOption) *PostIterator {
+	list.Limit = list.pageLimit()
+	return &PostIterator{
+		list: list,
+		fetch: func(ctx context.Context, list ListOptions) (Posts, error) {
+			return c.ThreadPosts(ctx, thread, list, opts...)
+		},
+	}
+}
+
+func (it *PostIterator) Next(ctx context.Context) bool {
+	if len(it.page) == 0 {
+		if it.done || it.err != nil {
+			return false
+		}
+		it.page, it.err = it.fetch(ctx, it.list)
+		if it.err != nil || len(it.page) == 0 {
+			return false
+		}
+		it.done = it.list.Sort != "parent_tree" && len(it.page) < it.list.Limit
+		it.list.Since = strconv.Itoa(it.page[len(it.page)-1].Id)
+	}
+	it.post, it.page = it.page[0], it.page[1:]
+	return true
+}
+
+func (it *PostIterator) Post() Post {
+	return it.post
+}
+
+func (it *PostIterator) Err() error {
+	return it.err
+}
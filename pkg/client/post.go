@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Post returns the post with the related "user", "forum" and "thread".
+func (c *Client) Post(ctx context.Context, id int, related []string, opts ...CallOption) (*PostDetails, error) {
+	var query url.Values
+	if len(related) > 0 {
+		query = url.Values{"related": {strings.Join(related, ",")}}
+	}
+
+	var details PostDetails
+	cl := &call{method: http.MethodGet, path: "/post/" + strconv.Itoa(id) + "/details", query: query}
+	if err := c.do(ctx, cl, &details, opts); err != nil {
+		return nil, err
+	}
+	return &details, nil
+}
+
+func (c *Client) UpdatePost(ctx context.Context, id int, update UpdatePost, opts ...CallOption) (*Post, error) {
+	var updated Post
+	cl := &call{method: http.MethodPost, path: "/post/" + strconv.Itoa(id) + "/details", in: update}
+	if err := c.do(ctx, cl, &updated, opts); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// SplitPost moves the post and its replies to a new thread. It fails with
+// ErrExists when the slug is taken, the existing thread is in the Existing
+// field of the error.
+func (c *Client) SplitPost(ctx context.Context, id int, split SplitPost, opts ...CallOption) (*Thread, error) {
+	var created Thread
+	cl := &call{method: http.MethodPost, path: "/post/" + strconv.Itoa(id) + "/split", in: split, existing: &Thread{}}
+	if err := c.do(ctx, cl, &created, opts); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// PostReplies returns the replies to the post down to list.Depth levels.
+func (c *Client) PostReplies(ctx context.Context, id int, list ListOptions, opts ...CallOption) (Posts, error) {
+	var posts Posts
+	cl := &call{method: http.MethodGet, path: "/post/" + strconv.Itoa(id) + "/replies", query: list.query()}
+	if err := c.do(ctx, cl, &posts, opts); err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+func (c *Client) PostAncestors(ctx context.Context, id int, opts ...CallOption) (Posts, error) {
+	var posts Posts
+	cl := &call{method: http.MethodGet, path: "/post/" + strconv.Itoa(id) + "/ancestors"}
+	if err := c.do(ctx, cl, &posts, opts); err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
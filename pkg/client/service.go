@@ -0,0 +1,30 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+func (c *Client) Status(ctx context.Context, opts ...CallOption) (*ServStatus, error) {
+	var status ServStatus
+	cl := &call{method: http.MethodGet, path: "/service/status"}
+	if err := c.do(ctx, cl, &status, opts); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// Clear deletes all the data.
+func (c *Client) Clear(ctx context.Context, opts ...CallOption) error {
+	cl := &call{method: http.MethodPost, path: "/service/clear"}
+	return c.do(ctx, cl, nil, opts)
+}
+
+func (c *Client) CacheStatus(ctx context.Context, opts ...CallOption) (*CacheStatus, error) {
+	var status CacheStatus
+	cl := &call{method: http.MethodGet, path: "/service/cache"}
+	if err := c.do(ctx, cl, &status, opts); err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
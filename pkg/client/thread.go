@@ -0,0 +1,91 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// CreatePosts creates the posts in one transaction, thread is a slug or an id.
+func (c *Client) CreatePosts(ctx context.Context, thread string, posts CreatePosts, opts ...CallOption) (Posts, error) {
+	var created Posts
+	cl := &call{method: http.MethodPost, path: "/thread/" + escape(thread) + "/create", in: posts}
+	if err := c.do(ctx, cl, &created, opts); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (c *Client) Vote(ctx context.Context, thread string, vote Vote, opts ...CallOption) (*Thread, error) {
+	var voted Thread
+	cl := &call{method: http.MethodPost, path: "/thread/" + escape(thread) + "/vote", in: vote}
+	if err := c.do(ctx, cl, &voted, opts); err != nil {
+		return nil, err
+	}
+	return &voted, nil
+}
+
+func (c *Client) Thread(ctx context.Context, thread string, opts ...CallOption) (*Thread, error) {
+	var found Thread
+	cl := &call{method: http.MethodGet, path: "/thread/" + escape(thread) + "/details"}
+	if err := c.do(ctx, cl, &found, opts); err != nil {
+		return nil, err
+	}
+	return &found, nil
+}
+
+// UpdateThread changes the title and the message, the empty ones are kept.
+func (c *Client) UpdateThread(ctx context.Context, thread string, update Thread, opts ...CallOption) (*Thread, error) {
+	var updated Thread
+	cl := &call{method: http.MethodPost, path: "/thread/" + escape(thread) + "/details", in: update}
+	if err := c.do(ctx, cl, &updated, opts); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// ThreadPosts returns a page of the posts of the thread, Since is a post id.
+func (c *Client) ThreadPosts(ctx context.Context, thread string, list ListOptions, opts ...CallOption) (Posts, error) {
+	var posts Posts
+	cl := &call{method: http.MethodGet, path: "/thread/" + escape(thread) + "/posts", query: list.query()}
+	if err := c.do(ctx, cl, &posts, opts); err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// ThreadPostsNested returns a page of the posts as a tree of replies. The
+// replies deeper than maxDepth are only counted, 0 returns all.
+func (c *Client) ThreadPostsNested(ctx context.Context, thread string, list ListOptions, maxDepth int, opts ...CallOption) (PostNodes, error) {
+	query := list.query()
+	query.Set("format", "nested")
+	if maxDepth > 0 {
+		query.Set("max_depth", strconv.Itoa(maxDepth))
+	}
+
+	var nodes PostNodes
+	cl := &call{method: http.MethodGet, path: "/thread/" + escape(thread) + "/posts", query: query}
+	if err := c.do(ctx, cl, &nodes, opts); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+func (c *Client) MoveThread(ctx context.Context, thread string, move MoveThread, opts ...CallOption) (*Thread, error) {
+	var moved Thread
+	cl := &call{method: http.MethodPost, path: "/thread/" + escape(thread) + "/move", in: move}
+	if err := c.do(ctx, cl, &moved, opts); err != nil {
+		return nil, err
+	}
+	return &moved, nil
+}
+
+// MergeThread moves the posts of thread to the target and returns the target.
+func (c *Client) MergeThread(ctx context.Context, thread string, merge MergeThread, opts ...CallOption) (*Thread, error) {
+	var target Thread
+	cl := &call{method: http.MethodPost, path: "/thread/" + escape(thread) + "/merge", in: merge}
+	if err := c.do(ctx, cl, &target, opts); err != nil {
+		return nil, err
+	}
+	return &target, nil
+}
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"net/http"
+)
+
+// CreateUser fails with ErrExists when the nickname or the email is taken,
+// the users holding them are in the Existing field of the error.
+func (c *Client) CreateUser(ctx context.Context, nickname string, user CreateUser, opts ...CallOption) (*User, error) {
+	var created User
+	cl := &call{method: http.MethodPost, path: "/user/" + escape(nickname) + "/create", in: user, existing: &Users{}}
+	if err := c.do(ctx, cl, &created, opts); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (c *Client) User(ctx context.Context, nickname string, opts ...CallOption) (*User, error) {
+	var user User
+	cl := &call{method: http.MethodGet, path: "/user/" + escape(nickname) + "/profile"}
+	if err := c.do(ctx, cl, &user, opts); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// UpdateUser changes the profile, the empty fields are kept.
+func (c *Client) UpdateUser(ctx context.Context, nickname string, update UpdateUser, opts ...CallOption) (*User, error) {
+	var updated User
+	cl := &call{method: http.MethodPost, path: "/user/" + escape(nickname) + "/profile", in: update}
+	if err := c.do(ctx, cl, &updated, opts); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (c *Client) RenameUser(ctx context.Context, nickname string, newNickname string, opts ...CallOption) (*User, error) {
+	var renamed User
+	cl := &call{method: http.MethodPost, path: "/user/" + escape(nickname) + "/rename", in: RenameUser{Nickname: newNickname}}
+	if err := c.do(ctx, cl, &renamed, opts); err != nil {
+		return nil, err
+	}
+	return &renamed, nil
+}